@@ -74,6 +74,11 @@ const (
 	SourceRelationshipHyperLink        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipHeader           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/header"
 	SourceRelationshipFooter           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer"
+	SourceRelationshipSettings         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/settings"
+	SourceRelationshipNumbering        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering"
+	SourceRelationshipFootnotes        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes"
+	SourceRelationshipEndnotes         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/endnotes"
+	SourceRelationshipCommentsExtended = "http://schemas.microsoft.com/office/2011/relationships/commentsExtended"
 )
 
 const (
@@ -60,6 +60,44 @@ var NSToLocal = map[string]string{
 
 	// Word Processing Styles
 	"http://schemas.openxmlformats.org/officeDocument/2006/styles": "s",
+
+	// Word Processing Drawing
+	"http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing": "wp",
+	"http://schemas.microsoft.com/office/word/2010/wordprocessingDrawing":    "wp14",
+	"http://schemas.microsoft.com/office/word/2010/wordprocessingShape":      "wps",
+	"http://schemas.microsoft.com/office/word/2010/wordprocessingGroup":      "wpg",
+	"http://schemas.microsoft.com/office/word/2010/wordprocessingCanvas":     "wpc",
+	"http://schemas.microsoft.com/office/word/2010/wordprocessingInk":        "wpi",
+
+	// Microsoft Word Extensions
+	"http://schemas.microsoft.com/office/word/2006/wordml":             "wne",
+	"http://schemas.microsoft.com/office/word/2010/wordml":             "w14",
+	"http://schemas.microsoft.com/office/word/2012/wordml":             "w15",
+	"http://schemas.microsoft.com/office/word/2015/wordml/symex":       "w16se",
+	"http://schemas.microsoft.com/office/word/2016/wordml/cid":         "w16cid",
+	"http://schemas.microsoft.com/office/word/2018/wordml":             "w16",
+	"http://schemas.microsoft.com/office/word/2018/wordml/cex":         "w16cex",
+	"http://schemas.microsoft.com/office/word/2020/wordml/sdtdatahash": "w16sdtdh",
+	"http://schemas.microsoft.com/office/word/2023/wordml/word16du":    "w16du",
+	"http://schemas.openxmlformats.org/officeDocument/2006/math":       "m",
+	"http://schemas.microsoft.com/office/drawing/2014/chartex":         "cx",
+	"http://schemas.microsoft.com/office/drawing/2016/ink":             "aink",
+	"http://schemas.microsoft.com/office/drawing/2017/model3d":         "am3d",
+	"http://schemas.microsoft.com/office/mac/office/2008/main":         "mo",
+	"urn:schemas-microsoft-com:mac:vml":                                "mv",
+	"http://schemas.openxmlformats.org/officeDocument/2006/customXml":  "ds",
+	"http://schemas.openxmlformats.org/schemaLibrary/2006/main":        "sl",
+	"http://schemas.microsoft.com/office/2006/metadata/properties":     "p",
+	"http://schemas.microsoft.com/office/drawing/2012/main":            "a15",
+	"http://schemas.microsoft.com/office/drawing/2010/picture":         "pic14",
+
+	// VML
+	"urn:schemas-microsoft-com:vml":           "v",
+	"urn:schemas-microsoft-com:office:office": "o",
+	"urn:schemas-microsoft-com:office:word":   "w10",
+
+	// XML
+	"http://www.w3.org/XML/1998/namespace": "xml",
 }
 
 // replaceBytes replace source bytes with given target.
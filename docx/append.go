@@ -0,0 +1,742 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// StyleConflict is how AppendDocument handles a style of the appended document whose ID the document
+// already uses for a different definition.
+type StyleConflict int
+
+const (
+	KeepDestinationStyle StyleConflict = iota // The appended content takes the style of the document
+	KeepSourceStyle                           // The style of the appended document replaces the one of the document
+	RenameSourceStyle                         // The style of the appended document is added under a new ID
+)
+
+// AppendOptions are the options of AppendDocument.
+type AppendOptions struct {
+	StyleConflict StyleConflict
+
+	// SectionBreak, if set, starts the appended content in a new section that starts as given, e.g.
+	// stypes.SectionMarkNextPage, with the page setup of the appended document. Otherwise the appended
+	// content continues the last section of the document.
+	SectionBreak stypes.SectionMark
+}
+
+// AppendDocument appends the body of another document to the end of this one. The pictures, hyperlinks
+// and other parts the appended content refers to are copied along, as well as its styles, numbering
+// definitions, footnotes, endnotes and comments, under IDs and names that do not clash with those of
+// this document. Bookmarks whose names are already used are renamed. The other document is not changed.
+//
+// The headers and footers of the other document are not copied: its sections continue with the headers
+// and footers of the section before them.
+//
+// Example:
+//
+//	appendix, err := godocx.OpenDocument("appendix.docx")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	err = document.AppendDocument(appendix, docx.AppendOptions{
+//		StyleConflict: docx.RenameSourceStyle,
+//		SectionBreak:  stypes.SectionMarkNextPage,
+//	})
+func (rd *RootDoc) AppendDocument(other *RootDoc, opts AppendOptions) error {
+	a := newAppender(rd, other, opts)
+
+	a.mergeContentTypes()
+	a.mapNumbering()
+	if err := a.mergeStyles(); err != nil {
+		return fmt.Errorf("appending styles: %w", err)
+	}
+	if err := a.mergeNumbering(); err != nil {
+		return fmt.Errorf("appending numbering: %w", err)
+	}
+	if err := a.mergeNotes(); err != nil {
+		return fmt.Errorf("appending notes: %w", err)
+	}
+	if err := a.mergeComments(); err != nil {
+		return fmt.Errorf("appending comments: %w", err)
+	}
+
+	children, err := a.copyChildren(other.Document.Body.Children, nil, nil)
+	if err != nil {
+		return fmt.Errorf("appending body: %w", err)
+	}
+
+	if opts.SectionBreak != "" {
+		rd.AddSection(opts.SectionBreak)
+		if sectPr := other.Document.Body.SectPr; sectPr != nil {
+			copied := ctypes.NewSectionProper()
+			if err := a.copyElement(sectPr, copied); err != nil {
+				return fmt.Errorf("appending section: %w", err)
+			}
+			copied.Type = ctypes.NewGenSingleStrVal(opts.SectionBreak)
+			rd.Document.Body.SectPr = copied
+		}
+	}
+
+	rd.Document.Body.Children = append(rd.Document.Body.Children, children...)
+	return nil
+}
+
+// appender copies the content of a document into another, renumbering what has to be unique within a
+// document on the way.
+type appender struct {
+	dst, src *RootDoc
+	opts     AppendOptions
+
+	// Relationship IDs, by the relationships of the other document they belong to, nil for its document
+	rels map[*Relationships]map[string]string
+
+	parts     map[string]string // Paths of the copied parts
+	styles    map[string]string // IDs of renamed styles
+	nums      map[string]string // Numbering definition instance IDs
+	abstracts map[string]string // Abstract numbering definition IDs
+	footnotes map[string]string
+	endnotes  map[string]string
+	comments  map[string]string
+
+	bookmarkIDs   map[string]string
+	bookmarkNames map[string]string
+	usedNames     map[string]bool // Bookmark names of the document
+	nextBookmark  int
+
+	paraIDs     map[string]string // Paragraph IDs (w14:paraId)
+	usedParaIDs map[string]bool
+	nextParaID  int
+}
+
+func newAppender(dst, src *RootDoc, opts AppendOptions) *appender {
+	a := &appender{
+		dst:           dst,
+		src:           src,
+		opts:          opts,
+		rels:          map[*Relationships]map[string]string{},
+		parts:         map[string]string{},
+		styles:        map[string]string{},
+		nums:          map[string]string{},
+		abstracts:     map[string]string{},
+		footnotes:     map[string]string{},
+		endnotes:      map[string]string{},
+		comments:      map[string]string{},
+		bookmarkIDs:   map[string]string{},
+		bookmarkNames: map[string]string{},
+		usedNames:     map[string]bool{},
+		paraIDs:       map[string]string{},
+		usedParaIDs:   map[string]bool{},
+		nextBookmark:  dst.nextBookmarkID(),
+		nextParaID:    1,
+	}
+
+	for _, bookmark := range dst.Bookmarks() {
+		a.usedNames[bookmark.Name()] = true
+	}
+
+	collect := func(p *ctypes.Paragraph) {
+		if paraID := getParaID(p); paraID != "" {
+			a.usedParaIDs[paraID] = true
+		}
+	}
+	forEachParagraph(dst.Document.Body.Children, collect)
+	if dst.Comments != nil {
+		for _, comment := range dst.Comments.Comments {
+			forEachParagraph(comment.Children, collect)
+		}
+	}
+
+	return a
+}
+
+// mergeContentTypes adds the default content types of file extensions the document lacks.
+func (a *appender) mergeContentTypes() {
+	for _, def := range a.src.ContentType.Default {
+		if !a.dst.ContentType.hasExtension(def.Extension) {
+			a.dst.ContentType.AddExtension(def.Extension, def.ContentType)
+		}
+	}
+}
+
+// mergeStyles adds the styles of the other document, resolving the IDs both documents use for different
+// definitions as the options say.
+func (a *appender) mergeStyles() error {
+	src, dst := a.src.DocStyles, a.dst.DocStyles
+	if src == nil || dst == nil {
+		return nil
+	}
+
+	index := map[string]int{}
+	used := map[string]bool{}
+	for i, style := range dst.StyleList {
+		if style.ID != nil {
+			index[*style.ID] = i
+			used[*style.ID] = true
+		}
+	}
+	for _, style := range src.StyleList {
+		if style.ID != nil {
+			used[*style.ID] = true
+		}
+	}
+
+	// IDs are settled first, as styles refer to each other
+	type plannedStyle struct {
+		style   *ctypes.Style
+		replace int // Index of the style of the document to replace, -1 to add the style
+	}
+	var planned []plannedStyle
+	for i := range src.StyleList {
+		style := &src.StyleList[i]
+		if style.ID == nil {
+			continue
+		}
+
+		j, exists := index[*style.ID]
+		switch {
+		case !exists:
+			planned = append(planned, plannedStyle{style, -1})
+		case sameXML(style, &dst.StyleList[j]):
+		case a.opts.StyleConflict == KeepSourceStyle:
+			planned = append(planned, plannedStyle{style, j})
+		case a.opts.StyleConflict == RenameSourceStyle:
+			id := *style.ID
+			for n := 1; used[id]; n++ {
+				id = *style.ID + "_" + strconv.Itoa(n)
+			}
+			used[id] = true
+			a.styles[*style.ID] = id
+			planned = append(planned, plannedStyle{style, -1})
+		}
+	}
+
+	for _, plan := range planned {
+		var copied ctypes.Style
+		if err := a.copyElement(plan.style, &copied); err != nil {
+			return err
+		}
+		if plan.replace >= 0 {
+			dst.StyleList[plan.replace] = copied
+			continue
+		}
+		if _, renamed := a.styles[*plan.style.ID]; renamed {
+			// The renamed style is an additional style; its name shows where it comes from
+			copied.Default = nil
+			if copied.Name != nil {
+				copied.Name.Val = copied.Name.Val + strings.TrimPrefix(*copied.ID, *plan.style.ID)
+			}
+		}
+		dst.StyleList = append(dst.StyleList, copied)
+	}
+	return nil
+}
+
+// mapNumbering settles the IDs the numbering definitions of the other document get, which its styles
+// and content refer to.
+func (a *appender) mapNumbering() {
+	src := a.src.Numbering
+	if src == nil || (len(src.AbstractNums) == 0 && len(src.Nums) == 0) {
+		return
+	}
+	dst := a.dst.numbering()
+
+	nextAbstract := 0
+	for _, abstractNum := range dst.AbstractNums {
+		if abstractNum.ID >= nextAbstract {
+			nextAbstract = abstractNum.ID + 1
+		}
+	}
+	nextNum := 1
+	for _, num := range dst.Nums {
+		if num.ID >= nextNum {
+			nextNum = num.ID + 1
+		}
+	}
+	for _, abstractNum := range src.AbstractNums {
+		a.abstracts[strconv.Itoa(abstractNum.ID)] = strconv.Itoa(nextAbstract)
+		nextAbstract++
+	}
+	for _, num := range src.Nums {
+		a.nums[strconv.Itoa(num.ID)] = strconv.Itoa(nextNum)
+		nextNum++
+	}
+}
+
+// mergeNumbering adds the numbering definitions of the other document under the IDs of mapNumbering.
+func (a *appender) mergeNumbering() error {
+	src := a.src.Numbering
+	if src == nil || (len(src.AbstractNums) == 0 && len(src.Nums) == 0) {
+		return nil
+	}
+	dst := a.dst.numbering()
+
+	for _, abstractNum := range src.AbstractNums {
+		copied := &ctypes.AbstractNum{}
+		if err := a.copyElement(abstractNum, copied); err != nil {
+			return err
+		}
+		dst.AbstractNums = append(dst.AbstractNums, copied)
+	}
+	for _, num := range src.Nums {
+		copied := &ctypes.Num{}
+		if err := a.copyElement(num, copied); err != nil {
+			return err
+		}
+		dst.Nums = append(dst.Nums, copied)
+	}
+	return nil
+}
+
+// mergeNotes adds the footnotes and endnotes of the other document under new IDs. Separators are left
+// out, as the document has its own.
+func (a *appender) mergeNotes() error {
+	for _, kind := range []*noteKind{footnoteKind, endnoteKind} {
+		src := *a.src.notesField(kind)
+		if src == nil {
+			continue
+		}
+		ids := a.footnotes
+		if kind == endnoteKind {
+			ids = a.endnotes
+		}
+
+		var notes []*Note
+		for _, note := range src.Notes {
+			if note.Type == nil {
+				notes = append(notes, note)
+			}
+		}
+		if len(notes) == 0 {
+			continue
+		}
+
+		dst := a.dst.notes(kind)
+		next := dst.nextID()
+		for _, note := range notes {
+			ids[strconv.Itoa(note.ID)] = strconv.Itoa(next)
+			next++
+		}
+
+		for _, note := range notes {
+			children, err := a.copyChildren(note.Children, &src.Rels, &dst.Rels)
+			if err != nil {
+				return err
+			}
+			id, _ := strconv.Atoi(ids[strconv.Itoa(note.ID)])
+			dst.Notes = append(dst.Notes, &Note{
+				Root:     a.dst,
+				Children: children,
+				ID:       id,
+				Attr:     note.Attr,
+				kind:     kind,
+				rels:     &dst.Rels,
+			})
+		}
+	}
+	return nil
+}
+
+// mergeComments adds the comments of the other document under new IDs, with their replies and resolved
+// state.
+func (a *appender) mergeComments() error {
+	src := a.src.Comments
+	if src == nil || len(src.Comments) == 0 {
+		return nil
+	}
+
+	dst := a.dst.comments()
+	next := dst.nextID()
+	for _, comment := range src.Comments {
+		a.comments[strconv.Itoa(comment.ID)] = strconv.Itoa(next)
+		next++
+	}
+
+	for _, comment := range src.Comments {
+		children, err := a.copyChildren(comment.Children, &src.Rels, &dst.Rels)
+		if err != nil {
+			return err
+		}
+		id, _ := strconv.Atoi(a.comments[strconv.Itoa(comment.ID)])
+		dst.Comments = append(dst.Comments, &Comment{
+			Root:     a.dst,
+			Children: children,
+			ID:       id,
+			Author:   comment.Author,
+			Initials: comment.Initials,
+			Date:     comment.Date,
+			Attr:     comment.Attr,
+			rels:     &dst.Rels,
+		})
+	}
+
+	if a.src.CommentsExtended == nil {
+		return nil
+	}
+	extended := a.dst.commentsExtended()
+	for _, entry := range a.src.CommentsExtended.Comments {
+		copied := *entry
+		copied.ParaID = a.paraID(entry.ParaID)
+		if entry.ParaIDParent != "" {
+			copied.ParaIDParent = a.paraID(entry.ParaIDParent)
+		}
+		extended.Comments = append(extended.Comments, &copied)
+	}
+	return nil
+}
+
+// copyChildren returns a copy of paragraphs and tables of the other document for this document. The
+// relationships of the content are those of from in the other document, and are added to those of to in
+// this document; nil stands for the document.
+func (a *appender) copyChildren(children []DocumentChild, from, to *Relationships) ([]DocumentChild, error) {
+	data, err := encodeChildren(children)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = a.rewrite(data, from, to); err != nil {
+		return nil, err
+	}
+	return decodeChildren(a.dst, to, data)
+}
+
+// copyElement decodes a copy of an element of the other document for this document into v. Its
+// relationships are those of the document.
+func (a *appender) copyElement(element any, v any) error {
+	data, err := xml.Marshal(element)
+	if err != nil {
+		return err
+	}
+	if data, err = a.rewrite(data, nil, nil); err != nil {
+		return err
+	}
+	d, err := fragmentDecoder(data)
+	if err != nil {
+		return err
+	}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return d.DecodeElement(v, &start)
+		}
+	}
+}
+
+// rewrite returns the XML data written by the library for the other document with the IDs and names of
+// this document, mapping the relationships of from to those of to. Header and footer references are left
+// out.
+func (a *appender) rewrite(data []byte, from, to *Relationships) ([]byte, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	instr := false
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space == "w" && (t.Name.Local == "headerReference" || t.Name.Local == "footerReference") {
+				if err := skipRaw(d); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			instr = t.Name.Space == "w" && t.Name.Local == "instrText"
+			tok = a.element(t, from, to)
+		case xml.EndElement:
+			instr = false
+			tok = xml.EndElement{Name: prefixedName(t.Name)}
+		case xml.CharData:
+			if instr {
+				tok = xml.CharData(a.fieldInstruction(string(t)))
+			}
+		}
+
+		if err := e.EncodeToken(xml.CopyToken(tok)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// skipRaw reads raw tokens up to the end of the element whose start was just read.
+func skipRaw(d *xml.Decoder) error {
+	for depth := 1; depth > 0; {
+		tok, err := d.RawToken()
+		if err != nil {
+			return err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return nil
+}
+
+// element returns the start of an element of the other document with the IDs and names of this document.
+func (a *appender) element(start xml.StartElement, from, to *Relationships) xml.StartElement {
+	name := start.Name
+	attrs := make([]xml.Attr, len(start.Attr))
+	for i, attr := range start.Attr {
+		value := attr.Value
+		switch {
+		case attr.Name.Space == "r":
+			value = a.relation(value, from, to)
+		case attr.Name.Space == "w14" && attr.Name.Local == "paraId":
+			value = a.paraID(value)
+		case attr.Name.Space == "w" && name.Space == "w":
+			value = a.attribute(name.Local, attr.Name.Local, value)
+		}
+		attrs[i] = xml.Attr{Name: prefixedName(attr.Name), Value: value}
+	}
+	return xml.StartElement{Name: prefixedName(name), Attr: attrs}
+}
+
+// attribute returns the value of an attribute of a WordprocessingML element of the other document for
+// this document.
+func (a *appender) attribute(element, attr, value string) string {
+	switch element + "@" + attr {
+	case "pStyle@val", "rStyle@val", "tblStyle@val", "basedOn@val", "next@val", "link@val", "style@styleId",
+		"styleLink@val", "numStyleLink@val":
+		return mapped(a.styles, value)
+	case "numId@val", "num@numId":
+		return mapped(a.nums, value)
+	case "abstractNumId@val", "abstractNum@abstractNumId":
+		return mapped(a.abstracts, value)
+	case "footnoteReference@id":
+		return mapped(a.footnotes, value)
+	case "endnoteReference@id":
+		return mapped(a.endnotes, value)
+	case "commentRangeStart@id", "commentRangeEnd@id", "commentReference@id":
+		return mapped(a.comments, value)
+	case "bookmarkStart@id", "bookmarkEnd@id":
+		return a.bookmarkID(value)
+	case "bookmarkStart@name":
+		return a.bookmarkName(value)
+	case "hyperlink@anchor":
+		return mapped(a.bookmarkNames, value)
+	case "fldSimple@instr":
+		return a.fieldInstruction(value)
+	}
+	return value
+}
+
+// mapped returns the value the IDs map to, or the value itself if it is not mapped.
+func mapped(ids map[string]string, value string) string {
+	if id, ok := ids[value]; ok {
+		return id
+	}
+	return value
+}
+
+func (a *appender) bookmarkID(id string) string {
+	if newID, ok := a.bookmarkIDs[id]; ok {
+		return newID
+	}
+	newID := strconv.Itoa(a.nextBookmark)
+	a.nextBookmark++
+	a.bookmarkIDs[id] = newID
+	return newID
+}
+
+// bookmarkName returns the name of a bookmark of the other document, which gets a number if the
+// document already uses it.
+func (a *appender) bookmarkName(name string) string {
+	if newName, ok := a.bookmarkNames[name]; ok {
+		return newName
+	}
+	newName := name
+	for n := 1; a.usedNames[newName]; n++ {
+		newName = name + "_" + strconv.Itoa(n)
+	}
+	a.usedNames[newName] = true
+	a.bookmarkNames[name] = newName
+	return newName
+}
+
+// fieldInstruction returns the field instruction with the names of renamed bookmarks replaced, as in
+// REF and PAGEREF fields.
+func (a *appender) fieldInstruction(instr string) string {
+	words := strings.Split(instr, " ")
+	for i, word := range words {
+		if newName, ok := a.bookmarkNames[word]; ok {
+			words[i] = newName
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// paraID returns the paragraph ID of a paragraph of the other document, which gets a new ID if the
+// document already uses it.
+func (a *appender) paraID(paraID string) string {
+	if newID, ok := a.paraIDs[paraID]; ok {
+		return newID
+	}
+	newID := paraID
+	for a.usedParaIDs[newID] {
+		newID = fmt.Sprintf("%08X", a.nextParaID)
+		a.nextParaID++
+	}
+	a.usedParaIDs[newID] = true
+	a.paraIDs[paraID] = newID
+	return newID
+}
+
+// relation returns the ID of the relationship of to that stands for the relationship of from with the
+// given ID, adding it and copying the part it targets the first time.
+func (a *appender) relation(id string, from, to *Relationships) string {
+	ids := a.rels[from]
+	if ids == nil {
+		ids = map[string]string{}
+		a.rels[from] = ids
+	}
+	if newID, ok := ids[id]; ok {
+		return newID
+	}
+	rel := a.src.partRelationByID(from, id)
+	if rel == nil {
+		return id
+	}
+
+	target := rel.Target
+	if rel.TargetMode != "External" {
+		target = relativeTarget("word", a.copyPart(partName("word", rel.Target)))
+	}
+
+	newID := a.dst.addPartRelation(to, rel.Type, target, rel.TargetMode)
+	ids[id] = newID
+	return newID
+}
+
+// copyPart copies a part of the other document, with the parts it relates to, and returns its path in
+// this document. Images get the next free media file name; other parts keep their name unless it is taken.
+func (a *appender) copyPart(name string) string {
+	if newName, ok := a.parts[name]; ok {
+		return newName
+	}
+	content, ok := a.src.FileMap.Load(name)
+	if !ok {
+		return name
+	}
+	data, _ := content.([]byte)
+
+	dir, base := path.Split(name)
+	ext := path.Ext(base)
+	newName := name
+	if dir == constants.MediaPath {
+		for {
+			a.dst.ImageCount++
+			newName = fmt.Sprintf("%simage%d%s", constants.MediaPath, a.dst.ImageCount, ext)
+			if _, taken := a.dst.FileMap.Load(newName); !taken {
+				break
+			}
+		}
+	} else {
+		stem := strings.TrimSuffix(base, ext)
+		for n := 1; ; n++ {
+			if _, taken := a.dst.FileMap.Load(newName); !taken {
+				break
+			}
+			newName = fmt.Sprintf("%s%s_%d%s", dir, stem, n, ext)
+		}
+	}
+	a.parts[name] = newName
+	a.dst.FileMap.Store(newName, data)
+
+	for _, override := range a.src.ContentType.Override {
+		if override.PartName == "/"+name {
+			a.dst.ContentType.AddOverride("/"+newName, override.ContentType)
+		}
+	}
+	if ext != "" && !a.dst.ContentType.hasExtension(ext[1:]) {
+		if mime, err := MIMEFromExt(ext); err == nil {
+			a.dst.ContentType.AddExtension(ext[1:], mime)
+		}
+	}
+
+	a.copyPartRelations(name, newName)
+	return newName
+}
+
+// copyPartRelations copies the relationships of a copied part, and the parts they target.
+func (a *appender) copyPartRelations(name, newName string) {
+	relsName := partRelsName(name)
+	content, ok := a.src.FileMap.Load(relsName)
+	if !ok {
+		return
+	}
+
+	rels := Relationships{}
+	if err := xml.Unmarshal(content.([]byte), &rels); err != nil {
+		return
+	}
+	rels.Xmlns = constants.XMLNS
+	for _, rel := range rels.Relationships {
+		if rel.TargetMode != "External" {
+			dir := path.Dir(name)
+			rel.Target = relativeTarget(dir, a.copyPart(partName(dir, rel.Target)))
+		}
+	}
+
+	if data, err := marshal(rels); err == nil {
+		a.dst.FileMap.Store(partRelsName(newName), data)
+	}
+}
+
+// partName returns the path of the part a relationship of a part in the given folder targets.
+func partName(dir, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return target[1:]
+	}
+	return path.Join(dir, target)
+}
+
+// relativeTarget returns the target of a relationship of a part in the given folder to the named part.
+func relativeTarget(dir, name string) string {
+	if strings.HasPrefix(name, dir+"/") {
+		return name[len(dir)+1:]
+	}
+	return "/" + name
+}
+
+// prefixedName returns the name of a raw token as a local name with its prefix, the way the library
+// writes names.
+func prefixedName(name xml.Name) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+	return xml.Name{Local: name.Space + ":" + name.Local}
+}
+
+// sameXML reports whether two elements are written the same.
+func sameXML(a, b any) bool {
+	dataA, errA := xml.Marshal(a)
+	dataB, errB := xml.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
@@ -0,0 +1,193 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/dml"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// appendTestDocs returns a document and another one to append to it, which use the same style ID, bookmark
+// name, picture file name, note ID and comment ID.
+func appendTestDocs(t *testing.T) (dst, src *RootDoc) {
+	img := testPNG(t, 10, 10)
+
+	dst = NewRootDoc()
+	addTestStyle(dst, stypes.StyleTypeParagraph, "Heading1", "", &ctypes.RunProperty{Italic: ctypes.OnOffFromBool(true)})
+	cover := dst.AddParagraph("Cover")
+	cover.Style("Heading1")
+	_, err := cover.AddBookmark("intro")
+	assert.NoError(t, err)
+	cover.AddFootnote("Cover note")
+	cover.AddComment("Jane Doe", "JD", "Cover comment")
+	assert.NoError(t, dst.AddEmptyParagraph().addImageData(img, ".png", "Cover", 0, 0))
+	dst.AddBulletList().AddItem("Cover item", 0)
+
+	src = NewRootDoc()
+	addTestStyle(src, stypes.StyleTypeParagraph, "Heading1", "", &ctypes.RunProperty{Bold: ctypes.OnOffFromBool(true)})
+	addTestStyle(src, stypes.StyleTypeParagraph, "Heading2", "Heading1", nil)
+	intro := src.AddParagraph("Intro")
+	intro.Style("Heading2")
+	_, err = intro.AddBookmark("intro")
+	assert.NoError(t, err)
+	intro.AddFootnote("Intro note")
+	intro.AddComment("John Doe", "JD", "Intro comment").AddReply("Jane Doe", "JD", "Reply")
+
+	body := src.AddParagraph("See ")
+	body.AddInternalLink("the intro", "intro")
+	body.AddRefField("intro", "Intro")
+	body.AddLink(" or the site", "https://example.com")
+	assert.NoError(t, body.addImageData(img, ".png", "Chart", 0, 0))
+	src.AddNumberedList().AddItem("Item", 0)
+	src.LastSection().Orientation(stypes.PageOrientLandscape)
+	return dst, src
+}
+
+func TestRootDoc_AppendDocument(t *testing.T) {
+	dst, src := appendTestDocs(t)
+	srcBody, err := xml.Marshal(src.Document.Body)
+	assert.NoError(t, err)
+
+	err = dst.AppendDocument(src, AppendOptions{StyleConflict: RenameSourceStyle, SectionBreak: stypes.SectionMarkNextPage})
+	assert.NoError(t, err)
+
+	blocks := dst.Blocks()
+	assert.Equal(t, []string{"Cover[1]", "", "•\tCover item", "", "Intro[2]", "See the introIntro or the site", "1.\tItem"}, blockTexts(dst))
+
+	// Styles: the conflicting style is renamed, and the style based on it follows
+	heading := dst.GetStyleByID("Heading1_1", stypes.StyleTypeParagraph)
+	assert.NotNil(t, heading)
+	assert.Equal(t, "Heading1_1", heading.Name.Val)
+	assert.NotNil(t, heading.RunProp.Bold)
+	assert.Equal(t, "Heading1_1", dst.GetStyleByID("Heading2", stypes.StyleTypeParagraph).BasedOn.Val)
+	assert.NotNil(t, dst.GetStyleByID("Heading1", stypes.StyleTypeParagraph).RunProp.Italic)
+
+	// Bookmarks: the name in use gets a number, and the links and fields to it follow
+	intro := blocks[4].(*Paragraph)
+	assert.Equal(t, "intro_1", intro.bookmarkWith("intro"))
+	assert.NotNil(t, dst.Bookmark("intro"))
+	ids := map[int]bool{}
+	for _, bookmark := range dst.Bookmarks() {
+		assert.False(t, ids[bookmark.ct.ID])
+		ids[bookmark.ct.ID] = true
+	}
+	see := blocks[5].(*Paragraph)
+	var links []*ctypes.Hyperlink
+	var drawing *dml.Drawing
+	for _, child := range see.ct.Children {
+		switch {
+		case child.Link != nil:
+			links = append(links, child.Link)
+		case child.Run != nil && child.Run.Children[0].Drawing != nil:
+			drawing = child.Run.Children[0].Drawing
+		}
+	}
+	assert.Equal(t, "intro_1", *links[0].Anchor)
+	output, err := xml.Marshal(see.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "REF intro_1")
+
+	// Notes and comments get IDs that follow those of the document
+	assert.Len(t, intro.Footnotes(), 1)
+	assert.Equal(t, 2, intro.Footnotes()[0].ID)
+	assert.Equal(t, "Intro note", dst.Footnotes.Note(2).Children[0].Para.Text()[1:])
+	assert.Len(t, dst.Comments.Comments, 3)
+	reply := dst.Comments.Comment(2)
+	assert.Equal(t, "Reply", reply.Children[0].Para.Text())
+	assert.Equal(t, dst.Comments.Comment(1), reply.Parent())
+	paraIDs := map[string]bool{}
+	for _, comment := range dst.Comments.Comments {
+		paraID := comment.lastParaID()
+		assert.False(t, paraIDs[paraID])
+		paraIDs[paraID] = true
+	}
+
+	// Pictures and hyperlinks get relationships of the document; the picture gets a new file name
+	embed := drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID
+	assert.Equal(t, "media/image2.png", dst.Document.relationByID(embed).Target)
+	_, stored := dst.FileMap.Load("word/media/image2.png")
+	assert.True(t, stored)
+	assert.True(t, dst.ContentType.hasOverride("/word/media/image2.png"))
+	assert.Equal(t, "https://example.com", dst.Document.relationByID(links[1].ID).Target)
+
+	// The list keeps its own numbering
+	item := blocks[6].(*Paragraph).ct.Property.NumProp.NumID.Val
+	cover := blocks[2].(*Paragraph).ct.Property.NumProp.NumID.Val
+	assert.NotEqual(t, cover, item)
+	assert.Equal(t, stypes.NumFmtDecimal, dst.numbering().Level(item, 0).NumFmt.Val)
+
+	// The appended content starts a section with the page setup of the other document
+	sections := dst.Sections()
+	assert.Len(t, sections, 2)
+	assert.Equal(t, stypes.PageOrientLandscape, sections[1].ct.PageSize.Orient)
+	assert.Equal(t, stypes.SectionMarkNextPage, sections[1].ct.Type.Val)
+
+	// The other document is left as it was
+	after, err := xml.Marshal(src.Document.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, string(srcBody), string(after))
+}
+
+func TestRootDoc_AppendDocument_StyleConflict(t *testing.T) {
+	dst, src := appendTestDocs(t)
+	assert.NoError(t, dst.AppendDocument(src, AppendOptions{}))
+	assert.NotNil(t, dst.GetStyleByID("Heading1", stypes.StyleTypeParagraph).RunProp.Italic)
+	assert.Nil(t, dst.GetStyleByID("Heading1_1", stypes.StyleTypeParagraph))
+	assert.NotNil(t, dst.GetStyleByID("Heading2", stypes.StyleTypeParagraph))
+	assert.Len(t, dst.Sections(), 1)
+
+	dst, src = appendTestDocs(t)
+	assert.NoError(t, dst.AppendDocument(src, AppendOptions{StyleConflict: KeepSourceStyle}))
+	heading := dst.GetStyleByID("Heading1", stypes.StyleTypeParagraph)
+	assert.NotNil(t, heading.RunProp.Bold)
+	assert.Nil(t, heading.RunProp.Italic)
+	assert.Equal(t, 2, len(dst.DocStyles.StyleList))
+}
+
+func TestRootDoc_AppendDocument_PartRelationships(t *testing.T) {
+	img := testPNG(t, 10, 10)
+
+	dst := NewRootDoc()
+	dst.AddParagraph("Cover").AddLink(" site", "https://example.com/cover")
+
+	src := NewRootDoc()
+	p := src.AddParagraph("Body")
+	note := p.AddFootnote("Note")
+	note.AddEmptyParagraph().AddLink("note link", "https://example.com/note")
+	assert.NoError(t, note.AddEmptyParagraph().addImageData(img, ".png", "Note", 0, 0))
+	comment := p.AddComment("Jane Doe", "JD", "Comment")
+	comment.AddParagraph("").AddLink("comment link", "https://example.com/comment")
+
+	assert.NoError(t, dst.AppendDocument(src, AppendOptions{}))
+
+	// The relationships of the notes and comments go to the parts of this document
+	for _, rel := range dst.Document.DocRels.Relationships {
+		assert.NotEqual(t, "https://example.com/note", rel.Target)
+		assert.NotEqual(t, "https://example.com/comment", rel.Target)
+		assert.NotEqual(t, constants.SourceRelationshipImage, rel.Type)
+	}
+	footnotes := dst.notes(footnoteKind)
+	assert.Len(t, footnotes.Rels.Relationships, 2)
+	assert.Len(t, dst.Comments.Rels.Relationships, 1)
+
+	copied := footnotes.Notes[len(footnotes.Notes)-1]
+	var targets []string
+	for _, child := range copied.Children {
+		for _, pc := range child.Para.ct.Children {
+			if pc.Link != nil {
+				targets = append(targets, footnotes.Rels.relationByID(pc.Link.ID).Target)
+			}
+			if pc.Run != nil && len(pc.Run.Children) > 0 && pc.Run.Children[0].Drawing != nil {
+				embed := pc.Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID
+				target := footnotes.Rels.relationByID(embed).Target
+				_, stored := dst.FileMap.Load("word/" + target)
+				assert.True(t, stored)
+			}
+		}
+	}
+	assert.Equal(t, []string{"https://example.com/note"}, targets)
+}
@@ -0,0 +1,392 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// Block is a paragraph or a table of the document body. Blocks are numbered from 0 in document order,
+// leaving out the bookmarks, content controls and other elements between them.
+type Block interface {
+	// documentChild returns the body element that holds the block.
+	documentChild() DocumentChild
+}
+
+func (p *Paragraph) documentChild() DocumentChild {
+	return DocumentChild{Para: p}
+}
+
+func (t *Table) documentChild() DocumentChild {
+	return DocumentChild{Table: t}
+}
+
+var errNotInBody = errors.New("block is not in the document body")
+
+// Blocks returns the paragraphs and tables of the document body in order.
+func (rd *RootDoc) Blocks() []Block {
+	var blocks []Block
+	for _, child := range rd.Document.Body.Children {
+		if block := childBlock(child); block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// BlockAt returns the paragraph or table with the given index in the document body, or nil if there is
+// none.
+//
+// Example:
+//
+//	if p, ok := document.BlockAt(0).(*docx.Paragraph); ok {
+//		p.Style("Title")
+//	}
+func (rd *RootDoc) BlockAt(index int) Block {
+	if index < 0 {
+		return nil
+	}
+	for _, child := range rd.Document.Body.Children {
+		if block := childBlock(child); block != nil {
+			if index == 0 {
+				return block
+			}
+			index--
+		}
+	}
+	return nil
+}
+
+// IndexOf returns the index of the paragraph or table in the document body, or -1 if it is not part of
+// the body.
+func (rd *RootDoc) IndexOf(block Block) int {
+	index := 0
+	for _, child := range rd.Document.Body.Children {
+		if childBlock(child) == nil {
+			continue
+		}
+		if sameBlock(child, block.documentChild()) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// InsertParagraphBefore inserts a new paragraph with the given text before the anchor, a paragraph or
+// table of the document body.
+//
+// Example:
+//
+//	marker := document.BlockAt(3)
+//	p, err := document.InsertParagraphBefore(marker, "Introduction")
+func (rd *RootDoc) InsertParagraphBefore(anchor Block, text string) (*Paragraph, error) {
+	return rd.insertParagraph(anchor, 0, text)
+}
+
+// InsertParagraphAfter inserts a new paragraph with the given text after the anchor, a paragraph or
+// table of the document body.
+func (rd *RootDoc) InsertParagraphAfter(anchor Block, text string) (*Paragraph, error) {
+	return rd.insertParagraph(anchor, 1, text)
+}
+
+// InsertTableBefore inserts a new table before the anchor, a paragraph or table of the document body.
+func (rd *RootDoc) InsertTableBefore(anchor Block) (*Table, error) {
+	return rd.insertTable(anchor, 0)
+}
+
+// InsertTableAfter inserts a new table after the anchor, a paragraph or table of the document body.
+func (rd *RootDoc) InsertTableAfter(anchor Block) (*Table, error) {
+	return rd.insertTable(anchor, 1)
+}
+
+func (rd *RootDoc) insertParagraph(anchor Block, offset int, text string) (*Paragraph, error) {
+	body := rd.Document.Body
+	pos := body.childIndex(anchor)
+	if pos < 0 {
+		return nil, errNotInBody
+	}
+
+	p := newParagraph(rd)
+	if text != "" {
+		p.AddText(text)
+	}
+	body.insertChildren(pos+offset, DocumentChild{Para: p})
+	return p, nil
+}
+
+func (rd *RootDoc) insertTable(anchor Block, offset int) (*Table, error) {
+	body := rd.Document.Body
+	pos := body.childIndex(anchor)
+	if pos < 0 {
+		return nil, errNotInBody
+	}
+
+	tbl := &Table{root: rd, ct: ctypes.DefaultTable()}
+	body.insertChildren(pos+offset, DocumentChild{Table: tbl})
+	return tbl, nil
+}
+
+// MoveTo moves the paragraph within the document body so that it gets the given index, as returned by
+// IndexOf. The move is not tracked as a change.
+func (p *Paragraph) MoveTo(index int) error {
+	return p.root.moveBlock(p, index)
+}
+
+// MoveTo moves the table within the document body so that it gets the given index, as returned by
+// IndexOf. The move is not tracked as a change.
+func (t *Table) MoveTo(index int) error {
+	return t.root.moveBlock(t, index)
+}
+
+func (rd *RootDoc) moveBlock(block Block, index int) error {
+	body := rd.Document.Body
+	pos := body.childIndex(block)
+	if pos < 0 {
+		return errNotInBody
+	}
+
+	count := len(rd.Blocks())
+	if index < 0 || index >= count {
+		return fmt.Errorf("block index %d out of range [0, %d)", index, count)
+	}
+
+	child := body.Children[pos]
+	body.Children = append(body.Children[:pos], body.Children[pos+1:]...)
+	body.insertChildren(body.blockPos(index), child)
+	return nil
+}
+
+// Remove removes the table from the document, along with the relationships of its pictures and
+// hyperlinks that the rest of the document does not use. While changes are tracked, the rows of the
+// table are marked as deleted instead.
+func (t *Table) Remove() {
+	rd := t.root
+	if rd.trackChanges {
+		for _, rowContent := range t.ct.RowContents {
+			if row := rowContent.Row; row != nil {
+				if row.Property == nil {
+					row.Property = &ctypes.RowProperty{}
+				}
+				row.Property.Del = rd.trackChange()
+			}
+		}
+		return
+	}
+
+	if rd.Document.Body.removeTable(t) {
+		rd.releaseRelations(t.ct)
+	}
+}
+
+// childBlock returns the paragraph or table of a body element, or nil for other elements.
+func childBlock(child DocumentChild) Block {
+	switch {
+	case child.Para != nil:
+		return child.Para
+	case child.Table != nil:
+		return child.Table
+	}
+	return nil
+}
+
+// sameBlock reports whether two body elements hold the same paragraph or table. Wrappers of the same
+// content are the same block.
+func sameBlock(a, b DocumentChild) bool {
+	switch {
+	case a.Para != nil && b.Para != nil:
+		return a.Para.ct == b.Para.ct
+	case a.Table != nil && b.Table != nil:
+		return a.Table.ct == b.Table.ct
+	}
+	return false
+}
+
+// childIndex returns the index of the block in the children of the body, or -1 if it is not one of them.
+func (b *Body) childIndex(block Block) int {
+	target := block.documentChild()
+	for i, child := range b.Children {
+		if sameBlock(child, target) {
+			return i
+		}
+	}
+	return -1
+}
+
+// blockPos returns the index in the children of the body at which to insert the block that gets the given
+// block index: before the block that has it now, or after the last block.
+func (b *Body) blockPos(index int) int {
+	pos := 0
+	for i, child := range b.Children {
+		if childBlock(child) == nil {
+			continue
+		}
+		if index == 0 {
+			return i
+		}
+		index--
+		pos = i + 1
+	}
+	return pos
+}
+
+// insertChildren inserts the elements into the children of the body at the given index.
+func (b *Body) insertChildren(pos int, children ...DocumentChild) {
+	b.Children = append(b.Children[:pos], append(children, b.Children[pos:]...)...)
+}
+
+// removeTable removes the table from the body, looking into tables as well, and reports whether it was
+// found.
+func (b *Body) removeTable(t *Table) bool {
+	for i, child := range b.Children {
+		if child.Table == nil {
+			continue
+		}
+		if child.Table.ct == t.ct {
+			b.Children = append(b.Children[:i], b.Children[i+1:]...)
+			return true
+		}
+		if removeTableBlock(child.Table.ct, func(block ctypes.TCBlockContent) bool { return block.Table == t.ct }) {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseRelations removes the picture and hyperlink relationships that the removed content refers to
+// and that no other content of the document uses. The images that no relationship targets anymore are
+// removed from the package. Nothing is removed when the content of the document cannot be scanned.
+func (rd *RootDoc) releaseRelations(removed any) {
+	_ = rd.releaseRelationIDs(relationIDs(removed))
+}
+
+// releaseRelationIDs removes the picture and hyperlink document relationships with the given IDs that the
+// body does not use, along with the images that no relationship targets anymore. When the body cannot be
+// scanned, nothing is removed and the error is returned.
+func (rd *RootDoc) releaseRelationIDs(ids map[string]bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	inUse, err := rd.relationsInUse()
+	if err != nil {
+		return err
+	}
+	doc := rd.Document
+	var released []*Relationship
+	kept := doc.DocRels.Relationships[:0]
+	for _, rel := range doc.DocRels.Relationships {
+		releasable := rel.Type == constants.SourceRelationshipImage || rel.Type == constants.SourceRelationshipHyperLink
+		if releasable && ids[rel.ID] && !inUse[rel.ID] {
+			released = append(released, rel)
+			continue
+		}
+		kept = append(kept, rel)
+	}
+	doc.DocRels.Relationships = kept
+
+	for _, rel := range released {
+		if rel.Type == constants.SourceRelationshipImage && rel.TargetMode == "" {
+			rd.removeMedia(rel.Target)
+		}
+	}
+	return nil
+}
+
+// relationsInUse returns the IDs of the document relationships that the body refers to. The headers,
+// footers, notes and comments have relationships of their own, so their content is left out.
+func (rd *RootDoc) relationsInUse() (map[string]bool, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := marshalDocumentChildren(e, rd.Document.Body.Children); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	return scanRelationIDs(buf.Bytes()), nil
+}
+
+// relationIDs returns the IDs of the relationships that the content refers to.
+func relationIDs(content any) map[string]bool {
+	data, err := xml.Marshal(content)
+	if err != nil {
+		return nil
+	}
+	return scanRelationIDs(data)
+}
+
+// scanRelationIDs returns the values of the attributes of the relationships namespace in the XML data,
+// such as r:id and r:embed.
+func scanRelationIDs(data []byte) map[string]bool {
+	ids := map[string]bool{}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.RawToken()
+		if err != nil {
+			return ids
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			for _, attr := range start.Attr {
+				if attr.Name.Space == "r" && attr.Value != "" {
+					ids[attr.Value] = true
+				}
+			}
+		}
+	}
+}
+
+// removeMedia removes the media file that a document relationship targeted from the package, unless
+// another relationship of the document, or of a header, footer, notes or comments part, still targets it.
+func (rd *RootDoc) removeMedia(target string) {
+	parts := []*Relationships{&rd.Document.DocRels}
+	for _, header := range rd.Headers {
+		parts = append(parts, &header.Rels)
+	}
+	for _, footer := range rd.Footers {
+		parts = append(parts, &footer.Rels)
+	}
+	for _, notes := range []*Notes{rd.Footnotes, rd.Endnotes} {
+		if notes != nil {
+			parts = append(parts, &notes.Rels)
+		}
+	}
+	if rd.Comments != nil {
+		parts = append(parts, &rd.Comments.Rels)
+	}
+
+	loaded := map[string]bool{}
+	for _, rels := range parts {
+		loaded[rels.RelativePath] = true
+		for _, rel := range rels.Relationships {
+			if rel.Target == target && rel.TargetMode == "" {
+				return
+			}
+		}
+	}
+
+	// The relationships of parts that are not loaded, such as those of the notes before they are used
+	partName := path.Join("word", target)
+	used := false
+	rd.FileMap.Range(func(key, value any) bool {
+		name, _ := key.(string)
+		content, _ := value.([]byte)
+		if strings.HasSuffix(name, ".rels") && !loaded[name] && bytes.Contains(content, []byte(target)) {
+			used = true
+			return false
+		}
+		return true
+	})
+	if used {
+		return
+	}
+
+	rd.FileMap.Delete(partName)
+	rd.ContentType.removeOverride("/" + partName)
+}
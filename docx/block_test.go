@@ -0,0 +1,183 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func blockTexts(rd *RootDoc) []string {
+	var texts []string
+	for _, block := range rd.Blocks() {
+		switch b := block.(type) {
+		case *Paragraph:
+			texts = append(texts, b.Text())
+		case *Table:
+			texts = append(texts, "table")
+		}
+	}
+	return texts
+}
+
+func TestRootDoc_InsertParagraph(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Title")
+	// Elements between paragraphs are not blocks
+	rd.Document.Body.Children = append(rd.Document.Body.Children, DocumentChild{Raw: ctypes.NewRawXML()})
+	marker := rd.AddParagraph("{{sections}}")
+	rd.AddParagraph("Signature")
+
+	before, err := rd.InsertParagraphBefore(marker, "Before")
+	assert.NoError(t, err)
+	after, err := rd.InsertParagraphAfter(marker, "After")
+	assert.NoError(t, err)
+	tbl, err := rd.InsertTableAfter(after)
+	assert.NoError(t, err)
+	_, err = rd.InsertParagraphAfter(tbl, "Below the table")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Title", "Before", "{{sections}}", "After", "table", "Below the table", "Signature"}, blockTexts(rd))
+	assert.Equal(t, 1, rd.IndexOf(before))
+	assert.Equal(t, 4, rd.IndexOf(tbl))
+	assert.Equal(t, after.ct, rd.BlockAt(3).(*Paragraph).ct)
+	assert.Nil(t, rd.BlockAt(7))
+	assert.Nil(t, rd.BlockAt(-1))
+
+	// Blocks read from a document are anchors as well
+	_, err = rd.InsertTableBefore(rd.BlockAt(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "table", blockTexts(rd)[0])
+
+	_, err = rd.InsertParagraphAfter(newParagraph(rd), "Nowhere")
+	assert.Error(t, err)
+	assert.Equal(t, -1, rd.IndexOf(newParagraph(rd)))
+}
+
+func TestParagraph_MoveTo(t *testing.T) {
+	rd := NewRootDoc()
+	first := rd.AddParagraph("First")
+	rd.AddTable()
+	last := rd.AddParagraph("Last")
+
+	assert.NoError(t, last.MoveTo(0))
+	assert.Equal(t, []string{"Last", "First", "table"}, blockTexts(rd))
+	assert.NoError(t, first.MoveTo(2))
+	assert.Equal(t, []string{"Last", "table", "First"}, blockTexts(rd))
+	assert.NoError(t, rd.BlockAt(1).(*Table).MoveTo(0))
+	assert.Equal(t, []string{"table", "Last", "First"}, blockTexts(rd))
+
+	assert.Error(t, first.MoveTo(3))
+	assert.Error(t, newParagraph(rd).MoveTo(0))
+}
+
+func TestTable_Remove(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Before")
+	tbl := rd.AddTable()
+	cell := tbl.AddRow().AddCell()
+	nested := &Table{root: rd, ct: ctypes.DefaultTable()}
+	cell.ct.Contents = append(cell.ct.Contents, ctypes.TCBlockContent{Table: nested.ct})
+	rd.AddParagraph("After")
+
+	nested.Remove()
+	assert.Empty(t, cell.ct.Contents)
+
+	rd.TrackChanges("Jane Doe")
+	tbl.Remove()
+	assert.Equal(t, []string{"Before", "table", "After"}, blockTexts(rd))
+	assert.NotNil(t, tbl.ct.RowContents[0].Row.Property.Del)
+
+	rd.StopTrackChanges()
+	tbl.Remove()
+	assert.Equal(t, []string{"Before", "After"}, blockTexts(rd))
+}
+
+func TestRemove_Relations(t *testing.T) {
+	img := testPNG(t, 10, 10)
+
+	rd := NewRootDoc()
+	p := rd.AddParagraph("Logo ")
+	assert.NoError(t, p.addImageData(img, ".png", "Logo", 0, 0))
+	p.AddLink("home", "https://example.com")
+	embed := p.ct.Children[1].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID
+
+	// A copy of the picture keeps the image while it is in the document
+	copied := rd.AddEmptyParagraph()
+	assert.NoError(t, copied.addImageData(img, ".png", "Other", 0, 0))
+	copied.ct.Children[0].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID = embed
+
+	p.Remove()
+	assert.NotNil(t, rd.Document.relationByID(embed))
+	_, stored := rd.FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+
+	copied.Remove()
+	assert.Nil(t, rd.Document.relationByID(embed))
+	_, stored = rd.FileMap.Load("word/media/image1.png")
+	assert.False(t, stored)
+	assert.False(t, rd.ContentType.hasOverride("/word/media/image1.png"))
+	for _, rel := range rd.Document.DocRels.Relationships {
+		assert.NotEqual(t, "https://example.com", rel.Target)
+	}
+	// The image the copy was added with is not referred to and stays
+	_, stored = rd.FileMap.Load("word/media/image2.png")
+	assert.True(t, stored)
+}
+
+func TestRemove_RelationsUnscannable(t *testing.T) {
+	img := testPNG(t, 10, 10)
+
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	assert.NoError(t, p.addImageData(img, ".png", "Logo", 0, 0))
+	embed := p.ct.Children[0].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID
+
+	// Content that cannot be written leaves the relationships in place
+	rd.Document.Body.Children = append(rd.Document.Body.Children, DocumentChild{Raw: &ctypes.RawXML{
+		Tokens: []xml.Token{xml.EndElement{Name: xml.Name{Local: "w:sdt"}}},
+	}})
+	p.Remove()
+	assert.NotNil(t, rd.Document.relationByID(embed))
+	_, stored := rd.FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+	assert.Error(t, rd.releaseRelationIDs(map[string]bool{embed: true}))
+}
+
+func TestRemove_PartRelations(t *testing.T) {
+	img := testPNG(t, 10, 10)
+
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	assert.NoError(t, p.addImageData(img, ".png", "Body", 0, 0))
+	embed := p.ct.Children[0].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID
+
+	// A header picture with the same relationship ID does not keep the one of the document
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	hp := header.AddEmptyParagraph()
+	assert.NoError(t, hp.addImageData(img, ".png", "Header", 0, 0))
+	blip := hp.ct.Children[0].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip
+	header.Rels.relationByID(blip.EmbedID).ID = embed
+	blip.EmbedID = embed
+
+	p.Remove()
+	assert.Nil(t, rd.Document.relationByID(embed))
+	_, stored := rd.FileMap.Load("word/media/image1.png")
+	assert.False(t, stored)
+	assert.NotNil(t, header.Rels.relationByID(embed))
+	_, stored = rd.FileMap.Load("word/media/image2.png")
+	assert.True(t, stored)
+
+	// An image the header still targets stays when the body picture is removed
+	other := rd.AddEmptyParagraph()
+	assert.NoError(t, other.addImageData(img, ".png", "Shared", 0, 0))
+	otherEmbed := other.ct.Children[0].Run.Children[0].Drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID
+	header.Rels.relationByID(embed).Target = rd.Document.relationByID(otherEmbed).Target
+
+	other.Remove()
+	assert.Nil(t, rd.Document.relationByID(otherEmbed))
+	_, stored = rd.FileMap.Load("word/media/image3.png")
+	assert.True(t, stored)
+}
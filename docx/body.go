@@ -1,6 +1,7 @@
 package docx
 
 import (
+	"bytes"
 	"encoding/xml"
 
 	"github.com/mrlijnden/godocx/wml/ctypes"
@@ -15,9 +16,13 @@ type Body struct {
 }
 
 // DocumentChild represents a child element within a Word document, which can be a Paragraph or a Table.
+// Elements that are not modelled by the library are kept in Raw so they are written back unchanged.
 type DocumentChild struct {
-	Para  *Paragraph
-	Table *Table
+	Para      *Paragraph
+	Table     *Table
+	RngMarkup *ctypes.RngMarkupElem // Bookmark start or end between paragraphs and tables
+	Sdt       *ctypes.SdtBlock      // Content control around paragraphs and tables
+	Raw       *ctypes.RawXML
 }
 
 // Use this function to initialize a new Body before adding content to it.
@@ -37,20 +42,8 @@ func (b Body) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 		return err
 	}
 
-	if b.Children != nil {
-		for _, child := range b.Children {
-			if child.Para != nil {
-				if err = child.Para.ct.MarshalXML(e, xml.StartElement{}); err != nil {
-					return err
-				}
-			}
-
-			if child.Table != nil {
-				if err = child.Table.ct.MarshalXML(e, xml.StartElement{}); err != nil {
-					return err
-				}
-			}
-		}
+	if err = marshalDocumentChildren(e, b.Children); err != nil {
+		return err
 	}
 
 	if b.SectPr != nil {
@@ -62,6 +55,84 @@ func (b Body) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
+// marshalDocumentChildren encodes the block-level elements of the body, a header, a footer, a note or
+// a comment in order.
+func marshalDocumentChildren(e *xml.Encoder, children []DocumentChild) (err error) {
+	for _, child := range children {
+		if child.Para != nil {
+			if err = child.Para.ct.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if child.Table != nil {
+			if err = child.Table.ct.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if child.RngMarkup != nil {
+			if err = child.RngMarkup.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if child.Sdt != nil {
+			if err = child.Sdt.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if child.Raw != nil {
+			if err = child.Raw.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// encodeChildren returns the XML data of the block-level elements, written without namespace declarations.
+func encodeChildren(children []DocumentChild) ([]byte, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := marshalDocumentChildren(e, children); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeChildren decodes the block-level elements of XML data written by encodeChildren for the
+// document, as content of the part with the given relationships; nil stands for the document.
+func decodeChildren(rd *RootDoc, rels *Relationships, data []byte) ([]DocumentChild, error) {
+	d, err := fragmentDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []DocumentChild
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch elem := tok.(type) {
+		case xml.StartElement:
+			child, err := unmarshalDocumentChild(rd, rels, d, elem)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		case xml.EndElement:
+			return children, nil
+		}
+	}
+}
+
 // UnmarshalXML implements the xml.Unmarshaler interface for the Body type.
 // It decodes the XML representation of the Body.
 func (body *Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
@@ -74,31 +145,119 @@ func (body *Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err erro
 
 		switch elem := currentToken.(type) {
 		case xml.StartElement:
-			switch elem.Name.Local {
-			case "p":
-				para := newParagraph(body.root)
-				if err := para.unmarshalXML(d, elem); err != nil {
-					return err
-				}
-				body.Children = append(body.Children, DocumentChild{Para: para})
-			case "tbl":
-				tbl := NewTable(body.root)
-				if err := tbl.unmarshalXML(d, elem); err != nil {
-					return err
-				}
-				body.Children = append(body.Children, DocumentChild{Table: tbl})
-			case "sectPr":
+			if elem.Name.Local == "sectPr" {
 				body.SectPr = ctypes.NewSectionProper()
 				if err := d.DecodeElement(body.SectPr, &elem); err != nil {
 					return err
 				}
-			default:
-				if err = d.Skip(); err != nil {
-					return err
-				}
+				continue
 			}
+
+			child, err := unmarshalDocumentChild(body.root, nil, d, elem)
+			if err != nil {
+				return err
+			}
+			body.Children = append(body.Children, child)
 		case xml.EndElement:
 			return nil
 		}
 	}
 }
+
+// unmarshalDocumentChild decodes a block-level element of the body, a header or a footer.
+// Elements other than paragraphs, tables, content controls and bookmarks are kept as RawXML. rels
+// are the relationships of the part, nil for the body.
+func unmarshalDocumentChild(root *RootDoc, rels *Relationships, d *xml.Decoder, elem xml.StartElement) (DocumentChild, error) {
+	switch elem.Name.Local {
+	case "p":
+		para := &Paragraph{root: root, ct: &ctypes.Paragraph{}, rels: rels}
+		if err := para.unmarshalXML(d, elem); err != nil {
+			return DocumentChild{}, err
+		}
+		return DocumentChild{Para: para}, nil
+	case "tbl":
+		tbl := NewTable(root)
+		tbl.rels = rels
+		if err := tbl.unmarshalXML(d, elem); err != nil {
+			return DocumentChild{}, err
+		}
+		return DocumentChild{Table: tbl}, nil
+	case "sdt":
+		sdt := &ctypes.SdtBlock{}
+		if err := sdt.UnmarshalXML(d, elem); err != nil {
+			return DocumentChild{}, err
+		}
+		return DocumentChild{Sdt: sdt}, nil
+	}
+
+	if ctypes.IsRngMarkupElem(elem.Name.Local) {
+		rng := &ctypes.RngMarkupElem{}
+		if err := rng.UnmarshalXML(d, elem); err != nil {
+			return DocumentChild{}, err
+		}
+		return DocumentChild{RngMarkup: rng}, nil
+	}
+
+	raw := ctypes.NewRawXML()
+	if err := raw.UnmarshalXML(d, elem); err != nil {
+		return DocumentChild{}, err
+	}
+	return DocumentChild{Raw: raw}, nil
+}
+
+// forEachParagraph calls fn for every paragraph among the block-level elements, including the paragraphs
+// of tables and content controls, in document order.
+func forEachParagraph(children []DocumentChild, fn func(p *ctypes.Paragraph)) {
+	for _, child := range children {
+		if child.Para != nil {
+			fn(child.Para.ct)
+		}
+		if child.Table != nil {
+			forEachTableParagraph(child.Table.ct, fn)
+		}
+		if child.Sdt != nil {
+			forEachBlockParagraph(child.Sdt.Content, fn)
+		}
+	}
+}
+
+// forEachTableParagraph calls fn for every paragraph of the table, including the paragraphs of nested tables.
+func forEachTableParagraph(tbl *ctypes.Table, fn func(p *ctypes.Paragraph)) {
+	forEachRowParagraph(tbl.RowContents, fn)
+}
+
+func forEachRowParagraph(rowContents []ctypes.RowContent, fn func(p *ctypes.Paragraph)) {
+	for _, rowContent := range rowContents {
+		if rowContent.Sdt != nil {
+			forEachRowParagraph(rowContent.Sdt.Content, fn)
+		}
+		if rowContent.Row != nil {
+			forEachCellParagraph(rowContent.Row.Contents, fn)
+		}
+	}
+}
+
+func forEachCellParagraph(cellContents []ctypes.TRCellContent, fn func(p *ctypes.Paragraph)) {
+	for _, cellContent := range cellContents {
+		if cellContent.Sdt != nil {
+			forEachCellParagraph(cellContent.Sdt.Content, fn)
+		}
+		if cellContent.Cell != nil {
+			forEachBlockParagraph(cellContent.Cell.Contents, fn)
+		}
+	}
+}
+
+func forEachBlockParagraph(blocks []ctypes.TCBlockContent, fn func(p *ctypes.Paragraph)) {
+	for _, block := range blocks {
+		if block.Paragraph != nil {
+			fn(block.Paragraph)
+		}
+		if block.Table != nil {
+			forEachTableParagraph(block.Table, fn)
+		}
+		if block.Sdt != nil {
+			forEachBlockParagraph(block.Sdt.Content, fn)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestBody_PreservesUnknownContent(t *testing.T) {
+	input := `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:sdt><w:sdtPr><w:tag w:val="name"/></w:sdtPr><w:sdtContent><w:p><w:r><w:t>Inside</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+		`<w:p><w:r><w:t>After</w:t></w:r></w:p>` +
+		`<w:bookmarkEnd w:id="0"/>` +
+		`</w:body>`
+
+	body := NewBody(nil)
+	if err := xml.Unmarshal([]byte(input), body); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(body.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(body.Children))
+	}
+	if body.Children[0].Sdt == nil || body.Children[0].Sdt.Property.Tag.Val != "name" {
+		t.Errorf("Expected first child to be a content control")
+	}
+	if body.Children[1].Para == nil {
+		t.Errorf("Expected second child to be a paragraph")
+	}
+	if body.Children[2].RngMarkup == nil || body.Children[2].RngMarkup.BookmarkEnd == nil {
+		t.Errorf("Expected third child to be a bookmark end")
+	}
+
+	var result strings.Builder
+	encoder := xml.NewEncoder(&result)
+	if err := body.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	encoder.Flush()
+
+	expected := `<w:body>` +
+		`<w:sdt><w:sdtPr><w:tag w:val="name"></w:tag></w:sdtPr><w:sdtContent><w:p><w:r><w:t>Inside</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+		`<w:p><w:r><w:t>After</w:t></w:r></w:p>` +
+		`<w:bookmarkEnd w:id="0"></w:bookmarkEnd>` +
+		`</w:body>`
+
+	if result.String() != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, result.String())
+	}
+}
+
+func TestDocument_PreservesRootAttributes(t *testing.T) {
+	input := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" ` +
+		`xmlns:w16se="http://schemas.microsoft.com/office/word/2015/wordml/symex" mc:Ignorable="w16se">` +
+		`<w:body></w:body></w:document>`
+
+	doc := Document{}
+	if err := xml.Unmarshal([]byte(input), &doc); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	var result strings.Builder
+	encoder := xml.NewEncoder(&result)
+	if err := doc.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	encoder.Flush()
+
+	for _, exp := range []string{
+		`xmlns:w16se="http://schemas.microsoft.com/office/word/2015/wordml/symex"`,
+		`mc:Ignorable="w16se"`,
+		`xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing"`,
+	} {
+		if !strings.Contains(result.String(), exp) {
+			t.Errorf("Expected XML part not found in actual XML:\nExpected part: %s\nActual XML: %s", exp, result.String())
+		}
+	}
+
+	if strings.Count(result.String(), "mc:Ignorable") != 1 {
+		t.Errorf("Expected a single mc:Ignorable attribute, got: %s", result.String())
+	}
+}
@@ -0,0 +1,367 @@
+package docx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// tocBookmarkPrefix is the prefix of the hidden bookmarks Word puts on headings listed in a table of contents.
+const tocBookmarkPrefix = "_Toc"
+
+// maxBookmarkName is the longest bookmark name Word accepts.
+const maxBookmarkName = 40
+
+// Bookmark represents a named location of the document, the target of internal links and of REF and
+// PAGEREF fields.
+type Bookmark struct {
+	root *RootDoc
+	ct   *ctypes.BookmarkStart
+	para *Paragraph // paragraph that holds the start of the bookmark, nil if unknown
+}
+
+// GetCT returns a pointer to the underlying bookmark start element.
+func (b *Bookmark) GetCT() *ctypes.BookmarkStart {
+	return b.ct
+}
+
+// Name returns the name of the bookmark.
+func (b *Bookmark) Name() string {
+	return b.ct.Name
+}
+
+// Paragraph returns the paragraph in which the bookmark starts. It is nil for bookmarks that start
+// between paragraphs, or in a table of an opened document.
+func (b *Bookmark) Paragraph() *Paragraph {
+	return b.para
+}
+
+// Text returns the text the bookmark spans, paragraphs separated by line feeds. Deleted text is left out.
+func (b *Bookmark) Text() string {
+	var text strings.Builder
+	inside, started := false, false
+
+	b.root.walkMarkup(func(item markupItem) bool {
+		switch {
+		case item.start == b.ct:
+			inside = true
+		case item.end != nil && inside && item.end.ID == b.ct.ID:
+			return false
+		case !inside:
+		case item.run != nil:
+			text.WriteString(runText(item.run))
+			started = true
+		case item.paraEnd && started:
+			text.WriteString("\n")
+		}
+		return true
+	})
+
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+// AddBookmark adds a bookmark that spans the content of the paragraph.
+//
+// Parameters:
+//   - name: The name of the bookmark, up to 40 letters, digits and underscores starting with a letter.
+//     Names starting with an underscore are hidden bookmarks.
+//
+// Returns:
+//   - *Bookmark: The newly created Bookmark instance.
+//   - error: An error if the name is not valid or already used in the document.
+func (p *Paragraph) AddBookmark(name string) (*Bookmark, error) {
+	if err := validBookmarkName(name); err != nil {
+		return nil, err
+	}
+	if p.root.Bookmark(name) != nil {
+		return nil, fmt.Errorf("bookmark %q already exists", name)
+	}
+
+	return p.addBookmark(name), nil
+}
+
+func (p *Paragraph) addBookmark(name string) *Bookmark {
+	start := ctypes.NewBookmarkStart(p.root.nextBookmarkID(), name)
+
+	p.ct.Children = append([]ctypes.ParagraphChild{{BookmarkStart: start}}, p.ct.Children...)
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{BookmarkEnd: ctypes.NewMarkupRange(start.ID)})
+
+	return &Bookmark{root: p.root, ct: start, para: p}
+}
+
+func validBookmarkName(name string) error {
+	if name == "" || len(name) > maxBookmarkName {
+		return fmt.Errorf("bookmark name must be 1 to %d characters long", maxBookmarkName)
+	}
+	for i, r := range name {
+		if i == 0 && !unicode.IsLetter(r) && r != '_' {
+			return fmt.Errorf("bookmark name %q must start with a letter", name)
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return fmt.Errorf("bookmark name %q may only hold letters, digits and underscores", name)
+		}
+	}
+	return nil
+}
+
+// AddInternalLink adds a hyperlink to a bookmark of the document.
+//
+// Example:
+//
+//	p := document.AddParagraph("See ")
+//	p.AddInternalLink("Installation", "installation")
+//
+// Parameters:
+//   - text: The text of the link.
+//   - bookmark: The name of the bookmark the link points to.
+//
+// Returns:
+//   - *Hyperlink: The newly created Hyperlink instance.
+func (p *Paragraph) AddInternalLink(text string, bookmark string) *Hyperlink {
+	run := &ctypes.Run{
+		Children: []ctypes.RunChild{{Text: ctypes.TextFromString(text)}},
+		Property: &ctypes.RunProperty{
+			Style: &ctypes.CTString{
+				Val: constants.HyperLinkStyle,
+			},
+		},
+	}
+
+	hyperLink := &ctypes.Hyperlink{
+		Anchor: &bookmark,
+		Run:    run,
+	}
+
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Link: hyperLink})
+
+	return newHyperlink(p.root, p.rels, hyperLink)
+}
+
+// Bookmark returns the bookmark with the given name, or nil if the document has none.
+func (rd *RootDoc) Bookmark(name string) *Bookmark {
+	for _, bookmark := range rd.Bookmarks() {
+		if bookmark.Name() == name {
+			return bookmark
+		}
+	}
+	return nil
+}
+
+// Bookmarks returns the bookmarks of the document body, in document order, including hidden bookmarks
+// such as those of a table of contents.
+func (rd *RootDoc) Bookmarks() []*Bookmark {
+	var bookmarks []*Bookmark
+	rd.walkMarkup(func(item markupItem) bool {
+		if item.start != nil {
+			bookmarks = append(bookmarks, &Bookmark{root: rd, ct: item.start, para: item.para})
+		}
+		return true
+	})
+	return bookmarks
+}
+
+// nextBookmarkID returns an ID that is not used by any bookmark of the document body.
+func (rd *RootDoc) nextBookmarkID() int {
+	id := 0
+	rd.walkMarkup(func(item markupItem) bool {
+		switch {
+		case item.start != nil && item.start.ID >= id:
+			id = item.start.ID + 1
+		case item.end != nil && item.end.ID >= id:
+			id = item.end.ID + 1
+		}
+		return true
+	})
+	return id
+}
+
+// newBookmarkName returns a name made of the prefix and a number that is not used by any bookmark of
+// the document.
+func (rd *RootDoc) newBookmarkName(prefix string) string {
+	names := map[string]bool{}
+	for _, bookmark := range rd.Bookmarks() {
+		names[bookmark.Name()] = true
+	}
+
+	for n := 0; ; n++ {
+		name := fmt.Sprintf("%s%09d", prefix, n)
+		if !names[name] {
+			return name
+		}
+	}
+}
+
+// bookmarkWith returns the name of the first bookmark of the paragraph whose name has the prefix, or an
+// empty string.
+func (p *Paragraph) bookmarkWith(prefix string) string {
+	for _, child := range p.ct.Children {
+		if child.BookmarkStart != nil && strings.HasPrefix(child.BookmarkStart.Name, prefix) {
+			return child.BookmarkStart.Name
+		}
+	}
+	return ""
+}
+
+// tocBookmark returns the name of the bookmark that spans the paragraph for a table of contents,
+// adding one if there is none.
+func (p *Paragraph) tocBookmark() string {
+	if name := p.bookmarkWith(tocBookmarkPrefix); name != "" {
+		return name
+	}
+	return p.addBookmark(p.root.newBookmarkName(tocBookmarkPrefix)).Name()
+}
+
+// markupItem is an element met while walking the document body: a bookmark start or end, a run, or the
+// end of a paragraph.
+type markupItem struct {
+	start   *ctypes.BookmarkStart
+	end     *ctypes.MarkupRange
+	run     *ctypes.Run
+	paraEnd bool
+	para    *Paragraph // paragraph holding the item, nil if unknown
+}
+
+// walkMarkup calls fn for the bookmarks, runs and paragraph ends of the document body in document order,
+// until fn returns false.
+func (rd *RootDoc) walkMarkup(fn func(item markupItem) bool) {
+	for _, child := range rd.Document.Body.Children {
+		var more bool
+		switch {
+		case child.Para != nil:
+			more = walkParagraphMarkup(child.Para.ct, child.Para, fn)
+		case child.Table != nil:
+			more = walkTableMarkup(child.Table.ct, fn)
+		case child.RngMarkup != nil:
+			more = walkRngMarkup(child.RngMarkup, fn)
+		case child.Sdt != nil:
+			more = walkBlockMarkup(child.Sdt.Content, fn)
+		default:
+			more = true
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+func walkRngMarkup(rng *ctypes.RngMarkupElem, fn func(item markupItem) bool) bool {
+	return fn(markupItem{start: rng.BookmarkStart, end: rng.BookmarkEnd})
+}
+
+func walkParagraphMarkup(p *ctypes.Paragraph, para *Paragraph, fn func(item markupItem) bool) bool {
+	var walk func(children []ctypes.ParagraphChild) bool
+	walk = func(children []ctypes.ParagraphChild) bool {
+		for _, child := range children {
+			more := true
+			switch {
+			case child.BookmarkStart != nil:
+				more = fn(markupItem{start: child.BookmarkStart, para: para})
+			case child.BookmarkEnd != nil:
+				more = fn(markupItem{end: child.BookmarkEnd, para: para})
+			case child.Run != nil:
+				more = fn(markupItem{run: child.Run, para: para})
+			case child.Link != nil:
+				if child.Link.Run != nil {
+					more = fn(markupItem{run: child.Link.Run, para: para})
+				}
+				more = more && walk(child.Link.Children)
+			case child.Ins != nil:
+				more = walk(child.Ins.Children)
+			case child.MoveTo != nil:
+				more = walk(child.MoveTo.Children)
+			case child.Sdt != nil:
+				more = walk(child.Sdt.Content)
+			}
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+
+	return walk(p.Children) && fn(markupItem{paraEnd: true, para: para})
+}
+
+func walkTableMarkup(tbl *ctypes.Table, fn func(item markupItem) bool) bool {
+	for i := range tbl.RngMarkupElems {
+		if !walkRngMarkup(&tbl.RngMarkupElems[i], fn) {
+			return false
+		}
+	}
+	return walkRowMarkup(tbl.RowContents, fn)
+}
+
+func walkRowMarkup(rowContents []ctypes.RowContent, fn func(item markupItem) bool) bool {
+	for _, rowContent := range rowContents {
+		more := true
+		switch {
+		case rowContent.RngMarkup != nil:
+			more = walkRngMarkup(rowContent.RngMarkup, fn)
+		case rowContent.Sdt != nil:
+			more = walkRowMarkup(rowContent.Sdt.Content, fn)
+		case rowContent.Row != nil:
+			more = walkCellMarkup(rowContent.Row.Contents, fn)
+		}
+		if !more {
+			return false
+		}
+	}
+	return true
+}
+
+func walkCellMarkup(cellContents []ctypes.TRCellContent, fn func(item markupItem) bool) bool {
+	for _, cellContent := range cellContents {
+		more := true
+		switch {
+		case cellContent.RngMarkup != nil:
+			more = walkRngMarkup(cellContent.RngMarkup, fn)
+		case cellContent.Sdt != nil:
+			more = walkCellMarkup(cellContent.Sdt.Content, fn)
+		case cellContent.Cell != nil:
+			more = walkBlockMarkup(cellContent.Cell.Contents, fn)
+		}
+		if !more {
+			return false
+		}
+	}
+	return true
+}
+
+func walkBlockMarkup(blocks []ctypes.TCBlockContent, fn func(item markupItem) bool) bool {
+	for _, block := range blocks {
+		more := true
+		switch {
+		case block.Paragraph != nil:
+			more = walkParagraphMarkup(block.Paragraph, nil, fn)
+		case block.Table != nil:
+			more = walkTableMarkup(block.Table, fn)
+		case block.RngMarkup != nil:
+			more = walkRngMarkup(block.RngMarkup, fn)
+		case block.Sdt != nil:
+			more = walkBlockMarkup(block.Sdt.Content, fn)
+		}
+		if !more {
+			return false
+		}
+	}
+	return true
+}
+
+// runText returns the text of the run, with tabs and breaks as "\t" and "\n".
+func runText(run *ctypes.Run) string {
+	var text strings.Builder
+	for _, child := range run.Children {
+		switch {
+		case child.Text != nil:
+			text.WriteString(child.Text.Text)
+		case child.Tab != nil:
+			text.WriteString("\t")
+		case child.Break != nil:
+			text.WriteString("\n")
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,83 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraph_AddBookmark(t *testing.T) {
+	rd := NewRootDoc()
+	heading, _ := rd.AddHeading("Installation", 1)
+
+	bookmark, err := heading.AddBookmark("installation")
+	assert.NoError(t, err)
+	assert.Equal(t, "installation", bookmark.Name())
+	assert.Same(t, heading, bookmark.Paragraph())
+	assert.Equal(t, "Installation", bookmark.Text())
+
+	output, err := xml.Marshal(heading.ct)
+	assert.NoError(t, err)
+	assert.Equal(t, `<w:p><w:pPr><w:pStyle w:val="Heading1"></w:pStyle></w:pPr>`+
+		`<w:bookmarkStart w:id="0" w:name="installation"></w:bookmarkStart>`+
+		`<w:r><w:t>Installation</w:t></w:r>`+
+		`<w:bookmarkEnd w:id="0"></w:bookmarkEnd></w:p>`, string(output))
+
+	_, err = rd.AddParagraph("Again").AddBookmark("installation")
+	assert.Error(t, err)
+	_, err = rd.AddParagraph("Invalid").AddBookmark("1st step")
+	assert.Error(t, err)
+
+	other, err := rd.AddParagraph("Usage").AddBookmark("usage")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, other.GetCT().ID)
+}
+
+func TestParagraph_AddInternalLink(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddParagraph("See ")
+	link := p.AddInternalLink("Installation", "installation")
+
+	assert.Equal(t, "installation", link.Anchor())
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	assert.Equal(t, `<w:p><w:r><w:t xml:space="preserve">See </w:t></w:r>`+
+		`<w:hyperlink w:anchor="installation"><w:r><w:rPr><w:rStyle w:val="Hyperlink"></w:rStyle></w:rPr>`+
+		`<w:t>Installation</w:t></w:r></w:hyperlink></w:p>`, string(output))
+}
+
+func TestRootDoc_Bookmarks(t *testing.T) {
+	input := `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:bookmarkStart w:id="3" w:name="chapter"/>` +
+		`<w:p><w:r><w:t>First</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t xml:space="preserve">Second </w:t></w:r><w:bookmarkStart w:id="5" w:name="_Ref1"/>` +
+		`<w:r><w:t>part</w:t></w:r><w:bookmarkEnd w:id="5"/></w:p>` +
+		`<w:bookmarkEnd w:id="3"/>` +
+		`<w:tbl><w:tblPr/><w:tblGrid/><w:tr><w:tc><w:p><w:bookmarkStart w:id="7" w:name="cell"/>` +
+		`<w:r><w:t>In table</w:t></w:r><w:bookmarkEnd w:id="7"/></w:p></w:tc></w:tr></w:tbl>` +
+		`</w:body>`
+
+	rd := NewRootDoc()
+	if err := xml.Unmarshal([]byte(input), rd.Document.Body); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	bookmarks := rd.Bookmarks()
+	assert.Len(t, bookmarks, 3)
+
+	chapter := rd.Bookmark("chapter")
+	assert.Nil(t, chapter.Paragraph())
+	assert.Equal(t, "First\nSecond part", chapter.Text())
+
+	ref := rd.Bookmark("_Ref1")
+	assert.Same(t, rd.Document.Body.Children[2].Para, ref.Paragraph())
+	assert.Equal(t, "part", ref.Text())
+
+	cell := rd.Bookmark("cell")
+	assert.Nil(t, cell.Paragraph())
+	assert.Equal(t, "In table", cell.Text())
+
+	assert.Nil(t, rd.Bookmark("missing"))
+	assert.Equal(t, 8, rd.nextBookmarkID())
+}
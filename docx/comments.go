@@ -0,0 +1,732 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+const (
+	commentsFileName         = "word/comments.xml"
+	commentsExtendedFileName = "word/commentsExtended.xml"
+)
+
+var commentsAttrs = map[string]string{
+	"xmlns:w":      "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r":      "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:wp":     "http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing",
+	"xmlns:w14":    "http://schemas.microsoft.com/office/word/2010/wordml",
+	"xmlns:mc":     "http://schemas.openxmlformats.org/markup-compatibility/2006",
+	"mc:Ignorable": "w14",
+}
+
+// Comments represents the comments part (word/comments.xml) of a document.
+type Comments struct {
+	Root     *RootDoc
+	Comments []*Comment
+
+	// Attributes of the root element of an opened part (namespace declarations, mc:Ignorable, ...)
+	Attr []xml.Attr
+
+	// Relationships of the part, e.g. to the images and hyperlinks of the comments
+	Rels Relationships
+
+	filename string
+}
+
+// Comment represents a comment. It is anchored to a range of the document by comment range markers
+// and a reference mark that carry its ID. Like the body of the document, its content is made of
+// paragraphs and tables.
+type Comment struct {
+	Root     *RootDoc
+	Children []DocumentChild
+
+	ID       int
+	Author   string
+	Initials string
+	Date     string // Date and time the comment was made, e.g. 2024-05-01T10:00:00Z; empty if unknown
+
+	// Attributes that are not modelled, kept for round trip
+	Attr []xml.Attr
+
+	rels *Relationships // relationships of the comments part
+}
+
+func newComments(root *RootDoc, filename string) *Comments {
+	return &Comments{
+		Root:     root,
+		filename: filename,
+		Rels:     newPartRels(filename),
+	}
+}
+
+// Comment returns the comment with the given ID, or nil if there is none.
+func (cs *Comments) Comment(id int) *Comment {
+	for _, comment := range cs.Comments {
+		if comment.ID == id {
+			return comment
+		}
+	}
+	return nil
+}
+
+// nextID returns an ID that is not used by any comment of the part.
+func (cs *Comments) nextID() int {
+	id := 0
+	for _, comment := range cs.Comments {
+		if comment.ID >= id {
+			id = comment.ID + 1
+		}
+	}
+	return id
+}
+
+// MarshalXML implements the xml.Marshaler interface for the Comments type
+func (cs Comments) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:comments"
+
+	declared := make(map[string]bool, len(cs.Attr))
+	for _, attr := range cs.Attr {
+		declared[attr.Name.Local] = true
+		start.Attr = append(start.Attr, attr)
+	}
+
+	for key, value := range commentsAttrs {
+		if declared[key] {
+			continue
+		}
+		attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
+		start.Attr = append(start.Attr, attr)
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, comment := range cs.Comments {
+		if err := comment.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the Comments type
+func (cs *Comments) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	cs.Attr = ctypes.QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			if elem.Name.Local != "comment" {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			comment := &Comment{Root: cs.Root, rels: &cs.Rels}
+			if err = comment.UnmarshalXML(d, elem); err != nil {
+				return err
+			}
+			cs.Comments = append(cs.Comments, comment)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXML implements the xml.Marshaler interface for the Comment type
+func (c Comment) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:comment"
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(c.ID)})
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:author"}, Value: c.Author})
+	if c.Date != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:date"}, Value: c.Date})
+	}
+	if c.Initials != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:initials"}, Value: c.Initials})
+	}
+	start.Attr = append(start.Attr, c.Attr...)
+
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	if err = marshalDocumentChildren(e, c.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the Comment type
+func (c *Comment) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return err
+			}
+			c.ID = id
+		case "author":
+			c.Author = attr.Value
+		case "initials":
+			c.Initials = attr.Value
+		case "date":
+			c.Date = attr.Value
+		default:
+			attrs = append(attrs, attr)
+		}
+	}
+	c.Attr = ctypes.QualifyAttrs(attrs)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			child, err := unmarshalDocumentChild(c.Root, c.rels, d, elem)
+			if err != nil {
+				return err
+			}
+			c.Children = append(c.Children, child)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// AddComment adds a comment with the given author, initials and text that covers the whole paragraph.
+// The comment is dated now; use Comment.SetDate to change that.
+//
+// Example:
+//
+//	p := document.AddParagraph("The total is 42.")
+//	p.AddComment("Jane Doe", "JD", "Please double-check this figure.")
+func (p *Paragraph) AddComment(author, initials, text string) *Comment {
+	comment := p.root.addComment(author, initials, text)
+
+	children := []ctypes.ParagraphChild{{CmntRangeStart: ctypes.NewMarkupRange(comment.ID)}}
+	children = append(children, p.ct.Children...)
+	children = append(children,
+		ctypes.ParagraphChild{CmntRangeEnd: ctypes.NewMarkupRange(comment.ID)},
+		ctypes.ParagraphChild{Run: p.root.commentReference(comment.ID)},
+	)
+	p.ct.Children = children
+
+	return comment
+}
+
+// AddComment adds a comment with the given author, initials and text that covers the run.
+// The comment is dated now; use Comment.SetDate to change that.
+//
+// Example:
+//
+//	p := document.AddParagraph("Revenue grew ")
+//	p.AddText("by 12%").AddComment("Jane Doe", "JD", "Source?")
+func (r *Run) AddComment(author, initials, text string) *Comment {
+	comment := r.root.addComment(author, initials, text)
+
+	if r.parent == nil {
+		r.ct.Children = append(r.ct.Children, ctypes.RunChild{CmntRef: &ctypes.Markup{ID: comment.ID}})
+		return comment
+	}
+
+	children := r.parent.ct.Children
+	for i, child := range children {
+		if child.Run != r.ct {
+			continue
+		}
+
+		anchored := make([]ctypes.ParagraphChild, 0, len(children)+3)
+		anchored = append(anchored, children[:i]...)
+		anchored = append(anchored,
+			ctypes.ParagraphChild{CmntRangeStart: ctypes.NewMarkupRange(comment.ID)},
+			child,
+			ctypes.ParagraphChild{CmntRangeEnd: ctypes.NewMarkupRange(comment.ID)},
+			ctypes.ParagraphChild{Run: r.root.commentReference(comment.ID)},
+		)
+		anchored = append(anchored, children[i+1:]...)
+		r.parent.ct.Children = anchored
+		break
+	}
+
+	return comment
+}
+
+// AddReply adds a comment with the given author, initials and text that replies to the comment. The reply
+// covers the same range of the document.
+func (c *Comment) AddReply(author, initials, text string) *Comment {
+	rd := c.Root
+	reply := rd.addComment(author, initials, text)
+
+	forEachParagraph(rd.Document.Body.Children, func(p *ctypes.Paragraph) {
+		var children []ctypes.ParagraphChild
+		for _, child := range p.Children {
+			children = append(children, child)
+			switch {
+			case child.CmntRangeStart != nil && child.CmntRangeStart.ID == c.ID:
+				children = append(children, ctypes.ParagraphChild{CmntRangeStart: ctypes.NewMarkupRange(reply.ID)})
+			case child.CmntRangeEnd != nil && child.CmntRangeEnd.ID == c.ID:
+				children = append(children, ctypes.ParagraphChild{CmntRangeEnd: ctypes.NewMarkupRange(reply.ID)})
+			case child.Run != nil && runReferencesComment(child.Run, c.ID):
+				children = append(children, ctypes.ParagraphChild{Run: rd.commentReference(reply.ID)})
+			}
+		}
+		p.Children = children
+	})
+
+	extended := rd.commentsExtended()
+	parentID := c.paraID()
+	if extended.Get(parentID) == nil {
+		extended.Comments = append(extended.Comments, &ctypes.CommentEx{ParaID: parentID})
+	}
+	extended.Comments = append(extended.Comments, &ctypes.CommentEx{ParaID: reply.paraID(), ParaIDParent: parentID})
+
+	return reply
+}
+
+// Parent returns the comment this comment replies to, or nil if it is not a reply.
+func (c *Comment) Parent() *Comment {
+	entry := c.extended()
+	if entry == nil || entry.ParaIDParent == "" {
+		return nil
+	}
+
+	for _, comment := range c.Root.Comments.Comments {
+		if comment.lastParaID() == entry.ParaIDParent {
+			return comment
+		}
+	}
+	return nil
+}
+
+// Replies returns the comments that reply to this comment, in order.
+func (c *Comment) Replies() []*Comment {
+	var replies []*Comment
+	for _, comment := range c.Root.Comments.Comments {
+		if comment != c && comment.Parent() == c {
+			replies = append(replies, comment)
+		}
+	}
+	return replies
+}
+
+// SetResolved marks the comment as resolved (done) or as open again.
+func (c *Comment) SetResolved(resolved bool) *Comment {
+	extended := c.Root.commentsExtended()
+	paraID := c.paraID()
+
+	entry := extended.Get(paraID)
+	if entry == nil {
+		entry = &ctypes.CommentEx{ParaID: paraID}
+		extended.Comments = append(extended.Comments, entry)
+	}
+
+	done := stypes.OnOffZero
+	if resolved {
+		done = stypes.OnOffOne
+	}
+	entry.Done = &done
+
+	return c
+}
+
+// Resolved reports whether the comment is marked as resolved.
+func (c *Comment) Resolved() bool {
+	entry := c.extended()
+	return entry != nil && entry.Done != nil && entry.Done.ToBool()
+}
+
+// SetDate sets the date and time the comment was made.
+func (c *Comment) SetDate(date time.Time) *Comment {
+	c.Date = date.UTC().Format(time.RFC3339)
+	return c
+}
+
+// SetText replaces the content of the comment with a single paragraph holding the given text.
+func (c *Comment) SetText(text string) *Comment {
+	paraID := c.lastParaID()
+
+	c.Children = nil
+	p := c.addParagraph(text)
+	if paraID != "" {
+		setParaID(p.ct, paraID)
+	}
+
+	return c
+}
+
+// AddParagraph adds a paragraph with the given text to the comment.
+func (c *Comment) AddParagraph(text string) *Paragraph {
+	// The last paragraph identifies the comment in commentsExtended.xml, so its ID moves to the new one
+	paraID := c.lastParaID()
+	for _, child := range c.Children {
+		if child.Para != nil {
+			removeParaID(child.Para.ct)
+		}
+	}
+
+	p := c.newParagraph()
+	p.ct.AddText(text)
+	c.Children = append(c.Children, DocumentChild{Para: p})
+
+	if paraID != "" {
+		setParaID(p.ct, paraID)
+	} else {
+		setParaID(p.ct, c.Root.newParaID())
+	}
+	return p
+}
+
+// Delete removes the comment, its replies and its markers from the document.
+func (c *Comment) Delete() {
+	c.Root.DeleteComment(c.ID)
+}
+
+// DeleteComment removes the comment with the given ID, its replies and its markers from the document.
+// It does nothing if there is no such comment.
+func (rd *RootDoc) DeleteComment(id int) {
+	if rd.Comments == nil {
+		return
+	}
+
+	comment := rd.Comments.Comment(id)
+	if comment == nil {
+		return
+	}
+
+	for _, reply := range comment.Replies() {
+		rd.DeleteComment(reply.ID)
+	}
+
+	removeCommentMarkers(rd.Document.Body, id)
+
+	if rd.CommentsExtended != nil {
+		rd.CommentsExtended.Remove(comment.lastParaID())
+	}
+
+	for i, existing := range rd.Comments.Comments {
+		if existing == comment {
+			rd.Comments.Comments = append(rd.Comments.Comments[:i], rd.Comments.Comments[i+1:]...)
+			break
+		}
+	}
+}
+
+// addParagraph adds the first paragraph of a new comment: the annotation mark followed by the text.
+func (c *Comment) addParagraph(text string) *Paragraph {
+	p := c.newParagraph()
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{
+		Run: &ctypes.Run{Property: c.Root.commentReferenceProp(), Children: []ctypes.RunChild{{AnnotationRef: &ctypes.Empty{}}}},
+	})
+	if text != "" {
+		p.ct.AddText(text)
+	}
+	c.Children = append(c.Children, DocumentChild{Para: p})
+	return p
+}
+
+// newParagraph returns an empty paragraph for the comment, in the style Word uses for comments when the
+// document defines it. The content of comments is never tracked as a revision.
+func (c *Comment) newParagraph() *Paragraph {
+	p := &Paragraph{root: c.Root, ct: &ctypes.Paragraph{}, rels: c.rels}
+	if c.Root.GetStyleByID("CommentText", stypes.StyleTypeParagraph) != nil {
+		p.ct.Property = &ctypes.ParagraphProp{Style: ctypes.NewParagraphStyle("CommentText")}
+	}
+	return p
+}
+
+// paraID returns the paragraph ID of the comment's last paragraph, which identifies the comment in
+// commentsExtended.xml. A comment without one gets a new ID.
+func (c *Comment) paraID() string {
+	if paraID := c.lastParaID(); paraID != "" {
+		return paraID
+	}
+
+	var last *Paragraph
+	for _, child := range c.Children {
+		if child.Para != nil {
+			last = child.Para
+		}
+	}
+	if last == nil {
+		last = c.addParagraph("")
+	}
+
+	paraID := c.Root.newParaID()
+	setParaID(last.ct, paraID)
+	return paraID
+}
+
+// lastParaID returns the paragraph ID of the comment's last paragraph, or "" if it has none.
+func (c *Comment) lastParaID() string {
+	paraID := ""
+	for _, child := range c.Children {
+		if child.Para != nil {
+			paraID = getParaID(child.Para.ct)
+		}
+	}
+	return paraID
+}
+
+// extended returns the entry of the comment in commentsExtended.xml, or nil if there is none.
+func (c *Comment) extended() *ctypes.CommentEx {
+	if c.Root.CommentsExtended == nil {
+		return nil
+	}
+	paraID := c.lastParaID()
+	if paraID == "" {
+		return nil
+	}
+	return c.Root.CommentsExtended.Get(paraID)
+}
+
+// addComment adds a comment to the comments part, dated now.
+func (rd *RootDoc) addComment(author, initials, text string) *Comment {
+	part := rd.comments()
+
+	comment := &Comment{
+		Root:     rd,
+		ID:       part.nextID(),
+		Author:   author,
+		Initials: initials,
+		rels:     &part.Rels,
+	}
+	comment.SetDate(time.Now())
+
+	p := comment.addParagraph(text)
+	setParaID(p.ct, rd.newParaID())
+
+	part.Comments = append(part.Comments, comment)
+	return comment
+}
+
+// commentReference returns a run holding the reference mark of the comment.
+func (rd *RootDoc) commentReference(id int) *ctypes.Run {
+	return &ctypes.Run{
+		Property: rd.commentReferenceProp(),
+		Children: []ctypes.RunChild{{CmntRef: &ctypes.Markup{ID: id}}},
+	}
+}
+
+// commentReferenceProp returns the formatting of comment marks: the character style Word uses for them
+// when the document defines it, none otherwise.
+func (rd *RootDoc) commentReferenceProp() *ctypes.RunProperty {
+	if rd.GetStyleByID("CommentReference", stypes.StyleTypeCharacter) != nil {
+		return &ctypes.RunProperty{Style: ctypes.NewRunStyle("CommentReference")}
+	}
+	return nil
+}
+
+// runReferencesComment reports whether the run holds the reference mark of the comment.
+func runReferencesComment(run *ctypes.Run, id int) bool {
+	for _, child := range run.Children {
+		if child.CmntRef != nil && child.CmntRef.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// removeCommentMarkers removes the range markers and reference marks of the comment from the body.
+func removeCommentMarkers(body *Body, id int) {
+	isMarker := func(raw *ctypes.RawXML) bool {
+		name := raw.Name()
+		if name != "w:commentRangeStart" && name != "w:commentRangeEnd" {
+			return false
+		}
+		value, _ := raw.Attr("w:id")
+		return value == strconv.Itoa(id)
+	}
+
+	children := body.Children[:0]
+	for _, child := range body.Children {
+		if child.Raw == nil || !isMarker(child.Raw) {
+			children = append(children, child)
+		}
+	}
+	body.Children = children
+
+	forEachParagraph(body.Children, func(p *ctypes.Paragraph) {
+		children := p.Children[:0]
+		for _, child := range p.Children {
+			switch {
+			case child.CmntRangeStart != nil && child.CmntRangeStart.ID == id:
+				continue
+			case child.CmntRangeEnd != nil && child.CmntRangeEnd.ID == id:
+				continue
+			case child.Raw != nil && isMarker(child.Raw):
+				continue
+			case child.Run != nil && runReferencesComment(child.Run, id):
+				runChildren := child.Run.Children[:0]
+				for _, runChild := range child.Run.Children {
+					if runChild.CmntRef == nil || runChild.CmntRef.ID != id {
+						runChildren = append(runChildren, runChild)
+					}
+				}
+				child.Run.Children = runChildren
+				if len(runChildren) == 0 {
+					continue
+				}
+			}
+			children = append(children, child)
+		}
+		p.Children = children
+	})
+}
+
+// getParaID returns the paragraph ID (w14:paraId) of the paragraph, or "" if it has none.
+func getParaID(p *ctypes.Paragraph) string {
+	for _, attr := range p.Attr {
+		if attr.Name.Local == "w14:paraId" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// setParaID sets the paragraph ID (w14:paraId) of the paragraph.
+func setParaID(p *ctypes.Paragraph, paraID string) {
+	for i, attr := range p.Attr {
+		if attr.Name.Local == "w14:paraId" {
+			p.Attr[i].Value = paraID
+			return
+		}
+	}
+	p.Attr = append(p.Attr, xml.Attr{Name: xml.Name{Local: "w14:paraId"}, Value: paraID})
+}
+
+// removeParaID removes the paragraph ID (w14:paraId) of the paragraph.
+func removeParaID(p *ctypes.Paragraph) {
+	for i, attr := range p.Attr {
+		if attr.Name.Local == "w14:paraId" {
+			p.Attr = append(p.Attr[:i], p.Attr[i+1:]...)
+			return
+		}
+	}
+}
+
+// newParaID returns a paragraph ID that is not used by the paragraphs of the body or of the comments.
+// Paragraph IDs are 8 hexadecimal digits below 80000000.
+func (rd *RootDoc) newParaID() string {
+	used := map[string]bool{}
+	collect := func(p *ctypes.Paragraph) {
+		if paraID := getParaID(p); paraID != "" {
+			used[paraID] = true
+		}
+	}
+
+	forEachParagraph(rd.Document.Body.Children, collect)
+	if rd.Comments != nil {
+		for _, comment := range rd.Comments.Comments {
+			forEachParagraph(comment.Children, collect)
+		}
+	}
+
+	for i := 1; ; i++ {
+		paraID := fmt.Sprintf("%08X", i)
+		if !used[paraID] {
+			return paraID
+		}
+	}
+}
+
+// comments returns the comments part of the document. Documents without one get a new part, which is
+// registered in the document relationships and content types.
+func (rd *RootDoc) comments() *Comments {
+	if rd.Comments != nil {
+		return rd.Comments
+	}
+
+	if content, ok := rd.FileMap.Load(commentsFileName); ok {
+		if part, err := LoadCommentsXml(rd, commentsFileName, content.([]byte)); err == nil && rd.loadPartRels(&part.Rels) == nil {
+			rd.Comments = part
+			return part
+		}
+	}
+
+	rd.Comments = newComments(rd, commentsFileName)
+	if rd.Document.relationByType(constants.SourceRelationshipComments) == nil {
+		rd.Document.addRelation(constants.SourceRelationshipComments, "comments.xml")
+	}
+	if !rd.ContentType.hasOverride("/" + commentsFileName) {
+		rd.ContentType.AddOverride("/"+commentsFileName, "application/vnd.openxmlformats-officedocument.wordprocessingml.comments+xml")
+	}
+
+	return rd.Comments
+}
+
+// commentsExtended returns the extended comment information of the document, which holds replies and the
+// resolved state. Documents without it get a new part, which is registered in the document relationships
+// and content types.
+func (rd *RootDoc) commentsExtended() *ctypes.CommentsEx {
+	if rd.CommentsExtended != nil {
+		return rd.CommentsExtended
+	}
+
+	if content, ok := rd.FileMap.Load(commentsExtendedFileName); ok {
+		if part, err := LoadCommentsExtended(commentsExtendedFileName, content.([]byte)); err == nil {
+			rd.CommentsExtended = part
+			return part
+		}
+	}
+
+	rd.CommentsExtended = ctypes.NewCommentsEx(commentsExtendedFileName)
+	if rd.Document.relationByType(constants.SourceRelationshipCommentsExtended) == nil {
+		rd.Document.addRelation(constants.SourceRelationshipCommentsExtended, "commentsExtended.xml")
+	}
+	if !rd.ContentType.hasOverride("/" + commentsExtendedFileName) {
+		rd.ContentType.AddOverride("/"+commentsExtendedFileName, "application/vnd.openxmlformats-officedocument.wordprocessingml.commentsExtended+xml")
+	}
+
+	return rd.CommentsExtended
+}
+
+// LoadCommentsXml decodes the comments part of an opened document.
+//
+// Parameters:
+//   - rd: The root document the comments belong to.
+//   - fileName: The path of the part within the package, e.g. "word/comments.xml".
+//   - fileBytes: The XML data of the part.
+//
+// Returns:
+//   - *Comments: The decoded comments.
+//   - error: An error, if any occurred during the decoding process.
+func LoadCommentsXml(rd *RootDoc, fileName string, fileBytes []byte) (*Comments, error) {
+	cs := newComments(rd, fileName)
+	if err := xml.Unmarshal(fileBytes, cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// Load commentsExtended.xml into CommentsEx struct
+func LoadCommentsExtended(fileName string, fileBytes []byte) (*ctypes.CommentsEx, error) {
+	commentsEx := ctypes.NewCommentsEx(fileName)
+	err := xml.Unmarshal(fileBytes, commentsEx)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentsEx, nil
+}
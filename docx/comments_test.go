@@ -0,0 +1,202 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraph_AddComment(t *testing.T) {
+	rd := NewRootDoc()
+
+	p := rd.AddParagraph("The total is 42.")
+	comment := p.AddComment("Jane Doe", "JD", "Please double-check this figure.")
+	comment.SetDate(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+
+	assert.NotNil(t, rd.Comments)
+	assert.Nil(t, rd.CommentsExtended)
+	assert.NotNil(t, rd.Document.relationByType(constants.SourceRelationshipComments))
+	assert.True(t, rd.ContentType.hasOverride("/word/comments.xml"))
+	assert.Equal(t, 0, comment.ID)
+	assert.Same(t, comment, rd.Comments.Comment(0))
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:commentRangeStart w:id="0"></w:commentRangeStart>`+
+		`<w:r><w:t>The total is 42.</w:t></w:r>`+
+		`<w:commentRangeEnd w:id="0"></w:commentRangeEnd>`+
+		`<w:r><w:commentReference w:id="0"></w:commentReference></w:r></w:p>`)
+
+	output, err = xml.Marshal(rd.Comments)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:comment w:id="0" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z" w:initials="JD">`+
+		`<w:p w14:paraId="00000001"><w:r><w:annotationRef></w:annotationRef></w:r>`+
+		`<w:r><w:t>Please double-check this figure.</w:t></w:r></w:p></w:comment>`)
+}
+
+func TestRun_AddComment(t *testing.T) {
+	rd := NewRootDoc()
+
+	p := rd.AddParagraph("Revenue grew ")
+	run := p.AddText("by 12%")
+	tail := p.AddText(" last year.")
+	comment := run.AddComment("Jane Doe", "JD", "Source?")
+
+	children := p.ct.Children
+	assert.Len(t, children, 6)
+	assert.Equal(t, comment.ID, children[1].CmntRangeStart.ID)
+	assert.Same(t, run.ct, children[2].Run)
+	assert.Equal(t, comment.ID, children[3].CmntRangeEnd.ID)
+	assert.Equal(t, comment.ID, children[4].Run.Children[0].CmntRef.ID)
+	assert.Same(t, tail.ct, children[5].Run)
+}
+
+func TestComment_Replies(t *testing.T) {
+	rd := NewRootDoc()
+
+	p := rd.AddParagraph("The total is 42.")
+	comment := p.AddComment("Jane Doe", "JD", "Please double-check this figure.")
+	reply := comment.AddReply("John Smith", "JS", "Checked, it is correct.")
+	comment.SetResolved(true)
+
+	assert.NotNil(t, rd.CommentsExtended)
+	assert.NotNil(t, rd.Document.relationByType(constants.SourceRelationshipCommentsExtended))
+	assert.True(t, rd.ContentType.hasOverride("/word/commentsExtended.xml"))
+
+	assert.Equal(t, 1, reply.ID)
+	assert.Same(t, comment, reply.Parent())
+	assert.Nil(t, comment.Parent())
+	assert.Equal(t, []*Comment{reply}, comment.Replies())
+	assert.True(t, comment.Resolved())
+	assert.False(t, reply.Resolved())
+
+	// The reply shares the range of the comment it replies to
+	children := p.ct.Children
+	assert.Len(t, children, 7)
+	assert.Equal(t, 0, children[0].CmntRangeStart.ID)
+	assert.Equal(t, 1, children[1].CmntRangeStart.ID)
+	assert.Equal(t, 0, children[3].CmntRangeEnd.ID)
+	assert.Equal(t, 1, children[4].CmntRangeEnd.ID)
+	assert.Equal(t, 0, children[5].Run.Children[0].CmntRef.ID)
+	assert.Equal(t, 1, children[6].Run.Children[0].CmntRef.ID)
+
+	output, err := xml.Marshal(rd.CommentsExtended)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w15:commentEx w15:paraId="00000001" w15:done="1"></w15:commentEx>`+
+		`<w15:commentEx w15:paraId="00000002" w15:paraIdParent="00000001"></w15:commentEx>`)
+
+	comment.SetResolved(false)
+	assert.False(t, comment.Resolved())
+}
+
+func TestComment_SetText(t *testing.T) {
+	rd := NewRootDoc()
+
+	comment := rd.AddParagraph("Text").AddComment("Jane Doe", "JD", "First")
+	comment.AddReply("John Smith", "JS", "Reply")
+	comment.SetText("Edited")
+
+	assert.Len(t, comment.Children, 1)
+	assert.Equal(t, "00000001", comment.lastParaID())
+	assert.Len(t, comment.Replies(), 1)
+
+	output, err := xml.Marshal(comment)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:r><w:t>Edited</w:t></w:r>`)
+
+	p := comment.AddParagraph("Second paragraph")
+	assert.Equal(t, "00000001", getParaID(p.ct))
+	assert.Equal(t, "", getParaID(comment.Children[0].Para.ct))
+	assert.Len(t, comment.Replies(), 1)
+}
+
+func TestComment_Delete(t *testing.T) {
+	rd := NewRootDoc()
+
+	p := rd.AddParagraph("The total is 42.")
+	kept := p.AddComment("Jane Doe", "JD", "Kept")
+	comment := p.AddComment("Jane Doe", "JD", "Removed")
+	comment.AddReply("John Smith", "JS", "Removed too")
+
+	comment.Delete()
+
+	assert.Equal(t, []*Comment{kept}, rd.Comments.Comments)
+	assert.Empty(t, rd.CommentsExtended.Comments)
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	assert.Equal(t, `<w:p><w:commentRangeStart w:id="0"></w:commentRangeStart>`+
+		`<w:r><w:t>The total is 42.</w:t></w:r>`+
+		`<w:commentRangeEnd w:id="0"></w:commentRangeEnd>`+
+		`<w:r><w:commentReference w:id="0"></w:commentReference></w:r></w:p>`, string(output))
+
+	// Unknown IDs are ignored
+	rd.DeleteComment(42)
+	assert.Len(t, rd.Comments.Comments, 1)
+}
+
+func TestLoadCommentsXml(t *testing.T) {
+	input := `<w:comments xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+		`<w:comment w:id="3" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z" w:initials="JD">` +
+		`<w:p w14:paraId="1A2B3C4D"><w:r><w:annotationRef/></w:r><w:r><w:t>Existing</w:t></w:r></w:p></w:comment>` +
+		`</w:comments>`
+
+	rd := NewRootDoc()
+	comments, err := LoadCommentsXml(rd, "word/comments.xml", []byte(input))
+	assert.NoError(t, err)
+	rd.Comments = comments
+
+	comment := comments.Comment(3)
+	assert.Equal(t, "Jane Doe", comment.Author)
+	assert.Equal(t, "JD", comment.Initials)
+	assert.Equal(t, "2024-05-01T10:00:00Z", comment.Date)
+	assert.Equal(t, "1A2B3C4D", comment.lastParaID())
+
+	// New comments continue after the existing ones, in the same part
+	added := rd.AddParagraph("More").AddComment("John Smith", "JS", "Added")
+	assert.Equal(t, 4, added.ID)
+	assert.Same(t, comments, rd.Comments)
+	assert.Nil(t, rd.Document.relationByType(constants.SourceRelationshipComments))
+
+	output, err := xml.Marshal(comments)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:comment w:id="3" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z" w:initials="JD">`+
+		`<w:p w14:paraId="1A2B3C4D"><w:r><w:annotationRef></w:annotationRef></w:r><w:r><w:t>Existing</w:t></w:r></w:p></w:comment>`)
+}
+
+func TestComments_Relationships(t *testing.T) {
+	rd := NewRootDoc()
+	docRels := len(rd.Document.DocRels.Relationships)
+
+	comment := rd.AddParagraph("Revenue grew.").AddComment("Jane Doe", "JD", "Source:")
+	link := comment.AddParagraph("").AddLink("the report", "https://example.com/report")
+	assert.Len(t, rd.Document.DocRels.Relationships, docRels+1) // the comments part
+	assert.Len(t, rd.Comments.Rels.Relationships, 1)
+
+	target, err := link.Target()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/report", target)
+
+	var buf bytes.Buffer
+	assert.NoError(t, rd.Write(&buf))
+	data, ok := rd.FileMap.Load("word/_rels/comments.xml.rels")
+	assert.True(t, ok)
+	assert.Contains(t, string(data.([]byte)), `Target="https://example.com/report" TargetMode="External"`)
+
+	// Parts left in the package are read with their relationships
+	commentsData, _ := rd.FileMap.Load("word/comments.xml")
+	opened := NewRootDoc()
+	opened.FileMap.Store("word/comments.xml", commentsData)
+	opened.FileMap.Store("word/_rels/comments.xml.rels", data)
+
+	links := opened.comments().Comment(0).Children[1].Para.Hyperlinks()
+	assert.Len(t, links, 1)
+	target, err = links[0].Target()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/report", target)
+}
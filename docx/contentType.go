@@ -42,6 +42,37 @@ func (c *ContentTypes) AddOverride(partName, contentType string) error {
 	return nil
 }
 
+// hasOverride reports whether the content type of the given part is already overridden.
+func (c *ContentTypes) hasOverride(partName string) bool {
+	for _, override := range c.Override {
+		if override.PartName == partName {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExtension reports whether the file extension has a default content type.
+func (c *ContentTypes) hasExtension(extension string) bool {
+	for _, def := range c.Default {
+		if strings.EqualFold(def.Extension, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeOverride removes the content type override of the given part.
+func (c *ContentTypes) removeOverride(partName string) {
+	kept := c.Override[:0]
+	for _, override := range c.Override {
+		if override.PartName != partName {
+			kept = append(kept, override)
+		}
+	}
+	c.Override = kept
+}
+
 func MIMEFromExt(extension string) (string, error) {
 	if strings.HasPrefix(extension, ".") {
 		extension = strings.TrimPrefix(extension, ".")
@@ -0,0 +1,529 @@
+package docx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// ContentControlType is the kind of a content control, which sets the values SetValue accepts.
+type ContentControlType int
+
+const (
+	ContentControlRichText     ContentControlType = iota // Formatted text, the default
+	ContentControlText                                   // Plain text
+	ContentControlComboBox                               // List of values that also accepts any text
+	ContentControlDropDownList                           // List of values
+	ContentControlDate                                   // Date picker
+	ContentControlCheckbox                               // Checkbox
+	ContentControlPicture                                // Picture
+	ContentControlGroup                                  // Group of controls, protecting the content around them
+)
+
+const (
+	checkboxFont             = "MS Gothic"
+	checkboxCheckedChar      = "2612" // ☒
+	checkboxUncheckedChar    = "2610" // ☐
+	contentControlDateLayout = "2006-01-02"
+)
+
+// ContentControl is a structured document tag (w:sdt), a region of the document such as a form field
+// that is identified by its tag. It wraps paragraphs and tables, table rows, table cells or runs within
+// a paragraph.
+type ContentControl struct {
+	root  *RootDoc
+	block *ctypes.SdtBlock
+	run   *ctypes.SdtRun
+	row   *ctypes.SdtRow
+	cell  *ctypes.SdtCell
+}
+
+// ContentControl returns the first content control with the given tag, or nil if the document has none.
+func (rd *RootDoc) ContentControl(tag string) *ContentControl {
+	for _, control := range rd.ContentControls() {
+		if control.Tag() == tag {
+			return control
+		}
+	}
+	return nil
+}
+
+// ContentControls returns the content controls of the document body, headers and footers, in document
+// order. Controls nested in another control follow it.
+func (rd *RootDoc) ContentControls() []*ContentControl {
+	collector := controlCollector{root: rd}
+	collector.documentChildren(rd.Document.Body.Children)
+	for _, header := range rd.Headers {
+		collector.documentChildren(header.Children)
+	}
+	for _, footer := range rd.Footers {
+		collector.documentChildren(footer.Children)
+	}
+	return collector.controls
+}
+
+// controlCollector gathers the content controls of block-level content.
+type controlCollector struct {
+	root     *RootDoc
+	controls []*ContentControl
+}
+
+func (c *controlCollector) documentChildren(children []DocumentChild) {
+	for _, child := range children {
+		switch {
+		case child.Para != nil:
+			c.paragraphChildren(child.Para.ct.Children)
+		case child.Table != nil:
+			c.rows(child.Table.ct.RowContents)
+		case child.Sdt != nil:
+			c.controls = append(c.controls, &ContentControl{root: c.root, block: child.Sdt})
+			c.blocks(child.Sdt.Content)
+		}
+	}
+}
+
+func (c *controlCollector) blocks(blocks []ctypes.TCBlockContent) {
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			c.paragraphChildren(block.Paragraph.Children)
+		case block.Table != nil:
+			c.rows(block.Table.RowContents)
+		case block.Sdt != nil:
+			c.controls = append(c.controls, &ContentControl{root: c.root, block: block.Sdt})
+			c.blocks(block.Sdt.Content)
+		}
+	}
+}
+
+func (c *controlCollector) rows(rowContents []ctypes.RowContent) {
+	for _, rowContent := range rowContents {
+		switch {
+		case rowContent.Row != nil:
+			c.cells(rowContent.Row.Contents)
+		case rowContent.Sdt != nil:
+			c.controls = append(c.controls, &ContentControl{root: c.root, row: rowContent.Sdt})
+			c.rows(rowContent.Sdt.Content)
+		}
+	}
+}
+
+func (c *controlCollector) cells(cellContents []ctypes.TRCellContent) {
+	for _, cellContent := range cellContents {
+		switch {
+		case cellContent.Cell != nil:
+			c.blocks(cellContent.Cell.Contents)
+		case cellContent.Sdt != nil:
+			c.controls = append(c.controls, &ContentControl{root: c.root, cell: cellContent.Sdt})
+			c.cells(cellContent.Sdt.Content)
+		}
+	}
+}
+
+func (c *controlCollector) paragraphChildren(children []ctypes.ParagraphChild) {
+	for _, child := range children {
+		switch {
+		case child.Sdt != nil:
+			c.controls = append(c.controls, &ContentControl{root: c.root, run: child.Sdt})
+			c.paragraphChildren(child.Sdt.Content)
+		case child.Link != nil:
+			c.paragraphChildren(child.Link.Children)
+		case child.Ins != nil:
+			c.paragraphChildren(child.Ins.Children)
+		case child.MoveTo != nil:
+			c.paragraphChildren(child.MoveTo.Children)
+		}
+	}
+}
+
+// propertyRef returns the field holding the properties of the control.
+func (c *ContentControl) propertyRef() **ctypes.SdtProperty {
+	switch {
+	case c.block != nil:
+		return &c.block.Property
+	case c.run != nil:
+		return &c.run.Property
+	case c.row != nil:
+		return &c.row.Property
+	default:
+		return &c.cell.Property
+	}
+}
+
+// Property returns the properties of the control, creating them if the control has none.
+func (c *ContentControl) Property() *ctypes.SdtProperty {
+	ref := c.propertyRef()
+	if *ref == nil {
+		*ref = &ctypes.SdtProperty{}
+	}
+	return *ref
+}
+
+// Tag returns the tag of the control, which identifies it, or an empty string if it has none.
+func (c *ContentControl) Tag() string {
+	prop := *c.propertyRef()
+	if prop == nil || prop.Tag == nil {
+		return ""
+	}
+	return prop.Tag.Val
+}
+
+// Alias returns the friendly name of the control shown by Word, or an empty string if it has none.
+func (c *ContentControl) Alias() string {
+	prop := *c.propertyRef()
+	if prop == nil || prop.Alias == nil {
+		return ""
+	}
+	return prop.Alias.Val
+}
+
+// Type returns the kind of the control.
+func (c *ContentControl) Type() ContentControlType {
+	prop := *c.propertyRef()
+	switch {
+	case prop == nil:
+		return ContentControlRichText
+	case prop.Text != nil:
+		return ContentControlText
+	case prop.ComboBox != nil:
+		return ContentControlComboBox
+	case prop.DropDownList != nil:
+		return ContentControlDropDownList
+	case prop.Date != nil:
+		return ContentControlDate
+	case prop.Checkbox != nil:
+		return ContentControlCheckbox
+	case prop.Picture != nil:
+		return ContentControlPicture
+	case prop.Group != nil:
+		return ContentControlGroup
+	default:
+		return ContentControlRichText
+	}
+}
+
+// Locked reports whether the content of the control cannot be edited.
+func (c *ContentControl) Locked() bool {
+	prop := *c.propertyRef()
+	return prop != nil && prop.Lock != nil && prop.Lock.Val.ContentLocked()
+}
+
+// Checked reports whether a checkbox control is checked. It returns false for other controls.
+func (c *ContentControl) Checked() bool {
+	prop := *c.propertyRef()
+	return prop != nil && prop.Checkbox != nil && prop.Checkbox.Checked
+}
+
+// Items returns the entries of a combo box or drop-down list control, or nil for other controls.
+func (c *ContentControl) Items() []ctypes.SdtListItem {
+	if list := c.list(); list != nil {
+		return list.Items
+	}
+	return nil
+}
+
+func (c *ContentControl) list() *ctypes.SdtList {
+	prop := *c.propertyRef()
+	switch {
+	case prop == nil:
+		return nil
+	case prop.DropDownList != nil:
+		return prop.DropDownList
+	default:
+		return prop.ComboBox
+	}
+}
+
+// Text returns the text of the content of the control, paragraphs separated by line feeds. Deleted text
+// is left out.
+func (c *ContentControl) Text() string {
+	var lines []string
+	paragraphText := func(p *ctypes.Paragraph) {
+		var line strings.Builder
+		walkParagraphMarkup(p, nil, func(item markupItem) bool {
+			if item.run != nil {
+				line.WriteString(runText(item.run))
+			}
+			return true
+		})
+		lines = append(lines, line.String())
+	}
+
+	switch {
+	case c.run != nil:
+		paragraphText(&ctypes.Paragraph{Children: c.run.Content})
+	case c.block != nil:
+		forEachBlockParagraph(c.block.Content, paragraphText)
+	case c.row != nil:
+		forEachRowParagraph(c.row.Content, paragraphText)
+	default:
+		forEachCellParagraph(c.cell.Content, paragraphText)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetValue fills the control with the value, keeping the formatting of its content: the value takes the
+// formatting of the first run of the control, or that of the control properties when it has no run.
+//
+// The value depends on the type of the control:
+//   - Rich text and plain text: the text, line feeds and tabs becoming breaks and tabs.
+//   - Checkbox: "true" or "false", as accepted by strconv.ParseBool.
+//   - Date picker: a date in the format "2006-01-02", displayed with the date format of the control.
+//   - Drop-down list: the value or the display text of one of its entries.
+//   - Combo box: any text; the display text is shown for the value of an entry.
+//
+// Block-level controls hold the value in their first paragraph and cell-level controls in the first
+// paragraph of their first cell; the other paragraphs are left unchanged. The value replaces the
+// placeholder text of the control. Changes are not recorded as tracked changes. The custom XML part a
+// control may be bound to is not updated, and Word shows the content of the part when it opens the
+// document.
+//
+// Example:
+//
+//	document.ContentControl("client").SetValue("Acme Corp.")
+//	document.ContentControl("signed").SetValue("true")
+//
+// Returns:
+//   - error: An error if the content of the control is locked, if the value does not suit the type of
+//     the control, or for row-level, picture and group controls.
+func (c *ContentControl) SetValue(value string) error {
+	if c.Locked() {
+		return fmt.Errorf("content control %q: content is locked", c.Tag())
+	}
+	if c.row != nil {
+		return fmt.Errorf("content control %q: cannot set the value of a row-level control", c.Tag())
+	}
+
+	prop := c.Property()
+	text := value
+	switch c.Type() {
+	case ContentControlPicture, ContentControlGroup:
+		return fmt.Errorf("content control %q: cannot set the value of a picture or group control", c.Tag())
+	case ContentControlCheckbox:
+		checked, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("content control %q: invalid checkbox value %q", c.Tag(), value)
+		}
+		prop.Checkbox.Checked = checked
+		text = checkboxSymbol(prop.Checkbox)
+	case ContentControlDate:
+		date, err := time.Parse(contentControlDateLayout, value)
+		if err != nil {
+			return fmt.Errorf("content control %q: invalid date %q", c.Tag(), value)
+		}
+		fullDate := date.Format(contentControlDateLayout) + "T00:00:00Z"
+		prop.Date.FullDate = &fullDate
+		format := "M/d/yyyy"
+		if prop.Date.DateFormat != nil {
+			format = prop.Date.DateFormat.Val
+		}
+		text = formatFieldDate(date, format)
+	case ContentControlDropDownList, ContentControlComboBox:
+		list := c.list()
+		item := listItem(list, value)
+		if item == nil && c.Type() == ContentControlDropDownList {
+			return fmt.Errorf("content control %q: %q is not an entry of the list", c.Tag(), value)
+		}
+		lastValue := value
+		if item != nil {
+			lastValue = item.Value
+			if item.DisplayText != "" {
+				text = item.DisplayText
+			}
+		}
+		list.LastValue = &lastValue
+	}
+
+	children, err := c.valueChildren()
+	if err != nil {
+		return err
+	}
+	*children = controlValueChildren(*children, text, prop.RunProperty)
+	prop.ShowingPlcHdr = nil
+	return nil
+}
+
+// valueChildren returns the paragraph content that holds the value of the control.
+func (c *ContentControl) valueChildren() (*[]ctypes.ParagraphChild, error) {
+	if c.run != nil {
+		return &c.run.Content, nil
+	}
+
+	var first *ctypes.Paragraph
+	findFirst := func(p *ctypes.Paragraph) {
+		if first == nil {
+			first = p
+		}
+	}
+	if c.block != nil {
+		forEachBlockParagraph(c.block.Content, findFirst)
+		if first == nil {
+			first = &ctypes.Paragraph{}
+			c.block.Content = append(c.block.Content, ctypes.TCBlockContent{Paragraph: first})
+		}
+	} else {
+		forEachCellParagraph(c.cell.Content, findFirst)
+		if first == nil {
+			return nil, fmt.Errorf("content control %q: no cell paragraph to hold the value", c.Tag())
+		}
+	}
+	return &first.Children, nil
+}
+
+// controlValueChildren returns the paragraph content holding the text, in a single run formatted as the
+// first run of children, or with fallback when there is none. Bookmarks of children are kept around it.
+func controlValueChildren(children []ctypes.ParagraphChild, text string, fallback *ctypes.RunProperty) []ctypes.ParagraphChild {
+	var first *ctypes.Run
+	walkParagraphMarkup(&ctypes.Paragraph{Children: children}, nil, func(item markupItem) bool {
+		if item.run != nil {
+			first = item.run
+			return false
+		}
+		return true
+	})
+
+	var prop *ctypes.RunProperty
+	switch {
+	case first != nil && first.Property != nil:
+		propCopy := *first.Property
+		prop = &propCopy
+	case first == nil && fallback != nil:
+		propCopy := *fallback
+		prop = &propCopy
+	}
+	if prop != nil && prop.Style != nil && prop.Style.Val == "PlaceholderText" {
+		prop.Style = nil
+	}
+
+	var starts, ends []ctypes.ParagraphChild
+	for _, child := range children {
+		switch {
+		case child.BookmarkStart != nil:
+			starts = append(starts, child)
+		case child.BookmarkEnd != nil:
+			ends = append(ends, child)
+		}
+	}
+
+	run := &ctypes.Run{Property: prop, Children: textRunChildren(text)}
+	value := append(starts, ctypes.ParagraphChild{Run: run})
+	return append(value, ends...)
+}
+
+// textRunChildren returns the run content of the text, line feeds and tabs becoming breaks and tabs.
+func textRunChildren(text string) []ctypes.RunChild {
+	var children []ctypes.RunChild
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			children = append(children, ctypes.RunChild{Break: &ctypes.Break{}})
+		}
+		for j, part := range strings.Split(line, "\t") {
+			if j > 0 {
+				children = append(children, ctypes.RunChild{Tab: &ctypes.Empty{}})
+			}
+			if part != "" {
+				children = append(children, ctypes.RunChild{Text: ctypes.TextFromString(part)})
+			}
+		}
+	}
+	return children
+}
+
+// listItem returns the entry of the list whose value or display text is value, or nil.
+func listItem(list *ctypes.SdtList, value string) *ctypes.SdtListItem {
+	for i, item := range list.Items {
+		if item.Value == value {
+			return &list.Items[i]
+		}
+	}
+	for i, item := range list.Items {
+		if item.DisplayText == value {
+			return &list.Items[i]
+		}
+	}
+	return nil
+}
+
+// checkboxSymbol returns the character a checkbox shows in its current state.
+func checkboxSymbol(checkbox *ctypes.SdtCheckbox) string {
+	symbol, code := checkbox.UncheckedState, checkboxUncheckedChar
+	if checkbox.Checked {
+		symbol, code = checkbox.CheckedState, checkboxCheckedChar
+	}
+	if symbol != nil && symbol.Val != "" {
+		code = symbol.Val
+	}
+
+	char, err := strconv.ParseUint(code, 16, 32)
+	if err != nil {
+		return ""
+	}
+	return string(rune(char))
+}
+
+// AddContentControl adds a plain text content control holding the text to the paragraph.
+//
+// Example:
+//
+//	p := document.AddParagraph("Client: ")
+//	p.AddContentControl("client", "Click to enter the name")
+//
+// Parameters:
+//   - tag: The tag that identifies the control.
+//   - text: The initial text of the control.
+//
+// Returns:
+//   - *ContentControl: The new control.
+func (p *Paragraph) AddContentControl(tag string, text string) *ContentControl {
+	return p.addContentControl(&ctypes.SdtProperty{Text: &ctypes.SdtText{}}, tag, &ctypes.Run{
+		Children: textRunChildren(text),
+	})
+}
+
+// AddCheckbox adds a checkbox content control to the paragraph.
+//
+// Parameters:
+//   - tag: The tag that identifies the control.
+//   - checked: The initial state of the checkbox.
+//
+// Returns:
+//   - *ContentControl: The new control.
+func (p *Paragraph) AddCheckbox(tag string, checked bool) *ContentControl {
+	checkbox := &ctypes.SdtCheckbox{
+		Checked:        checked,
+		CheckedState:   &ctypes.SdtCheckboxSymbol{Val: checkboxCheckedChar, Font: checkboxFont},
+		UncheckedState: &ctypes.SdtCheckboxSymbol{Val: checkboxUncheckedChar, Font: checkboxFont},
+	}
+	return p.addContentControl(&ctypes.SdtProperty{Checkbox: checkbox}, tag, &ctypes.Run{
+		Property: &ctypes.RunProperty{
+			Fonts: &ctypes.RunFonts{Ascii: checkboxFont, HAnsi: checkboxFont, EastAsia: checkboxFont},
+		},
+		Children: []ctypes.RunChild{{Text: ctypes.TextFromString(checkboxSymbol(checkbox))}},
+	})
+}
+
+func (p *Paragraph) addContentControl(prop *ctypes.SdtProperty, tag string, run *ctypes.Run) *ContentControl {
+	prop.Tag = ctypes.NewCTString(tag)
+	prop.ID = ctypes.NewDecimalNum(p.root.nextContentControlID())
+
+	sdt := &ctypes.SdtRun{
+		Property: prop,
+		Content:  []ctypes.ParagraphChild{{Run: run}},
+	}
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Sdt: sdt})
+	return &ContentControl{root: p.root, run: sdt}
+}
+
+// nextContentControlID returns an ID above those of the content controls of the document.
+func (rd *RootDoc) nextContentControlID() int {
+	next := 1
+	for _, control := range rd.ContentControls() {
+		if prop := *control.propertyRef(); prop != nil && prop.ID != nil && prop.ID.Val >= next {
+			next = prop.ID.Val + 1
+		}
+	}
+	return next
+}
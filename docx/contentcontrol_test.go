@@ -0,0 +1,140 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const formXML = `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+	`<w:sdt><w:sdtPr><w:alias w:val="Client name"/><w:tag w:val="client"/><w:id w:val="7"/><w:showingPlcHdr/><w:text/></w:sdtPr>` +
+	`<w:sdtContent><w:p><w:r><w:rPr><w:rStyle w:val="PlaceholderText"/><w:b/></w:rPr><w:t>Click here</w:t></w:r>` +
+	`<w:r><w:t> to enter text.</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+	`<w:p><w:r><w:t>Plan: </w:t></w:r>` +
+	`<w:sdt><w:sdtPr><w:tag w:val="plan"/><w:dropDownList><w:listItem w:displayText="Basic plan" w:value="basic"/>` +
+	`<w:listItem w:displayText="Premium plan" w:value="premium"/></w:dropDownList></w:sdtPr>` +
+	`<w:sdtContent><w:r><w:t>Basic plan</w:t></w:r></w:sdtContent></w:sdt>` +
+	`<w:sdt><w:sdtPr><w:tag w:val="start"/><w:date><w:dateFormat w:val="d MMMM yyyy"/></w:date></w:sdtPr>` +
+	`<w:sdtContent><w:r><w:t>Pick a date</w:t></w:r></w:sdtContent></w:sdt>` +
+	`<w:sdt><w:sdtPr><w:tag w:val="terms"/><w14:checkbox><w14:checked w14:val="0"/>` +
+	`<w14:checkedState w14:val="2612" w14:font="MS Gothic"/><w14:uncheckedState w14:val="2610" w14:font="MS Gothic"/></w14:checkbox></w:sdtPr>` +
+	`<w:sdtContent><w:r><w:t>☐</w:t></w:r></w:sdtContent></w:sdt></w:p>` +
+	`<w:tbl><w:sdt><w:sdtPr><w:tag w:val="rows"/></w:sdtPr><w:sdtContent><w:tr>` +
+	`<w:sdt><w:sdtPr><w:tag w:val="cell"/><w:lock w:val="sdtContentLocked"/></w:sdtPr><w:sdtContent>` +
+	`<w:tc><w:p><w:r><w:t>Fixed</w:t></w:r></w:p></w:tc></w:sdtContent></w:sdt>` +
+	`</w:tr></w:sdtContent></w:sdt></w:tbl>` +
+	`</w:body>`
+
+func loadForm(t *testing.T) *RootDoc {
+	rd := NewRootDoc()
+	body := NewBody(rd)
+	if err := xml.Unmarshal([]byte(formXML), body); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	rd.Document.Body = body
+	return rd
+}
+
+func TestRootDoc_ContentControls(t *testing.T) {
+	rd := loadForm(t)
+
+	controls := rd.ContentControls()
+	var tags []string
+	for _, control := range controls {
+		tags = append(tags, control.Tag())
+	}
+	assert.Equal(t, []string{"client", "plan", "start", "terms", "rows", "cell"}, tags)
+
+	client := controls[0]
+	assert.Equal(t, "Client name", client.Alias())
+	assert.Equal(t, ContentControlText, client.Type())
+	assert.Equal(t, "Click here to enter text.", client.Text())
+	assert.Equal(t, ContentControlDropDownList, controls[1].Type())
+	assert.Len(t, controls[1].Items(), 2)
+	assert.Equal(t, ContentControlDate, controls[2].Type())
+	assert.Equal(t, ContentControlCheckbox, controls[3].Type())
+	assert.Equal(t, ContentControlRichText, controls[4].Type())
+	assert.True(t, controls[5].Locked())
+	assert.Equal(t, "Fixed", rd.ContentControl("rows").Text())
+	assert.Nil(t, rd.ContentControl("missing"))
+}
+
+func TestContentControl_SetValue(t *testing.T) {
+	rd := loadForm(t)
+
+	client := rd.ContentControl("client")
+	assert.NoError(t, client.SetValue("Acme Corp."))
+	assert.Equal(t, "Acme Corp.", client.Text())
+	assert.Nil(t, client.Property().ShowingPlcHdr)
+
+	// The value keeps the formatting of the first run, without the placeholder style
+	run := client.block.Content[0].Paragraph.Children[0].Run
+	assert.Len(t, client.block.Content[0].Paragraph.Children, 1)
+	assert.NotNil(t, run.Property.Bold)
+	assert.Nil(t, run.Property.Style)
+
+	plan := rd.ContentControl("plan")
+	assert.NoError(t, plan.SetValue("premium"))
+	assert.Equal(t, "Premium plan", plan.Text())
+	assert.Equal(t, "premium", *plan.Property().DropDownList.LastValue)
+	assert.Error(t, plan.SetValue("gold"))
+
+	start := rd.ContentControl("start")
+	assert.NoError(t, start.SetValue("2024-05-01"))
+	assert.Equal(t, "1 May 2024", start.Text())
+	assert.Equal(t, "2024-05-01T00:00:00Z", *start.Property().Date.FullDate)
+	assert.Error(t, start.SetValue("May 1st"))
+
+	terms := rd.ContentControl("terms")
+	assert.NoError(t, terms.SetValue("true"))
+	assert.True(t, terms.Checked())
+	assert.Equal(t, "☒", terms.Text())
+	assert.Error(t, terms.SetValue("maybe"))
+
+	assert.Error(t, rd.ContentControl("cell").SetValue("Changed"))
+	assert.Equal(t, "Fixed", rd.ContentControl("cell").Text())
+	assert.Error(t, rd.ContentControl("rows").SetValue("Changed"))
+}
+
+func TestParagraph_AddContentControl(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddParagraph("Name: ")
+	name := p.AddContentControl("name", "Your name")
+	done := p.AddCheckbox("done", false)
+	footer := rd.AddFooter(stypes.HdrFtrDefault).AddParagraph("Ref: ")
+	footer.AddContentControl("ref", "")
+
+	assert.Len(t, rd.ContentControls(), 3)
+	assert.Equal(t, 1, name.Property().ID.Val)
+	assert.Equal(t, 2, done.Property().ID.Val)
+	assert.Equal(t, "☐", done.Text())
+
+	assert.NoError(t, name.SetValue("Jane\tDoe"))
+	assert.NoError(t, rd.ContentControl("ref").SetValue("A-1"))
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:sdt><w:sdtPr><w:tag w:val="name"></w:tag><w:id w:val="1"></w:id><w:text></w:text></w:sdtPr>`+
+		`<w:sdtContent><w:r><w:t>Jane</w:t><w:tab></w:tab><w:t>Doe</w:t></w:r></w:sdtContent></w:sdt>`)
+	assert.Contains(t, string(output), `<w14:checkbox><w14:checked w14:val="0"></w14:checked>`)
+}
+
+func TestAddCheckbox_PartNamespaces(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	header.AddParagraph("Approved: ").AddCheckbox("approved", true)
+	footer := rd.AddFooter(stypes.HdrFtrDefault)
+	footer.AddParagraph("Final: ").AddCheckbox("final", false)
+	rd.AddParagraph("Figures").AddFootnote("Checked: ").Children[0].Para.AddCheckbox("checked", true)
+
+	for _, part := range []any{header, footer, rd.Footnotes} {
+		output, err := xml.Marshal(part)
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `<w14:checkbox>`)
+		assert.Contains(t, string(output), `xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml"`)
+		assert.Contains(t, string(output), `mc:Ignorable="w14"`)
+	}
+}
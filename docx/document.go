@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 
 	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/ctypes"
 	"github.com/mrlijnden/godocx/wml/stypes"
 )
 
@@ -28,6 +29,9 @@ type Document struct {
 	// Reference to the RootDoc
 	Root *RootDoc
 
+	// Root attributes of an opened document, including its namespace declarations
+	Attr []xml.Attr
+
 	// Elements
 	Background *Background
 	Body       *Body
@@ -49,7 +53,18 @@ func (doc *Document) IncRelationID() int {
 func (doc Document) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 	start.Name.Local = "w:document"
 
+	// Keep the declarations of an opened document, since preserved content may
+	// refer to any of them, and add the ones the library itself relies on.
+	declared := make(map[string]bool, len(doc.Attr))
+	for _, attr := range doc.Attr {
+		declared[attr.Name.Local] = true
+		start.Attr = append(start.Attr, attr)
+	}
+
 	for key, value := range docAttrs {
+		if declared[key] {
+			continue
+		}
 		attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
 		start.Attr = append(start.Attr, attr)
 	}
@@ -76,6 +91,7 @@ func (doc Document) MarshalXML(e *xml.Encoder, start xml.StartElement) (err erro
 }
 
 func (d *Document) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) (err error) {
+	d.Attr = ctypes.QualifyAttrs(start.Attr)
 
 	for {
 		currentToken, err := decoder.Token()
@@ -0,0 +1,414 @@
+package docx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// Field represents a complex field of a paragraph: a begin character, the field instruction, a separate
+// character, the cached result and an end character, each held by its own run.
+//
+// Word shows the cached result until the field is updated, so it should be a sensible value for the
+// document as it is saved.
+type Field struct {
+	root   *RootDoc
+	parent *Paragraph
+
+	begin  *ctypes.Run
+	instr  []*ctypes.Run
+	result []*ctypes.Run
+	end    *ctypes.Run
+}
+
+// AddField appends a complex field to the paragraph.
+//
+// Example:
+//
+//	field := paragraph.AddField(`PAGE \* roman`, "i")
+//
+// Parameters:
+//   - instr: The field instruction, such as "PAGE" or `DATE \@ "d MMMM yyyy"`.
+//   - cachedResult: The text shown by Word until the field is updated.
+//
+// Returns:
+//   - *Field: The newly created Field instance.
+func (p *Paragraph) AddField(instr string, cachedResult string) *Field {
+	runs := fieldRuns(instr, cachedResult)
+	for _, run := range runs {
+		p.appendRun(run)
+	}
+
+	return &Field{
+		root:   p.root,
+		parent: p,
+		begin:  runs[0],
+		instr:  runs[1:2],
+		result: runs[3:4],
+		end:    runs[4],
+	}
+}
+
+// fieldRuns returns the runs of a complex field: begin, instruction, separate, result and end.
+func fieldRuns(instr string, cachedResult string) []*ctypes.Run {
+	return []*ctypes.Run{
+		fieldCharRun(stypes.FieldCharTypeBegin),
+		instrRun(instr),
+		fieldCharRun(stypes.FieldCharTypeSeparate),
+		{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(cachedResult)}}},
+		fieldCharRun(stypes.FieldCharTypeEnd),
+	}
+}
+
+// AddPageField appends a PAGE field, the number of the current page.
+func (p *Paragraph) AddPageField() *Field {
+	return p.AddField("PAGE", "1")
+}
+
+// AddPageCountField appends a NUMPAGES field, the number of pages of the document.
+func (p *Paragraph) AddPageCountField() *Field {
+	return p.AddField("NUMPAGES", "1")
+}
+
+// AddPageXOfY appends "Page X of Y" to the paragraph, with X and Y as live PAGE and NUMPAGES fields.
+//
+// Returns:
+//   - page: The PAGE field.
+//   - pages: The NUMPAGES field.
+func (p *Paragraph) AddPageXOfY() (page *Field, pages *Field) {
+	p.AddText("Page ")
+	page = p.AddPageField()
+	p.AddText(" of ")
+	pages = p.AddPageCountField()
+	return page, pages
+}
+
+// AddDateField appends a DATE field, the date the document is opened or printed.
+//
+// Parameters:
+//   - format: A Word date-time picture such as "d MMMM yyyy" or "dd/MM/yyyy HH:mm", empty for the default format.
+//
+// The cached result is the current date in the given format.
+func (p *Paragraph) AddDateField(format string) *Field {
+	instr := "DATE"
+	if format != "" {
+		instr += ` \@ ` + quoteFieldArg(format)
+	}
+	return p.AddField(instr, formatFieldDate(time.Now(), format))
+}
+
+// AddDocPropertyField appends a DOCPROPERTY field, the value of a document property such as "Title",
+// "Author" or a custom property.
+//
+// Parameters:
+//   - name: The name of the property.
+//   - cachedResult: The current value of the property.
+func (p *Paragraph) AddDocPropertyField(name string, cachedResult string) *Field {
+	return p.AddField(`DOCPROPERTY `+quoteFieldArg(name)+` \* MERGEFORMAT`, cachedResult)
+}
+
+// AddRefField appends a REF field, the text of a bookmark, as a link to the bookmark.
+//
+// Parameters:
+//   - bookmark: The name of the bookmark.
+//   - cachedResult: The current text of the bookmark.
+func (p *Paragraph) AddRefField(bookmark string, cachedResult string) *Field {
+	return p.AddField("REF "+quoteFieldArg(bookmark)+` \h`, cachedResult)
+}
+
+// Fields returns the complex fields of the paragraph, in document order. Fields nested in the instruction
+// or the result of another field are not returned on their own.
+func (p *Paragraph) Fields() []*Field {
+	var fields []*Field
+	var current *Field
+	var inResult bool
+	depth := 0
+
+	for _, r := range p.Runs() {
+		run := r.ct
+		outer := false // the run holds a field character of the outermost field
+		for _, child := range run.Children {
+			if child.FldChar == nil {
+				continue
+			}
+			switch child.FldChar.FieldCharType {
+			case stypes.FieldCharTypeBegin:
+				depth++
+				if depth == 1 {
+					current = &Field{root: p.root, parent: p, begin: run}
+					inResult = false
+					outer = true
+				}
+			case stypes.FieldCharTypeSeparate:
+				if depth == 1 {
+					inResult = true
+					outer = true
+				}
+			case stypes.FieldCharTypeEnd:
+				if depth == 1 && current != nil {
+					current.end = run
+					fields = append(fields, current)
+					current = nil
+					outer = true
+				}
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+
+		if current == nil || outer {
+			continue
+		}
+		if inResult {
+			current.result = append(current.result, run)
+		} else {
+			current.instr = append(current.instr, run)
+		}
+	}
+
+	return fields
+}
+
+// Instruction returns the field instruction, without the surrounding spaces.
+func (f *Field) Instruction() string {
+	var instr strings.Builder
+	for _, run := range f.instr {
+		for _, child := range run.Children {
+			switch {
+			case child.InstrText != nil:
+				instr.WriteString(child.InstrText.Text)
+			case child.DelInstrText != nil:
+				instr.WriteString(child.DelInstrText.Text)
+			}
+		}
+	}
+	return strings.TrimSpace(instr.String())
+}
+
+// Result returns the cached result of the field.
+func (f *Field) Result() string {
+	var result strings.Builder
+	for _, run := range f.result {
+		for _, child := range run.Children {
+			if child.Text != nil {
+				result.WriteString(child.Text.Text)
+			}
+		}
+	}
+	return result.String()
+}
+
+// SetResult replaces the cached result of the field. The text goes to the first run of the result so
+// that its formatting is kept; the text of the other runs is removed.
+func (f *Field) SetResult(text string) *Field {
+	if len(f.result) == 0 {
+		f.result = []*ctypes.Run{{}}
+		f.insertResult(f.result[0])
+	}
+
+	for i, run := range f.result {
+		children := run.Children[:0]
+		for _, child := range run.Children {
+			if child.Text == nil {
+				children = append(children, child)
+			}
+		}
+		run.Children = children
+
+		if i == 0 {
+			run.Children = append(run.Children, ctypes.RunChild{Text: ctypes.TextFromString(text)})
+		}
+	}
+	return f
+}
+
+// insertResult adds the separate character and the result run before the end of a field that has no
+// result.
+func (f *Field) insertResult(result *ctypes.Run) {
+	children := f.parent.ct.Children
+	for i, child := range children {
+		if !holdsRun(child, f.end) {
+			continue
+		}
+		added := []ctypes.ParagraphChild{f.root.trackedRun(fieldCharRun(stypes.FieldCharTypeSeparate)), f.root.trackedRun(result)}
+		f.parent.ct.Children = append(children[:i], append(added, children[i:]...)...)
+		return
+	}
+}
+
+// holdsRun reports whether the paragraph content is the run, or a revision that holds it.
+func holdsRun(child ctypes.ParagraphChild, run *ctypes.Run) bool {
+	if child.Run != nil {
+		return child.Run == run
+	}
+	for _, revision := range []*ctypes.RunTrackChange{child.Ins, child.Del} {
+		if revision == nil {
+			continue
+		}
+		for _, revChild := range revision.Children {
+			if revChild.Run == run {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Dirty marks the field to be updated by Word when the document is opened.
+func (f *Field) Dirty(value bool) *Field {
+	f.fieldChar().Dirty = onOffAttr(value)
+	return f
+}
+
+// Lock prevents the field from being updated.
+func (f *Field) Lock(value bool) *Field {
+	f.fieldChar().FldLock = onOffAttr(value)
+	return f
+}
+
+// ResultRun returns the run that holds the cached result, to format the field.
+func (f *Field) ResultRun() *Run {
+	if len(f.result) == 0 {
+		f.SetResult("")
+	}
+	r := newRun(f.root, f.result[0])
+	r.parent = f.parent
+	return r
+}
+
+// fieldChar returns the begin character of the field.
+func (f *Field) fieldChar() *ctypes.FieldChar {
+	for _, child := range f.begin.Children {
+		if child.FldChar != nil {
+			return child.FldChar
+		}
+	}
+	return nil
+}
+
+func onOffAttr(value bool) *stypes.OnOff {
+	if !value {
+		return nil
+	}
+	val := stypes.OnOffTrue
+	return &val
+}
+
+func fieldCharRun(fieldCharType stypes.FieldCharType) *ctypes.Run {
+	return &ctypes.Run{
+		Children: []ctypes.RunChild{{FldChar: ctypes.NewFieldChar(fieldCharType)}},
+	}
+}
+
+func instrRun(instr string) *ctypes.Run {
+	return &ctypes.Run{
+		Children: []ctypes.RunChild{{InstrText: ctypes.TextFromString(" " + instr + " ")}},
+	}
+}
+
+// quoteFieldArg quotes a field argument that holds spaces or quotes.
+func quoteFieldArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
+
+// dateTokens are the parts of a Word date-time picture, longest first.
+var dateTokens = []string{
+	"yyyy", "yy", "MMMM", "MMM", "MM", "M", "dddd", "ddd", "dd", "d",
+	"HH", "H", "hh", "h", "mm", "m", "ss", "s", "AM/PM", "am/pm",
+}
+
+// formatFieldDate formats the time with a Word date-time picture. Text between single quotes is kept as is.
+func formatFieldDate(t time.Time, picture string) string {
+	if picture == "" {
+		return t.Format("1/2/2006")
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(picture); {
+		if picture[i] == '\'' {
+			end := strings.IndexByte(picture[i+1:], '\'')
+			if end < 0 {
+				out.WriteString(picture[i+1:])
+				break
+			}
+			out.WriteString(picture[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		token := ""
+		for _, candidate := range dateTokens {
+			if strings.HasPrefix(picture[i:], candidate) {
+				token = candidate
+				break
+			}
+		}
+		if token == "" {
+			out.WriteByte(picture[i])
+			i++
+			continue
+		}
+
+		out.WriteString(formatDateToken(t, token))
+		i += len(token)
+	}
+	return out.String()
+}
+
+func formatDateToken(t time.Time, token string) string {
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	switch token {
+	case "yyyy":
+		return fmt.Sprintf("%04d", t.Year())
+	case "yy":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "MMMM":
+		return t.Month().String()
+	case "MMM":
+		return t.Month().String()[:3]
+	case "MM":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "M":
+		return fmt.Sprint(int(t.Month()))
+	case "dddd":
+		return t.Weekday().String()
+	case "ddd":
+		return t.Weekday().String()[:3]
+	case "dd":
+		return fmt.Sprintf("%02d", t.Day())
+	case "d":
+		return fmt.Sprint(t.Day())
+	case "HH":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "H":
+		return fmt.Sprint(t.Hour())
+	case "hh":
+		return fmt.Sprintf("%02d", hour12)
+	case "h":
+		return fmt.Sprint(hour12)
+	case "mm":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "m":
+		return fmt.Sprint(t.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", t.Second())
+	case "s":
+		return fmt.Sprint(t.Second())
+	case "AM/PM":
+		return t.Format("PM")
+	case "am/pm":
+		return t.Format("pm")
+	}
+	return token
+}
@@ -0,0 +1,109 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraph_AddField(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	field := p.AddField(`PAGE \* roman`, "i")
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	expected := `<w:p>` +
+		`<w:r><w:fldChar w:fldCharType="begin"></w:fldChar></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> PAGE \* roman </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"></w:fldChar></w:r>` +
+		`<w:r><w:t>i</w:t></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end"></w:fldChar></w:r>` +
+		`</w:p>`
+	assert.Equal(t, expected, string(output))
+
+	assert.Equal(t, `PAGE \* roman`, field.Instruction())
+	assert.Equal(t, "i", field.Result())
+
+	field.Dirty(true)
+	assert.Equal(t, stypes.OnOffTrue, *p.ct.Children[0].Run.Children[0].FldChar.Dirty)
+
+	field.ResultRun().Bold(true)
+	field.SetResult("iv")
+	assert.Equal(t, "iv", field.Result())
+	assert.NotNil(t, p.ct.Children[3].Run.Property.Bold)
+}
+
+func TestParagraph_FieldHelpers(t *testing.T) {
+	rd := NewRootDoc()
+	footer := rd.AddEmptyParagraph()
+	footer.AddPageXOfY()
+	footer.AddDocPropertyField("Project Name", "Apollo")
+	footer.AddRefField("Clause1", "1.1")
+
+	fields := footer.Fields()
+	assert.Len(t, fields, 4)
+	assert.Equal(t, "PAGE", fields[0].Instruction())
+	assert.Equal(t, "NUMPAGES", fields[1].Instruction())
+	assert.Equal(t, `DOCPROPERTY "Project Name" \* MERGEFORMAT`, fields[2].Instruction())
+	assert.Equal(t, "Apollo", fields[2].Result())
+	assert.Equal(t, `REF Clause1 \h`, fields[3].Instruction())
+
+	date := rd.AddEmptyParagraph().AddDateField("d MMMM yyyy")
+	assert.Equal(t, `DATE \@ "d MMMM yyyy"`, date.Instruction())
+	assert.Equal(t, time.Now().Format("2 January 2006"), date.Result())
+}
+
+func TestParagraph_Fields_Nested(t *testing.T) {
+	input := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:r><w:fldChar w:fldCharType="begin"/></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> IF </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="begin"/></w:r>` +
+		`<w:r><w:instrText>PAGE</w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"/></w:r><w:r><w:t>2</w:t></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end"/></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> > 1 "Continued" "" </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"/></w:r>` +
+		`<w:r><w:t>Contin</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>ued</w:t></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end"/></w:r>` +
+		`<w:r><w:t xml:space="preserve"> text</w:t></w:r>` +
+		`</w:p>`
+
+	p := newParagraph(NewRootDoc())
+	if err := xml.Unmarshal([]byte(input), p.ct); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	fields := p.Fields()
+	assert.Len(t, fields, 1)
+	assert.Equal(t, `IF PAGE > 1 "Continued" ""`, fields[0].Instruction())
+	assert.Equal(t, "Continued", fields[0].Result())
+
+	fields[0].SetResult("")
+	assert.Equal(t, "", fields[0].Result())
+	assert.Len(t, p.ct.Children, 13)
+}
+
+func TestFormatFieldDate(t *testing.T) {
+	date := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		picture  string
+		expected string
+	}{
+		{"", "3/5/2024"},
+		{"d MMMM yyyy", "5 March 2024"},
+		{"dd/MM/yy", "05/03/24"},
+		{"dddd, MMM d", "Tuesday, Mar 5"},
+		{"HH:mm:ss", "14:07:09"},
+		{"h:mm am/pm", "2:07 pm"},
+		{"'Week of' d M", "Week of 5 3"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatFieldDate(date, tt.picture), tt.picture)
+	}
+}
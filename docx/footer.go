@@ -11,15 +11,26 @@ import (
 )
 
 var footerAttrs = map[string]string{
-	"xmlns:w": "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
-	"xmlns:r": "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:w":      "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r":      "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:wp":     "http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing",
+	"xmlns:w14":    "http://schemas.microsoft.com/office/word/2010/wordml",
+	"xmlns:mc":     "http://schemas.openxmlformats.org/markup-compatibility/2006",
+	"mc:Ignorable": "w14",
 }
 
 // Footer represents a document footer with its content and properties
 type Footer struct {
 	Root     *RootDoc
 	Children []DocumentChild
-	rID      int
+
+	// Attributes of the root element of an opened part (namespace declarations, mc:Ignorable, ...)
+	Attr []xml.Attr
+
+	// Relationships of the part, e.g. to the images and hyperlinks of the footer
+	Rels Relationships
+
+	rID      string // relationship ID of the part in the document relationships
 	filename string
 }
 
@@ -28,12 +39,13 @@ func NewFooter(root *RootDoc, filename string) *Footer {
 	return &Footer{
 		Root:     root,
 		filename: filename,
+		Rels:     newPartRels(filename),
 	}
 }
 
 // AddParagraph adds a paragraph to the footer
 func (f *Footer) AddParagraph(text string) *Paragraph {
-	p := newParagraph(f.Root)
+	p := newParagraph(f.Root, paraInPart(&f.Rels))
 	p.AddText(text)
 	f.Children = append(f.Children, DocumentChild{Para: p})
 	return p
@@ -41,7 +53,7 @@ func (f *Footer) AddParagraph(text string) *Paragraph {
 
 // AddEmptyParagraph adds an empty paragraph to the footer
 func (f *Footer) AddEmptyParagraph() *Paragraph {
-	p := newParagraph(f.Root)
+	p := newParagraph(f.Root, paraInPart(&f.Rels))
 	f.Children = append(f.Children, DocumentChild{Para: p})
 	return p
 }
@@ -55,7 +67,16 @@ func (f *Footer) Update() error {
 func (f Footer) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name.Local = "w:ftr"
 
+	declared := make(map[string]bool, len(f.Attr))
+	for _, attr := range f.Attr {
+		declared[attr.Name.Local] = true
+		start.Attr = append(start.Attr, attr)
+	}
+
 	for key, value := range footerAttrs {
+		if declared[key] {
+			continue
+		}
 		attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
 		start.Attr = append(start.Attr, attr)
 	}
@@ -65,51 +86,103 @@ func (f Footer) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
-	for _, child := range f.Children {
-		if child.Para != nil {
-			if err = child.Para.ct.MarshalXML(e, xml.StartElement{}); err != nil {
-				return err
-			}
+	if err = marshalDocumentChildren(e, f.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the Footer type
+func (f *Footer) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	f.Attr = ctypes.QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
 		}
-		if child.Table != nil {
-			if err = child.Table.ct.MarshalXML(e, xml.StartElement{}); err != nil {
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			child, err := unmarshalDocumentChild(f.Root, &f.Rels, d, elem)
+			if err != nil {
 				return err
 			}
+			f.Children = append(f.Children, child)
+		case xml.EndElement:
+			return nil
 		}
 	}
+}
 
-	return e.EncodeToken(xml.EndElement{Name: start.Name})
+// LoadFooterXml decodes a footer part of an opened document.
+//
+// Parameters:
+//   - rd: The root document the footer belongs to.
+//   - rID: The ID of the relationship that targets the part.
+//   - fileName: The path of the part within the package, e.g. "word/footer1.xml".
+//   - fileBytes: The XML data of the part.
+//
+// Returns:
+//   - *Footer: The decoded footer.
+//   - error: An error, if any occurred during the decoding process.
+func LoadFooterXml(rd *RootDoc, rID string, fileName string, fileBytes []byte) (*Footer, error) {
+	f := NewFooter(rd, fileName)
+	if err := xml.Unmarshal(fileBytes, f); err != nil {
+		return nil, err
+	}
+
+	f.rID = rID
+	return f, nil
 }
 
-// AddFooter adds a footer to the document
+// AddFooter adds a footer of the given type to the last section of the document, replacing the reference to an
+// existing footer of that type. See Section.AddFooter.
 func (rd *RootDoc) AddFooter(ftrType stypes.HdrFtrType) *Footer {
-	// Generate unique filename for footer
-	footerCount := rd.getFooterCount() + 1
-	filename := fmt.Sprintf("word/footer%d.xml", footerCount)
+	return rd.LastSection().AddFooter(ftrType)
+}
+
+// newFooterPart creates an empty footer part and registers it in the document relationships and content types.
+func (rd *RootDoc) newFooterPart() *Footer {
+	// Generate unique filename for footer, leaving the parts of an opened document untouched
+	footerIndex := rd.nextPartIndex("footer")
+	filename := fmt.Sprintf("word/footer%d.xml", footerIndex)
 
 	// Create footer instance
 	footer := NewFooter(rd, filename)
 
 	// Add relationship to document
-	rID := rd.Document.addRelation(constants.SourceRelationshipFooter, "footer"+strconv.Itoa(footerCount)+".xml")
+	footer.rID = rd.Document.addRelation(constants.SourceRelationshipFooter, "footer"+strconv.Itoa(footerIndex)+".xml")
 
 	// Add content type override
 	contentType := "application/vnd.openxmlformats-officedocument.wordprocessingml.footer+xml"
 	rd.ContentType.AddOverride("/"+filename, contentType)
 
-	// Update section properties
-	rd.ensureSectionProperties()
-	rd.Document.Body.SectPr.FooterReference = &ctypes.FooterReference{
-		Type: ftrType,
-		ID:   rID,
-	}
-
 	// Store footer for automatic serialization
 	rd.Footers = append(rd.Footers, footer)
 
 	return footer
 }
 
+// Footer returns the footer of the given type referenced by the last section of the document, or nil if there is none.
+func (rd *RootDoc) Footer(ftrType stypes.HdrFtrType) *Footer {
+	if rd.Document.Body.SectPr == nil {
+		return nil
+	}
+	return newSection(rd, rd.Document.Body.SectPr).Footer(ftrType)
+}
+
+// footerByID returns the footer part targeted by the relationship with the given ID, or nil if there is none.
+func (rd *RootDoc) footerByID(rID string) *Footer {
+	for _, footer := range rd.Footers {
+		if footer.rID == rID {
+			return footer
+		}
+	}
+	return nil
+}
+
 // getFooterCount returns the current number of footers in the document
 func (rd *RootDoc) getFooterCount() int {
 	count := 0
@@ -94,11 +94,11 @@ func TestRootDoc_AddFooter(t *testing.T) {
 	}
 
 	// Check that footer reference was added
-	if rd.Document.Body.SectPr.FooterReference == nil {
-		t.Error("Footer reference was not added to section properties")
+	if len(rd.Document.Body.SectPr.FooterReference) != 1 {
+		t.Fatal("Footer reference was not added to section properties")
 	}
 
-	if rd.Document.Body.SectPr.FooterReference.Type != stypes.HdrFtrDefault {
+	if rd.Document.Body.SectPr.FooterReference[0].Type != stypes.HdrFtrDefault {
 		t.Error("Footer reference type is incorrect")
 	}
 }
@@ -203,4 +203,70 @@ func TestRootDoc_getFooterCount(t *testing.T) {
 	if count := rd.getFooterCount(); count != 2 {
 		t.Errorf("Expected footer count to still be 2, got %d", count)
 	}
-}
\ No newline at end of file
+}
+func TestLoadFooterXml(t *testing.T) {
+	input := `<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:p><w:r><w:t>Confidential</w:t></w:r></w:p>` +
+		`</w:ftr>`
+
+	rd := NewRootDoc()
+	footer, err := LoadFooterXml(rd, "rId8", "word/footer1.xml", []byte(input))
+	if err != nil {
+		t.Fatalf("LoadFooterXml failed: %v", err)
+	}
+
+	if footer.rID != "rId8" || footer.filename != "word/footer1.xml" {
+		t.Errorf("Unexpected footer identity: %+v", footer)
+	}
+
+	if len(footer.Children) != 1 || footer.Children[0].Para == nil {
+		t.Fatalf("Expected a single paragraph child, got %+v", footer.Children)
+	}
+
+	output, err := xml.Marshal(footer)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+
+	if !strings.Contains(string(output), `<w:p><w:r><w:t>Confidential</w:t></w:r></w:p>`) {
+		t.Errorf("Footer content not preserved: %s", output)
+	}
+}
+
+func TestRootDoc_AddFooter_KeepsExistingParts(t *testing.T) {
+	rd := NewRootDoc()
+	rd.Footers = append(rd.Footers, NewFooter(rd, "word/footer1.xml"))
+
+	footer := rd.AddFooter(stypes.HdrFtrDefault)
+	if footer.filename != "word/footer2.xml" {
+		t.Errorf("Expected footer filename word/footer2.xml, got: %s", footer.filename)
+	}
+}
+
+func TestRootDoc_Footer(t *testing.T) {
+	rd := NewRootDoc()
+
+	defaultFooter := rd.AddFooter(stypes.HdrFtrDefault)
+	firstFooter := rd.AddFooter(stypes.HdrFtrFirst)
+
+	if len(rd.Document.Body.SectPr.FooterReference) != 2 {
+		t.Fatalf("Expected 2 footer references, got %d", len(rd.Document.Body.SectPr.FooterReference))
+	}
+
+	if rd.Footer(stypes.HdrFtrDefault) != defaultFooter || rd.Footer(stypes.HdrFtrFirst) != firstFooter {
+		t.Error("Footers are not referenced by their type")
+	}
+
+	if rd.Footer(stypes.HdrFtrEven) != nil {
+		t.Error("Expected no even page footer")
+	}
+
+	// Replacing a footer removes the part of the replaced one
+	replaced := rd.AddFooter(stypes.HdrFtrDefault)
+	if len(rd.Footers) != 2 || rd.footerByID(defaultFooter.rID) != nil || rd.Document.relationByID(defaultFooter.rID) != nil {
+		t.Error("Expected the replaced footer to be removed")
+	}
+	if rd.ContentType.hasOverride("/"+defaultFooter.filename) || rd.Footer(stypes.HdrFtrDefault) != replaced {
+		t.Error("Expected the default footer reference to be replaced")
+	}
+}
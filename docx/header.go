@@ -3,7 +3,9 @@ package docx
 import (
 	"encoding/xml"
 	"fmt"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/mrlijnden/godocx/common/constants"
 	"github.com/mrlijnden/godocx/wml/ctypes"
@@ -11,15 +13,26 @@ import (
 )
 
 var headerAttrs = map[string]string{
-	"xmlns:w": "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
-	"xmlns:r": "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:w":      "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r":      "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:wp":     "http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing",
+	"xmlns:w14":    "http://schemas.microsoft.com/office/word/2010/wordml",
+	"xmlns:mc":     "http://schemas.openxmlformats.org/markup-compatibility/2006",
+	"mc:Ignorable": "w14",
 }
 
 // Header represents a document header with its content and properties
 type Header struct {
 	Root     *RootDoc
 	Children []DocumentChild
-	rID      int
+
+	// Attributes of the root element of an opened part (namespace declarations, mc:Ignorable, ...)
+	Attr []xml.Attr
+
+	// Relationships of the part, e.g. to the images and hyperlinks of the header
+	Rels Relationships
+
+	rID      string // relationship ID of the part in the document relationships
 	filename string
 }
 
@@ -28,12 +41,13 @@ func NewHeader(root *RootDoc, filename string) *Header {
 	return &Header{
 		Root:     root,
 		filename: filename,
+		Rels:     newPartRels(filename),
 	}
 }
 
 // AddParagraph adds a paragraph to the header
 func (h *Header) AddParagraph(text string) *Paragraph {
-	p := newParagraph(h.Root)
+	p := newParagraph(h.Root, paraInPart(&h.Rels))
 	p.AddText(text)
 	h.Children = append(h.Children, DocumentChild{Para: p})
 	return p
@@ -41,7 +55,7 @@ func (h *Header) AddParagraph(text string) *Paragraph {
 
 // AddEmptyParagraph adds an empty paragraph to the header
 func (h *Header) AddEmptyParagraph() *Paragraph {
-	p := newParagraph(h.Root)
+	p := newParagraph(h.Root, paraInPart(&h.Rels))
 	h.Children = append(h.Children, DocumentChild{Para: p})
 	return p
 }
@@ -55,7 +69,16 @@ func (h *Header) Update() error {
 func (h Header) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name.Local = "w:hdr"
 
+	declared := make(map[string]bool, len(h.Attr))
+	for _, attr := range h.Attr {
+		declared[attr.Name.Local] = true
+		start.Attr = append(start.Attr, attr)
+	}
+
 	for key, value := range headerAttrs {
+		if declared[key] {
+			continue
+		}
 		attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
 		start.Attr = append(start.Attr, attr)
 	}
@@ -65,51 +88,132 @@ func (h Header) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
-	for _, child := range h.Children {
-		if child.Para != nil {
-			if err = child.Para.ct.MarshalXML(e, xml.StartElement{}); err != nil {
-				return err
-			}
+	if err = marshalDocumentChildren(e, h.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the Header type
+func (h *Header) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	h.Attr = ctypes.QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
 		}
-		if child.Table != nil {
-			if err = child.Table.ct.MarshalXML(e, xml.StartElement{}); err != nil {
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			child, err := unmarshalDocumentChild(h.Root, &h.Rels, d, elem)
+			if err != nil {
 				return err
 			}
+			h.Children = append(h.Children, child)
+		case xml.EndElement:
+			return nil
 		}
 	}
+}
 
-	return e.EncodeToken(xml.EndElement{Name: start.Name})
+// LoadHeaderXml decodes a header part of an opened document.
+//
+// Parameters:
+//   - rd: The root document the header belongs to.
+//   - rID: The ID of the relationship that targets the part.
+//   - fileName: The path of the part within the package, e.g. "word/header1.xml".
+//   - fileBytes: The XML data of the part.
+//
+// Returns:
+//   - *Header: The decoded header.
+//   - error: An error, if any occurred during the decoding process.
+func LoadHeaderXml(rd *RootDoc, rID string, fileName string, fileBytes []byte) (*Header, error) {
+	h := NewHeader(rd, fileName)
+	if err := xml.Unmarshal(fileBytes, h); err != nil {
+		return nil, err
+	}
+
+	h.rID = rID
+	return h, nil
 }
 
-// AddHeader adds a header to the document
+// AddHeader adds a header of the given type to the last section of the document, replacing the reference to an
+// existing header of that type. See Section.AddHeader.
 func (rd *RootDoc) AddHeader(hdrType stypes.HdrFtrType) *Header {
-	// Generate unique filename for header
-	headerCount := rd.getHeaderCount() + 1
-	filename := fmt.Sprintf("word/header%d.xml", headerCount)
+	return rd.LastSection().AddHeader(hdrType)
+}
+
+// newHeaderPart creates an empty header part and registers it in the document relationships and content types.
+func (rd *RootDoc) newHeaderPart() *Header {
+	// Generate unique filename for header, leaving the parts of an opened document untouched
+	headerIndex := rd.nextPartIndex("header")
+	filename := fmt.Sprintf("word/header%d.xml", headerIndex)
 
 	// Create header instance
 	header := NewHeader(rd, filename)
 
 	// Add relationship to document
-	rID := rd.Document.addRelation(constants.SourceRelationshipHeader, "header"+strconv.Itoa(headerCount)+".xml")
+	header.rID = rd.Document.addRelation(constants.SourceRelationshipHeader, "header"+strconv.Itoa(headerIndex)+".xml")
 
 	// Add content type override
 	contentType := "application/vnd.openxmlformats-officedocument.wordprocessingml.header+xml"
 	rd.ContentType.AddOverride("/"+filename, contentType)
 
-	// Update section properties
-	rd.ensureSectionProperties()
-	rd.Document.Body.SectPr.HeaderReference = &ctypes.HeaderReference{
-		Type: hdrType,
-		ID:   rID,
-	}
-
 	// Store header for automatic serialization
 	rd.Headers = append(rd.Headers, header)
 
 	return header
 }
 
+// Header returns the header of the given type referenced by the last section of the document, or nil if there is none.
+func (rd *RootDoc) Header(hdrType stypes.HdrFtrType) *Header {
+	if rd.Document.Body.SectPr == nil {
+		return nil
+	}
+	return newSection(rd, rd.Document.Body.SectPr).Header(hdrType)
+}
+
+// headerByID returns the header part targeted by the relationship with the given ID, or nil if there is none.
+func (rd *RootDoc) headerByID(rID string) *Header {
+	for _, header := range rd.Headers {
+		if header.rID == rID {
+			return header
+		}
+	}
+	return nil
+}
+
+// SetDifferentFirstPage sets whether the first page of the last section uses its own header and footer.
+// See Section.SetDifferentFirstPage.
+func (rd *RootDoc) SetDifferentFirstPage(enable bool) {
+	rd.LastSection().SetDifferentFirstPage(enable)
+}
+
+// HasDifferentFirstPage reports whether the first page of the last section uses its own header and footer.
+func (rd *RootDoc) HasDifferentFirstPage() bool {
+	if rd.Document.Body.SectPr == nil {
+		return false
+	}
+	return newSection(rd, rd.Document.Body.SectPr).HasDifferentFirstPage()
+}
+
+// SetEvenAndOddHeaders sets whether even and odd pages use different headers and footers
+// (w:evenAndOddHeaders in the document settings). This applies to all sections of the document;
+// odd pages use the default header and footer.
+func (rd *RootDoc) SetEvenAndOddHeaders(enable bool) {
+	rd.settings().SetOnOff("w:evenAndOddHeaders", enable)
+}
+
+// HasEvenAndOddHeaders reports whether even and odd pages use different headers and footers.
+func (rd *RootDoc) HasEvenAndOddHeaders() bool {
+	if rd.Settings == nil {
+		return false
+	}
+	return rd.Settings.OnOff("w:evenAndOddHeaders")
+}
+
 // getHeaderCount returns the current number of headers in the document
 func (rd *RootDoc) getHeaderCount() int {
 	count := 0
@@ -130,6 +234,102 @@ func (rd *RootDoc) ensureSectionProperties() {
 	}
 }
 
+// releaseHdrFtrPart removes the header or footer part targeted by the relationship with the given ID,
+// along with its relationship and content type override, once no section refers to it anymore.
+func (rd *RootDoc) releaseHdrFtrPart(rID string) {
+	if rID == "" || rd.hdrFtrReferenced(rID) {
+		return
+	}
+	rel := rd.Document.relationByID(rID)
+	if rel == nil {
+		return
+	}
+
+	for i, header := range rd.Headers {
+		if header.rID == rID {
+			rd.Headers = append(rd.Headers[:i], rd.Headers[i+1:]...)
+			break
+		}
+	}
+	for i, footer := range rd.Footers {
+		if footer.rID == rID {
+			rd.Footers = append(rd.Footers[:i], rd.Footers[i+1:]...)
+			break
+		}
+	}
+	rd.removePart(rID, path.Join("word", rel.Target))
+}
+
+// hdrFtrReferenced reports whether a section of the document refers to the header or footer with the
+// given relationship ID.
+func (rd *RootDoc) hdrFtrReferenced(rID string) bool {
+	sectPrs := []*ctypes.SectionProp{rd.Document.Body.SectPr}
+	for _, child := range rd.Document.Body.Children {
+		sectPrs = append(sectPrs, paragraphSectPr(child.Para))
+	}
+	for _, sectPr := range sectPrs {
+		if sectPr == nil {
+			continue
+		}
+		for _, ref := range sectPr.HeaderReference {
+			if ref.ID == rID {
+				return true
+			}
+		}
+		for _, ref := range sectPr.FooterReference {
+			if ref.ID == rID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removePart removes a part that the document relates to, with its relationship and content type
+// override.
+func (rd *RootDoc) removePart(rID, name string) {
+	doc := rd.Document
+	kept := doc.DocRels.Relationships[:0]
+	for _, rel := range doc.DocRels.Relationships {
+		if rel.ID != rID {
+			kept = append(kept, rel)
+		}
+	}
+	doc.DocRels.Relationships = kept
+
+	rd.FileMap.Delete(name)
+	rd.FileMap.Delete(partRelsName(name))
+	rd.ContentType.removeOverride("/" + name)
+}
+
+// nextPartIndex returns the lowest index N for which "word/<prefix>N.xml" is not in use, either by a
+// part of the package, a header or footer that has not been written yet, or a content type override.
+func (rd *RootDoc) nextPartIndex(prefix string) int {
+	used := map[string]bool{}
+
+	rd.FileMap.Range(func(key, value interface{}) bool {
+		if keyStr, ok := key.(string); ok {
+			used[keyStr] = true
+		}
+		return true
+	})
+	for _, header := range rd.Headers {
+		used[header.filename] = true
+	}
+	for _, footer := range rd.Footers {
+		used[footer.filename] = true
+	}
+	for _, override := range rd.ContentType.Override {
+		used[strings.TrimPrefix(override.PartName, "/")] = true
+	}
+
+	for i := 1; ; i++ {
+		if !used[fmt.Sprintf("word/%s%d.xml", prefix, i)] {
+			return i
+		}
+	}
+}
+
 // SaveHeader saves the header XML to the document's FileMap
 func (rd *RootDoc) SaveHeader(header *Header) error {
 	xmlData, err := xml.Marshal(header)
@@ -2,9 +2,13 @@ package docx
 
 import (
 	"encoding/xml"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/common/units"
 	"github.com/mrlijnden/godocx/wml/ctypes"
 	"github.com/mrlijnden/godocx/wml/stypes"
 )
@@ -95,11 +99,11 @@ func TestRootDoc_AddHeader(t *testing.T) {
 	}
 
 	// Check that header reference was added
-	if rd.Document.Body.SectPr.HeaderReference == nil {
-		t.Error("Header reference was not added to section properties")
+	if len(rd.Document.Body.SectPr.HeaderReference) != 1 {
+		t.Fatal("Header reference was not added to section properties")
 	}
 
-	if rd.Document.Body.SectPr.HeaderReference.Type != stypes.HdrFtrDefault {
+	if rd.Document.Body.SectPr.HeaderReference[0].Type != stypes.HdrFtrDefault {
 		t.Error("Header reference type is incorrect")
 	}
 }
@@ -176,6 +180,249 @@ func TestRootDoc_SaveHeader(t *testing.T) {
 // Helper function to create a test paragraph
 func newTestParagraph(text string) *Paragraph {
 	return &Paragraph{
-		ct: ctypes.Paragraph{},
+		ct: &ctypes.Paragraph{},
 	}
-}
\ No newline at end of file
+}
+func TestLoadHeaderXml(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+		`<w:p w14:paraId="11223344"><w:r><w:t>Letterhead</w:t></w:r></w:p>` +
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`<w:sdt><w:sdtContent><w:p></w:p></w:sdtContent></w:sdt>` +
+		`</w:hdr>`
+
+	rd := NewRootDoc()
+	header, err := LoadHeaderXml(rd, "rId7", "word/header1.xml", []byte(input))
+	if err != nil {
+		t.Fatalf("LoadHeaderXml failed: %v", err)
+	}
+
+	if header.Root != rd || header.rID != "rId7" || header.filename != "word/header1.xml" {
+		t.Errorf("Unexpected header identity: %+v", header)
+	}
+
+	if len(header.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(header.Children))
+	}
+	if header.Children[0].Para == nil || header.Children[1].Table == nil || header.Children[2].Sdt == nil {
+		t.Errorf("Expected paragraph, table and content control children")
+	}
+
+	output, err := xml.Marshal(header)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+
+	for _, exp := range []string{
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml"`,
+		`<w:p w14:paraId="11223344"><w:r><w:t>Letterhead</w:t></w:r></w:p>`,
+		`<w:t>Cell</w:t>`,
+		`<w:sdt><w:sdtContent><w:p></w:p></w:sdtContent></w:sdt>`,
+	} {
+		if !strings.Contains(string(output), exp) {
+			t.Errorf("Expected XML part not found in actual XML:\nExpected part: %s\nActual XML: %s", exp, output)
+		}
+	}
+
+	if strings.Count(string(output), `xmlns:w=`) != 1 {
+		t.Errorf("Expected a single w namespace declaration, got: %s", output)
+	}
+}
+
+func TestRootDoc_AddHeader_KeepsExistingParts(t *testing.T) {
+	rd := NewRootDoc()
+
+	// Part of an opened document that was loaded into Headers, and another one left in FileMap
+	rd.Headers = append(rd.Headers, NewHeader(rd, "word/header1.xml"))
+	rd.FileMap.Store("word/header2.xml", []byte("<w:hdr></w:hdr>"))
+
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	if header.filename != "word/header3.xml" {
+		t.Errorf("Expected header filename word/header3.xml, got: %s", header.filename)
+	}
+
+	second := rd.AddHeader(stypes.HdrFtrFirst)
+	if second.filename != "word/header4.xml" {
+		t.Errorf("Expected header filename word/header4.xml, got: %s", second.filename)
+	}
+
+	if header.rID == "" || header.rID == second.rID {
+		t.Errorf("Expected distinct relationship IDs, got %q and %q", header.rID, second.rID)
+	}
+}
+
+func TestHeader_RelationsOfPart(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	docRels := len(rd.Document.DocRels.Relationships)
+
+	imgPath := filepath.Join(t.TempDir(), "logo.png")
+	if err := os.WriteFile(imgPath, []byte("png"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	para := header.AddEmptyParagraph()
+	link := para.AddLink("Website", "https://example.com")
+	pic, err := para.AddPicture(imgPath, units.Inch(1), units.Inch(1))
+	if err != nil {
+		t.Fatalf("AddPicture failed: %v", err)
+	}
+
+	if len(rd.Document.DocRels.Relationships) != docRels {
+		t.Errorf("Expected no new document relationships, got %d", len(rd.Document.DocRels.Relationships)-docRels)
+	}
+	if len(header.Rels.Relationships) != 2 {
+		t.Fatalf("Expected 2 header relationships, got %d", len(header.Rels.Relationships))
+	}
+
+	if target, err := link.Target(); err != nil || target != "https://example.com" {
+		t.Errorf("Expected link target https://example.com, got %q (%v)", target, err)
+	}
+
+	embed := pic.Inline.Graphic.Data.Pic.BlipFill.Blip.EmbedID
+	if rel := header.Rels.relationByID(embed); rel == nil || rel.Target != "media/image1.png" {
+		t.Errorf("Expected picture relationship %s in header relationships, got %+v", embed, rel)
+	}
+
+	if err := rd.serializeHeadersAndFooters(); err != nil {
+		t.Fatalf("serializeHeadersAndFooters failed: %v", err)
+	}
+	data, ok := rd.FileMap.Load("word/_rels/" + strings.TrimPrefix(header.filename, "word/") + ".rels")
+	if !ok {
+		t.Fatalf("Expected header relationships to be stored")
+	}
+	if !strings.Contains(string(data.([]byte)), `Target="https://example.com" TargetMode="External"`) {
+		t.Errorf("Expected hyperlink relationship in header relationships: %s", data.([]byte))
+	}
+}
+
+func TestHeader_LinkTargetNotInDocument(t *testing.T) {
+	rd := NewRootDoc()
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID: "rId9", Type: constants.SourceRelationshipHyperLink, Target: "https://document.example", TargetMode: "External",
+	})
+
+	input := `<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:p><w:hyperlink r:id="rId9"><w:r><w:t>Link</w:t></w:r></w:hyperlink></w:p>` +
+		`</w:hdr>`
+	header, err := LoadHeaderXml(rd, "rId7", "word/header1.xml", []byte(input))
+	if err != nil {
+		t.Fatalf("LoadHeaderXml failed: %v", err)
+	}
+
+	links := header.Children[0].Para.Hyperlinks()
+	if len(links) != 1 {
+		t.Fatalf("Expected 1 hyperlink, got %d", len(links))
+	}
+	if _, err := links[0].Target(); err == nil {
+		t.Errorf("Expected the link to be resolved against the header relationships only")
+	}
+
+	header.Rels.Relationships = append(header.Rels.Relationships, &Relationship{
+		ID: "rId9", Type: constants.SourceRelationshipHyperLink, Target: "https://header.example", TargetMode: "External",
+	})
+	if target, err := links[0].Target(); err != nil || target != "https://header.example" {
+		t.Errorf("Expected link target https://header.example, got %q (%v)", target, err)
+	}
+}
+
+func TestRootDoc_AddHeader_MultipleTypes(t *testing.T) {
+	rd := NewRootDoc()
+
+	defaultHeader := rd.AddHeader(stypes.HdrFtrDefault)
+	firstHeader := rd.AddHeader(stypes.HdrFtrFirst)
+	evenHeader := rd.AddHeader(stypes.HdrFtrEven)
+
+	sectPr := rd.Document.Body.SectPr
+	if len(sectPr.HeaderReference) != 3 {
+		t.Fatalf("Expected 3 header references, got %d", len(sectPr.HeaderReference))
+	}
+
+	if rd.Header(stypes.HdrFtrDefault) != defaultHeader || rd.Header(stypes.HdrFtrFirst) != firstHeader || rd.Header(stypes.HdrFtrEven) != evenHeader {
+		t.Error("Headers are not referenced by their type")
+	}
+
+	if !rd.HasDifferentFirstPage() {
+		t.Error("Expected a first page header to turn on titlePg")
+	}
+
+	if !rd.HasEvenAndOddHeaders() {
+		t.Error("Expected an even page header to turn on evenAndOddHeaders")
+	}
+
+	// The settings part added for evenAndOddHeaders is registered once
+	settingsRels := 0
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type == constants.SourceRelationshipSettings {
+			settingsRels++
+		}
+	}
+	if settingsRels != 1 || !rd.ContentType.hasOverride("/word/settings.xml") {
+		t.Errorf("Expected settings part to be registered once, got %d relationships", settingsRels)
+	}
+
+	// Replacing the default header keeps a single reference of that type
+	replaced := rd.AddHeader(stypes.HdrFtrDefault)
+	if len(sectPr.HeaderReference) != 3 || rd.Header(stypes.HdrFtrDefault) != replaced {
+		t.Error("Expected the default header reference to be replaced")
+	}
+
+	// The replaced header is no longer part of the document
+	if len(rd.Headers) != 3 || rd.headerByID(defaultHeader.rID) != nil || rd.Document.relationByID(defaultHeader.rID) != nil {
+		t.Error("Expected the replaced header to be removed")
+	}
+	if rd.ContentType.hasOverride("/"+defaultHeader.filename) || !rd.ContentType.hasOverride("/"+replaced.filename) {
+		t.Error("Expected the content type override of the replaced header to be removed")
+	}
+}
+
+func TestRootDoc_SetDifferentFirstPage(t *testing.T) {
+	rd := NewRootDoc()
+
+	rd.SetDifferentFirstPage(true)
+	if !rd.HasDifferentFirstPage() || rd.Header(stypes.HdrFtrFirst) != nil {
+		t.Error("Expected a different first page without a first page header")
+	}
+
+	rd.SetDifferentFirstPage(false)
+	if rd.HasDifferentFirstPage() || rd.Document.Body.SectPr.TitlePg != nil {
+		t.Error("Expected titlePg to be removed")
+	}
+
+	rd.SetEvenAndOddHeaders(true)
+	rd.SetEvenAndOddHeaders(false)
+	if rd.HasEvenAndOddHeaders() {
+		t.Error("Expected evenAndOddHeaders to be turned off")
+	}
+}
+
+func TestHeader_PictureRoundTrip(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	if err := header.AddEmptyParagraph().addImageData(testPNG(t, 10, 10), ".png", "Logo", 0, 0); err != nil {
+		t.Fatalf("addImageData failed: %v", err)
+	}
+
+	data, err := marshal(header)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `xmlns:wp="`+constants.WMLDrawingNS+`"`) {
+		t.Errorf("Expected the header to declare the wp namespace: %s", data)
+	}
+
+	loaded, err := LoadHeaderXml(rd, header.rID, header.filename, data)
+	if err != nil {
+		t.Fatalf("LoadHeaderXml failed: %v", err)
+	}
+	drawing := loaded.Children[0].Para.ct.Children[0].Run.Children[0].Drawing
+	if drawing == nil || len(drawing.Inline) != 1 {
+		t.Fatalf("Expected the picture to be read back, got %+v", drawing)
+	}
+	if embed := drawing.Inline[0].Graphic.Data.Pic.BlipFill.Blip.EmbedID; header.Rels.relationByID(embed) == nil {
+		t.Errorf("Expected picture relationship %s in header relationships", embed)
+	}
+}
@@ -0,0 +1,1182 @@
+package docx
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mrlijnden/godocx/dml"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// themeFileName is the name of the theme part, which holds the fonts styles refer to by theme.
+const themeFileName = "word/theme/theme1.xml"
+
+// HTMLOptions sets how a document is exported to HTML.
+type HTMLOptions struct {
+	// Directory the pictures of the document are written to, created if needed. The HTML refers to the
+	// pictures with this path, so a relative path is relative to the HTML file. When empty, the pictures
+	// are embedded in the HTML as data URIs.
+	ImageDir string
+
+	// Write a style element followed by the content instead of a complete HTML page, for embedding the
+	// document in another page. The style rules only apply within the element of the content.
+	Fragment bool
+}
+
+// ToHTML writes the body of the document as an HTML page:
+//   - Paragraphs become p elements, and those with the Title and Heading1 to Heading9 styles h1 to h6
+//     elements.
+//   - Numbered and bulleted paragraphs become ol and ul lists, nested by list level.
+//   - Tables become tables. Merged cells span columns and rows.
+//   - Hyperlinks become links, bookmarks anchors the links within the document lead to.
+//   - Pictures become images, embedded or written to opts.ImageDir.
+//   - Footnotes and endnotes are listed at the end.
+//
+// The paragraph, character and table styles used become CSS classes named after the style IDs, whose
+// rules hold the properties of the style and of the styles it is based on. Direct formatting of runs
+// and paragraphs becomes inline styles, bold, italic, struck through, superscript and subscript runs
+// strong, em, s, sup and sub elements.
+//
+// Deleted and hidden text, headers and footers are left out.
+//
+// Example:
+//
+//	f, err := os.Create("report.html")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = document.ToHTML(f, docx.HTMLOptions{})
+func (rd *RootDoc) ToHTML(w io.Writer, opts HTMLOptions) error {
+	h := &htmlWriter{
+		root:   rd,
+		opts:   opts,
+		text:   newTextWriter(rd, TextOptions{}),
+		fonts:  loadThemeFonts(rd),
+		images: map[string]string{},
+		styles: map[string]bool{},
+	}
+
+	var body strings.Builder
+	if err := h.blocks(&body, rd.Document.Body.Children); err != nil {
+		return err
+	}
+	if err := h.notes(&body); err != nil {
+		return err
+	}
+
+	var out strings.Builder
+	if !opts.Fragment {
+		out.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	}
+	out.WriteString("<style>\n" + h.css() + "</style>\n")
+	if !opts.Fragment {
+		out.WriteString("</head>\n<body>\n")
+	}
+	out.WriteString("<div class=\"docx\">\n" + body.String() + "</div>\n")
+	if !opts.Fragment {
+		out.WriteString("</body>\n</html>\n")
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// htmlWriter renders the content of a document as HTML.
+type htmlWriter struct {
+	root  *RootDoc
+	opts  HTMLOptions
+	text  *textWriter    // Counts the list items
+	rels  *Relationships // Relationships of the part being rendered, nil for the document
+	fonts themeFonts
+
+	images   map[string]string // Sources of the pictures written, by relationship target
+	styles   map[string]bool   // IDs of the styles used
+	noteRefs []htmlNote
+}
+
+// htmlNote is a reference to a footnote or an endnote.
+type htmlNote struct {
+	notes  *Notes
+	id     int
+	anchor string // ID of the note, the reference being "ref" after it
+	label  string
+}
+
+// htmlList is a list opened in the HTML, and whether one of its items is open.
+type htmlList struct {
+	tag  string
+	item bool
+}
+
+func (h *htmlWriter) blocks(b *strings.Builder, children []DocumentChild) error {
+	var blocks []ctypes.TCBlockContent
+	for _, child := range children {
+		switch {
+		case child.Para != nil:
+			blocks = append(blocks, ctypes.TCBlockContent{Paragraph: child.Para.ct})
+		case child.Table != nil:
+			blocks = append(blocks, ctypes.TCBlockContent{Table: child.Table.ct})
+		case child.Sdt != nil:
+			blocks = append(blocks, ctypes.TCBlockContent{Sdt: child.Sdt})
+		}
+	}
+	return h.cellBlocks(b, blocks)
+}
+
+func (h *htmlWriter) cellBlocks(b *strings.Builder, blocks []ctypes.TCBlockContent) error {
+	var lists []htmlList
+	endLists := func(depth int) {
+		for len(lists) > depth {
+			if lists[len(lists)-1].item {
+				b.WriteString("</li>\n")
+			}
+			b.WriteString("</" + lists[len(lists)-1].tag + ">\n")
+			lists = lists[:len(lists)-1]
+		}
+	}
+
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			entry, ok := h.text.listEntry(block.Paragraph)
+			if !ok {
+				endLists(0)
+				if err := h.paragraph(b, block.Paragraph); err != nil {
+					return err
+				}
+				continue
+			}
+
+			tag := "ol"
+			if entry.bullet() {
+				tag = "ul"
+			}
+			depth := entry.ilvl + 1
+			endLists(depth)
+			if len(lists) == depth && lists[depth-1].tag != tag {
+				endLists(depth - 1)
+			}
+			if len(lists) == depth && lists[depth-1].item {
+				b.WriteString("</li>\n")
+			}
+			for len(lists) < depth {
+				b.WriteString("<" + tag + listTypeAttr(entry) + ">\n")
+				lists = append(lists, htmlList{tag: tag})
+			}
+			lists[depth-1].item = true
+
+			content, err := h.inline(block.Paragraph.Children)
+			if err != nil {
+				return err
+			}
+			attrs := h.paragraphAttrs(block.Paragraph, true)
+			if tag == "ol" {
+				attrs += ` value="` + strconv.Itoa(entry.number()) + `"`
+			}
+			b.WriteString("<li" + attrs + ">" + content)
+		case block.Table != nil:
+			endLists(0)
+			if err := h.table(b, block.Table); err != nil {
+				return err
+			}
+		case block.Sdt != nil:
+			endLists(0)
+			if err := h.cellBlocks(b, block.Sdt.Content); err != nil {
+				return err
+			}
+		}
+	}
+	endLists(0)
+	return nil
+}
+
+// listTypeAttr returns the type attribute of an ordered list whose items are numbered like the entry.
+func listTypeAttr(entry listEntry) string {
+	if entry.level.NumFmt == nil {
+		return ""
+	}
+	switch entry.level.NumFmt.Val {
+	case stypes.NumFmtLowerLetter:
+		return ` type="a"`
+	case stypes.NumFmtUpperLetter:
+		return ` type="A"`
+	case stypes.NumFmtLowerRoman:
+		return ` type="i"`
+	case stypes.NumFmtUpperRoman:
+		return ` type="I"`
+	}
+	return ""
+}
+
+func (h *htmlWriter) paragraph(b *strings.Builder, p *ctypes.Paragraph) error {
+	tag := "p"
+	if level := headingLevel(p); level > 0 {
+		tag = "h" + strconv.Itoa(level)
+	}
+
+	content, err := h.inline(p.Children)
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		// Empty paragraphs keep the height of a line, as in Word
+		content = "<br>"
+	}
+	b.WriteString("<" + tag + h.paragraphAttrs(p, false) + ">" + content + "</" + tag + ">\n")
+	return nil
+}
+
+// paragraphAttrs returns the class and style attributes of a paragraph. Paragraphs without a style take
+// the default paragraph style. List items leave out their indentation, which the list sets.
+func (h *htmlWriter) paragraphAttrs(p *ctypes.Paragraph, item bool) string {
+	style := ""
+	if p.Property != nil && p.Property.Style != nil {
+		style = p.Property.Style.Val
+	} else if def := h.defaultStyle(stypes.StyleTypeParagraph); def != nil {
+		style = *def.ID
+	}
+
+	var css cssDecls
+	if p.Property != nil {
+		css.paragraph(p.Property, item)
+	}
+
+	attrs := ""
+	if style != "" && !item {
+		attrs += h.classAttr(style)
+	}
+	if len(css) > 0 {
+		attrs += ` style="` + html.EscapeString(css.String()) + `"`
+	}
+	return attrs
+}
+
+// classAttr returns the class attribute of a style, recording that the style is used.
+func (h *htmlWriter) classAttr(styleID string) string {
+	h.styles[styleID] = true
+	return ` class="` + cssClass(styleID) + `"`
+}
+
+// defaultStyle returns the default style of the type, or nil.
+func (h *htmlWriter) defaultStyle(styleType stypes.StyleType) *ctypes.Style {
+	if h.root.DocStyles == nil {
+		return nil
+	}
+	for i, style := range h.root.DocStyles.StyleList {
+		if style.Type == nil || *style.Type != styleType || style.ID == nil || style.Default == nil {
+			continue
+		}
+		switch *style.Default {
+		case stypes.OnOffOne, stypes.OnOffTrue, stypes.OnOffOn:
+			return &h.root.DocStyles.StyleList[i]
+		}
+	}
+	return nil
+}
+
+// inline returns the HTML of paragraph content.
+func (h *htmlWriter) inline(children []ctypes.ParagraphChild) (string, error) {
+	var b strings.Builder
+	for _, child := range children {
+		var content string
+		var err error
+		switch {
+		case child.Run != nil:
+			content, err = h.run(child.Run)
+		case child.Link != nil:
+			content, err = h.link(child.Link)
+		case child.BookmarkStart != nil:
+			if name := child.BookmarkStart.Name; name != "" && name != "_GoBack" {
+				content = `<a id="` + html.EscapeString(name) + `"></a>`
+			}
+		case child.Ins != nil:
+			content, err = h.inline(child.Ins.Children)
+		case child.MoveTo != nil:
+			content, err = h.inline(child.MoveTo.Children)
+		case child.Sdt != nil:
+			content, err = h.inline(child.Sdt.Content)
+		}
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(content)
+	}
+	return b.String(), nil
+}
+
+func (h *htmlWriter) link(link *ctypes.Hyperlink) (string, error) {
+	var children []ctypes.ParagraphChild
+	if link.Run != nil {
+		children = append(children, ctypes.ParagraphChild{Run: link.Run})
+	}
+	children = append(children, link.Children...)
+	content, err := h.inline(children)
+	if err != nil {
+		return "", err
+	}
+
+	target := ""
+	switch {
+	case link.ID != "":
+		if rel := h.root.partRelationByID(h.rels, link.ID); rel != nil {
+			target = rel.Target
+		}
+	case link.Anchor != nil:
+		target = "#" + *link.Anchor
+	}
+	if target == "" {
+		return content, nil
+	}
+	return `<a href="` + html.EscapeString(target) + `">` + content + "</a>", nil
+}
+
+func (h *htmlWriter) run(run *ctypes.Run) (string, error) {
+	prop := run.Property
+	if prop != nil && prop.Vanish != nil && prop.Vanish.Enabled() {
+		return "", nil
+	}
+
+	var content strings.Builder
+	for _, child := range run.Children {
+		switch {
+		case child.Text != nil:
+			content.WriteString(html.EscapeString(child.Text.Text))
+		case child.Tab != nil, child.PTab != nil:
+			content.WriteString("\t")
+		case child.Break != nil, child.CarrRtn != nil:
+			content.WriteString("<br>")
+		case child.NoBreakHyphen != nil:
+			content.WriteString("&#8209;")
+		case child.Drawing != nil:
+			images, err := h.drawing(child.Drawing)
+			if err != nil {
+				return "", err
+			}
+			content.WriteString(images)
+		case child.FootnoteReference != nil:
+			// The reference is a superscript of its own, whatever the formatting of the run
+			return h.noteReference(h.root.Footnotes, child.FootnoteReference.ID, "fn"), nil
+		case child.EndnoteReference != nil:
+			return h.noteReference(h.root.Endnotes, child.EndnoteReference.ID, "en"), nil
+		}
+	}
+	if content.Len() == 0 || prop == nil {
+		return content.String(), nil
+	}
+
+	text := content.String()
+	if prop.VertAlign != nil {
+		switch prop.VertAlign.Val {
+		case stypes.VerticalAlignRunSuperscript:
+			text = "<sup>" + text + "</sup>"
+		case stypes.VerticalAlignRunSubscript:
+			text = "<sub>" + text + "</sub>"
+		}
+	}
+	if enabled(prop.Strike) || enabled(prop.DoubleStrike) {
+		text = "<s>" + text + "</s>"
+	}
+	if enabled(prop.Italic) {
+		text = "<em>" + text + "</em>"
+	}
+	if enabled(prop.Bold) {
+		text = "<strong>" + text + "</strong>"
+	}
+
+	var css cssDecls
+	css.run(prop, h.fonts, true)
+	attrs := ""
+	if prop.Style != nil {
+		attrs += h.classAttr(prop.Style.Val)
+	}
+	if len(css) > 0 {
+		attrs += ` style="` + html.EscapeString(css.String()) + `"`
+	}
+	if attrs != "" {
+		text = "<span" + attrs + ">" + text + "</span>"
+	}
+	return text, nil
+}
+
+func enabled(value *ctypes.OnOff) bool {
+	return value != nil && value.Enabled()
+}
+
+// noteReference records a reference to a note and returns the superscript link to it. The notes of a
+// part are numbered in the order of their references.
+func (h *htmlWriter) noteReference(notes *Notes, id int, prefix string) string {
+	if notes == nil {
+		return ""
+	}
+
+	number := 1
+	for _, ref := range h.noteRefs {
+		if ref.notes == notes {
+			number++
+		}
+	}
+	label := strconv.Itoa(number)
+	if prefix == "en" {
+		label = romanNumeral(number)
+	}
+	anchor := prefix + strconv.Itoa(number)
+	h.noteRefs = append(h.noteRefs, htmlNote{notes: notes, id: id, anchor: anchor, label: label})
+
+	return `<sup><a href="#` + anchor + `" id="` + anchor + `ref">` + label + "</a></sup>"
+}
+
+// notes writes the footnotes and then the endnotes referenced, including those referenced by notes.
+func (h *htmlWriter) notes(b *strings.Builder) error {
+	for _, part := range []*Notes{h.root.Footnotes, h.root.Endnotes} {
+		var items strings.Builder
+		for i := 0; i < len(h.noteRefs); i++ {
+			ref := h.noteRefs[i]
+			note := ref.notes.Note(ref.id)
+			if ref.notes != part || note == nil {
+				continue
+			}
+			var content strings.Builder
+			h.rels = note.rels
+			if err := h.blocks(&content, note.Children); err != nil {
+				return err
+			}
+			items.WriteString(`<li id="` + ref.anchor + `">` + "\n" + content.String() +
+				`<a href="#` + ref.anchor + `ref">&#8617;</a></li>` + "\n")
+		}
+		if items.Len() == 0 {
+			continue
+		}
+
+		class, list := "footnotes", "<ol>"
+		if part == h.root.Endnotes {
+			class, list = "endnotes", `<ol type="i">`
+		}
+		b.WriteString(`<section class="` + class + `">` + "\n<hr>\n" + list + "\n" + items.String() + "</ol>\n</section>\n")
+	}
+	return nil
+}
+
+// htmlCell is a cell of a table with its place in the grid.
+type htmlCell struct {
+	cell    *ctypes.Cell
+	col     int
+	span    int
+	rowSpan int
+	merged  bool // Continues the cell above
+}
+
+func (h *htmlWriter) table(b *strings.Builder, tbl *ctypes.Table) error {
+	var rows []*ctypes.Row
+	var addRows func(rowContents []ctypes.RowContent)
+	addRows = func(rowContents []ctypes.RowContent) {
+		for _, rowContent := range rowContents {
+			switch {
+			case rowContent.Sdt != nil:
+				addRows(rowContent.Sdt.Content)
+			case rowContent.Row != nil:
+				if prop := rowContent.Row.Property; prop != nil && prop.Del != nil {
+					continue
+				}
+				rows = append(rows, rowContent.Row)
+			}
+		}
+	}
+	addRows(tbl.RowContents)
+
+	grid := make([][]*htmlCell, len(rows))
+	for r, row := range rows {
+		col := 0
+		if row.Property != nil && row.Property.GridBefore != nil {
+			col = row.Property.GridBefore.Val
+		}
+		for _, cell := range rowCellList(row.Contents) {
+			c := &htmlCell{cell: cell, col: col, span: 1, rowSpan: 1}
+			if prop := cell.Property; prop != nil {
+				if prop.GridSpan != nil && prop.GridSpan.Val > 1 {
+					c.span = prop.GridSpan.Val
+				}
+				if prop.VMerge != nil && (prop.VMerge.Val == nil || *prop.VMerge.Val == stypes.MergeCellContinue) {
+					c.merged = r > 0
+				}
+			}
+			grid[r] = append(grid[r], c)
+			col += c.span
+		}
+	}
+
+	// A cell spans the rows whose cells in the same column continue it
+	for r := range grid {
+		for _, c := range grid[r] {
+			if c.merged {
+				continue
+			}
+			for below := r + 1; below < len(grid); below++ {
+				next := gridCell(grid[below], c.col)
+				if next == nil || !next.merged {
+					break
+				}
+				c.rowSpan++
+			}
+		}
+	}
+
+	attrs := ""
+	if tbl.TableProp.Style != nil {
+		attrs += h.classAttr(tbl.TableProp.Style.Val)
+	}
+	var css cssDecls
+	css.table(&tbl.TableProp)
+	if len(css) > 0 {
+		attrs += ` style="` + html.EscapeString(css.String()) + `"`
+	}
+	b.WriteString("<table" + attrs + ">\n")
+
+	header := 0
+	for header < len(rows) && rows[header].Property != nil && enabled(rows[header].Property.Header) {
+		header++
+	}
+	for r, row := range grid {
+		switch {
+		case r == 0 && header > 0:
+			b.WriteString("<thead>\n")
+		case r == header:
+			if header > 0 {
+				b.WriteString("</thead>\n")
+			}
+			b.WriteString("<tbody>\n")
+		}
+
+		b.WriteString("<tr>\n")
+		for _, c := range row {
+			if c.merged {
+				continue
+			}
+			tag := "td"
+			if r < header {
+				tag = "th"
+			}
+
+			attrs := ""
+			if c.span > 1 {
+				attrs += ` colspan="` + strconv.Itoa(c.span) + `"`
+			}
+			if c.rowSpan > 1 {
+				attrs += ` rowspan="` + strconv.Itoa(c.rowSpan) + `"`
+			}
+			var css cssDecls
+			css.cell(c.cell.Property, tbl.TableProp.Borders)
+			if len(css) > 0 {
+				attrs += ` style="` + html.EscapeString(css.String()) + `"`
+			}
+
+			b.WriteString("<" + tag + attrs + ">\n")
+			if err := h.cellBlocks(b, c.cell.Contents); err != nil {
+				return err
+			}
+			b.WriteString("</" + tag + ">\n")
+		}
+		b.WriteString("</tr>\n")
+	}
+	if header == len(grid) && header > 0 {
+		b.WriteString("</thead>\n")
+	} else if len(grid) > 0 {
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+	return nil
+}
+
+// rowCellList returns the cells of a row, including those within content controls.
+func rowCellList(cellContents []ctypes.TRCellContent) []*ctypes.Cell {
+	var cells []*ctypes.Cell
+	for _, cellContent := range cellContents {
+		switch {
+		case cellContent.Sdt != nil:
+			cells = append(cells, rowCellList(cellContent.Sdt.Content)...)
+		case cellContent.Cell != nil:
+			cells = append(cells, cellContent.Cell)
+		}
+	}
+	return cells
+}
+
+// gridCell returns the cell of the row that starts at the grid column, or nil.
+func gridCell(row []*htmlCell, col int) *htmlCell {
+	for _, c := range row {
+		if c.col == col {
+			return c
+		}
+	}
+	return nil
+}
+
+// drawing returns the images of the pictures of a drawing.
+func (h *htmlWriter) drawing(drawing *dml.Drawing) (string, error) {
+	type picture struct {
+		docProp       dml.DocProp
+		graphic       dml.Graphic
+		width, height uint64
+	}
+	var pictures []picture
+	for _, inline := range drawing.Inline {
+		pictures = append(pictures, picture{inline.DocProp, inline.Graphic, inline.Extent.Width, inline.Extent.Height})
+	}
+	for _, anchor := range drawing.Anchor {
+		pictures = append(pictures, picture{anchor.DocProp, anchor.Graphic, anchor.Extent.Width, anchor.Extent.Height})
+	}
+
+	var b strings.Builder
+	for _, pic := range pictures {
+		data := pic.graphic.Data
+		if data == nil || data.Pic == nil || data.Pic.BlipFill.Blip == nil {
+			continue
+		}
+		rel := h.root.partRelationByID(h.rels, data.Pic.BlipFill.Blip.EmbedID)
+		if rel == nil {
+			continue
+		}
+
+		src, err := h.image(rel.Target)
+		if err != nil {
+			return "", err
+		}
+		alt := pic.docProp.Description
+		if alt == "" {
+			alt = pic.docProp.Name
+		}
+
+		// Extents are in EMUs, 9525 to a CSS pixel
+		fmt.Fprintf(&b, `<img src="%s" alt="%s" width="%d" height="%d">`,
+			html.EscapeString(src), html.EscapeString(alt), pic.width/9525, pic.height/9525)
+	}
+	return b.String(), nil
+}
+
+// image returns the source of the picture stored at the relationship target: a data URI, or the path of
+// the file it is written to in the image directory.
+func (h *htmlWriter) image(target string) (string, error) {
+	if src, ok := h.images[target]; ok {
+		return src, nil
+	}
+
+	content, ok := h.root.FileMap.Load(path.Join("word", target))
+	if !ok {
+		return "", fmt.Errorf("picture %s not found in the package", target)
+	}
+
+	var src string
+	if h.opts.ImageDir == "" {
+		mime, err := MIMEFromExt(path.Ext(target))
+		if err != nil {
+			mime = "application/octet-stream"
+		}
+		src = "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(content.([]byte))
+	} else {
+		if err := os.MkdirAll(h.opts.ImageDir, 0o755); err != nil {
+			return "", err
+		}
+		name := path.Base(target)
+		if err := os.WriteFile(filepath.Join(h.opts.ImageDir, name), content.([]byte), 0o644); err != nil {
+			return "", err
+		}
+		src = path.Join(filepath.ToSlash(h.opts.ImageDir), name)
+	}
+
+	h.images[target] = src
+	return src, nil
+}
+
+// css returns the style rules of the document: those of the defaults, then those of the styles used,
+// in the order the document defines them.
+func (h *htmlWriter) css() string {
+	var b strings.Builder
+	b.WriteString(".docx h1, .docx h2, .docx h3, .docx h4, .docx h5, .docx h6 { font-size: inherit; font-weight: inherit; }\n")
+	b.WriteString(".docx p, .docx h1, .docx h2, .docx h3, .docx h4, .docx h5, .docx h6, .docx li { margin: 0; white-space: pre-wrap; tab-size: 4; }\n")
+	b.WriteString(".docx table { border-collapse: collapse; }\n")
+	b.WriteString(".docx td, .docx th { vertical-align: top; text-align: inherit; font-weight: inherit; padding: 0 5.4pt; }\n")
+	b.WriteString(".docx img { max-width: 100%; height: auto; }\n")
+
+	page := cssDecls{{"max-width", twipsCSS(h.root.textWidth())}, {"margin", "0 auto"}}
+	var paragraphs cssDecls
+	if styles := h.root.DocStyles; styles != nil && styles.DocDefaults != nil {
+		if def := styles.DocDefaults.RunProp; def != nil && def.RunProp != nil {
+			page.run(def.RunProp, h.fonts, false)
+		}
+		if def := styles.DocDefaults.ParaProp; def != nil && def.ParaProp != nil {
+			paragraphs.paragraph(def.ParaProp, false)
+		}
+	}
+	b.WriteString(".docx { " + page.String() + "; }\n")
+	if len(paragraphs) > 0 {
+		b.WriteString(".docx p, .docx h1, .docx h2, .docx h3, .docx h4, .docx h5, .docx h6, .docx li { " + paragraphs.String() + "; }\n")
+	}
+
+	if h.root.DocStyles == nil {
+		return b.String()
+	}
+	for _, style := range h.root.DocStyles.StyleList {
+		if style.ID == nil || style.Type == nil || !h.styles[*style.ID] {
+			continue
+		}
+		class := ".docx ." + cssClass(*style.ID)
+
+		var own, cells cssDecls
+		for _, s := range h.styleChain(*style.ID, *style.Type) {
+			if s.ParaProp != nil {
+				own.paragraph(s.ParaProp, false)
+			}
+			if s.RunProp != nil {
+				own.run(s.RunProp, h.fonts, false)
+			}
+			if *style.Type == stypes.StyleTypeTable && s.TableProp != nil {
+				cells.insideBorders(s.TableProp.Borders)
+			}
+		}
+
+		if *style.Type == stypes.StyleTypeTable {
+			var table, text cssDecls
+			for _, decl := range own {
+				if strings.HasPrefix(decl.name, "margin") || decl.name == "line-height" || decl.name == "text-align" {
+					text = append(text, decl)
+				} else {
+					table = append(table, decl)
+				}
+			}
+			for _, s := range h.styleChain(*style.ID, *style.Type) {
+				if s.TableProp != nil {
+					table.table(s.TableProp)
+				}
+			}
+			class = ".docx table." + cssClass(*style.ID)
+			writeRule(&b, class, table)
+			writeRule(&b, class+" > * > tr > td, "+class+" > * > tr > th", cells)
+			writeRule(&b, class+" p", text)
+			continue
+		}
+		writeRule(&b, class, own)
+	}
+	return b.String()
+}
+
+func writeRule(b *strings.Builder, selector string, decls cssDecls) {
+	if len(decls) > 0 {
+		b.WriteString(selector + " { " + decls.String() + "; }\n")
+	}
+}
+
+// styleChain returns the style with the given ID and the styles it is based on, the base style first.
+func (h *htmlWriter) styleChain(id string, styleType stypes.StyleType) []*ctypes.Style {
+	var chain []*ctypes.Style
+	seen := map[string]bool{}
+	for id != "" && !seen[id] {
+		seen[id] = true
+		style := h.root.GetStyleByID(id, styleType)
+		if style == nil {
+			break
+		}
+		chain = append([]*ctypes.Style{style}, chain...)
+		id = ""
+		if style.BasedOn != nil {
+			id = style.BasedOn.Val
+		}
+	}
+	return chain
+}
+
+// cssClass returns the class name of a style ID, whose characters outside letters, digits, hyphens and
+// underscores become underscores.
+func cssClass(id string) string {
+	var b strings.Builder
+	for i, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == '-' && i > 0:
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// themeFonts holds the latin fonts of the theme.
+type themeFonts struct {
+	Major, Minor string
+}
+
+// loadThemeFonts returns the fonts of the theme of the document, empty if it has no theme.
+func loadThemeFonts(rd *RootDoc) themeFonts {
+	var fonts themeFonts
+	content, ok := rd.FileMap.Load(themeFileName)
+	if !ok {
+		return fonts
+	}
+
+	var theme struct {
+		Major struct {
+			Typeface string `xml:"typeface,attr"`
+		} `xml:"themeElements>fontScheme>majorFont>latin"`
+		Minor struct {
+			Typeface string `xml:"typeface,attr"`
+		} `xml:"themeElements>fontScheme>minorFont>latin"`
+	}
+	if err := xml.Unmarshal(content.([]byte), &theme); err != nil {
+		return fonts
+	}
+	fonts.Major = theme.Major.Typeface
+	fonts.Minor = theme.Minor.Typeface
+	return fonts
+}
+
+// cssDecl is a CSS declaration.
+type cssDecl struct {
+	name, value string
+}
+
+// cssDecls is a list of CSS declarations, where later values of a property replace earlier ones.
+type cssDecls []cssDecl
+
+func (d *cssDecls) set(name, value string) {
+	for i := range *d {
+		if (*d)[i].name == name {
+			(*d)[i].value = value
+			return
+		}
+	}
+	*d = append(*d, cssDecl{name, value})
+}
+
+func (d cssDecls) String() string {
+	parts := make([]string, len(d))
+	for i, decl := range d {
+		parts[i] = decl.name + ": " + decl.value
+	}
+	return strings.Join(parts, "; ")
+}
+
+// run adds the declarations of run properties. Direct formatting leaves out bold, italic, struck
+// through, superscript and subscript text, which have elements of their own.
+func (d *cssDecls) run(prop *ctypes.RunProperty, fonts themeFonts, direct bool) {
+	if font := runFont(prop.Fonts, fonts); font != "" {
+		d.set("font-family", "'"+strings.ReplaceAll(font, "'", "")+"'")
+	}
+	if prop.Size != nil && prop.Size.Value > 0 {
+		d.set("font-size", strconv.FormatFloat(float64(prop.Size.Value)/2, 'f', -1, 64)+"pt")
+	}
+	if prop.Color != nil && prop.Color.Val != "" && prop.Color.Val != "auto" {
+		d.set("color", "#"+prop.Color.Val)
+	}
+	if prop.Highlight != nil {
+		if color, ok := highlightColors[prop.Highlight.Val]; ok {
+			d.set("background-color", color)
+		}
+	}
+	if fill := shadingFill(prop.Shading); fill != "" {
+		d.set("background-color", fill)
+	}
+	if prop.Caps != nil {
+		d.set("text-transform", cssToggle(prop.Caps.Enabled(), "uppercase", "none"))
+	}
+	if prop.SmallCaps != nil {
+		d.set("font-variant", cssToggle(prop.SmallCaps.Enabled(), "small-caps", "normal"))
+	}
+	if prop.Spacing != nil && prop.Spacing.Val != 0 {
+		d.set("letter-spacing", twipsCSS(prop.Spacing.Val))
+	}
+	if prop.Vanish != nil && prop.Vanish.Enabled() {
+		d.set("display", "none")
+	}
+
+	if direct {
+		if prop.Bold != nil && !prop.Bold.Enabled() {
+			d.set("font-weight", "normal")
+		}
+		if prop.Italic != nil && !prop.Italic.Enabled() {
+			d.set("font-style", "normal")
+		}
+		if underline := underlineCSS(prop.Underline); underline != "" {
+			d.set("text-decoration", underline)
+		}
+		return
+	}
+
+	if prop.Bold != nil {
+		d.set("font-weight", cssToggle(prop.Bold.Enabled(), "bold", "normal"))
+	}
+	if prop.Italic != nil {
+		d.set("font-style", cssToggle(prop.Italic.Enabled(), "italic", "normal"))
+	}
+	var lines []string
+	if underline := underlineCSS(prop.Underline); underline != "" && underline != "none" {
+		lines = append(lines, underline)
+	}
+	if enabled(prop.Strike) || enabled(prop.DoubleStrike) {
+		lines = append(lines, "line-through")
+	}
+	if len(lines) > 0 {
+		d.set("text-decoration", strings.Join(lines, " "))
+	} else if prop.Underline != nil || prop.Strike != nil {
+		d.set("text-decoration", "none")
+	}
+	if prop.VertAlign != nil {
+		switch prop.VertAlign.Val {
+		case stypes.VerticalAlignRunSuperscript:
+			d.set("vertical-align", "super")
+			d.set("font-size", "smaller")
+		case stypes.VerticalAlignRunSubscript:
+			d.set("vertical-align", "sub")
+			d.set("font-size", "smaller")
+		}
+	}
+}
+
+// paragraph adds the declarations of paragraph properties. List items leave out the indentation.
+func (d *cssDecls) paragraph(prop *ctypes.ParagraphProp, item bool) {
+	if prop.Justification != nil {
+		switch prop.Justification.Val {
+		case stypes.JustificationCenter:
+			d.set("text-align", "center")
+		case stypes.JustificationRight:
+			d.set("text-align", "right")
+		case stypes.JustificationBoth, stypes.JustificationDistribute:
+			d.set("text-align", "justify")
+		case stypes.JustificationLeft:
+			d.set("text-align", "left")
+		}
+	}
+	if spacing := prop.Spacing; spacing != nil {
+		if spacing.Before != nil {
+			d.set("margin-top", twipsCSS(int(*spacing.Before)))
+		}
+		if spacing.After != nil {
+			d.set("margin-bottom", twipsCSS(int(*spacing.After)))
+		}
+		if spacing.Line != nil && *spacing.Line > 0 {
+			if spacing.LineRule == nil || *spacing.LineRule == stypes.LineSpacingRuleAuto {
+				d.set("line-height", strconv.FormatFloat(float64(*spacing.Line)/240, 'f', 2, 64))
+			} else {
+				d.set("line-height", twipsCSS(*spacing.Line))
+			}
+		}
+	}
+	if indent := prop.Indent; indent != nil && !item {
+		if indent.Left != nil {
+			d.set("margin-left", twipsCSS(*indent.Left))
+		}
+		if indent.Right != nil {
+			d.set("margin-right", twipsCSS(*indent.Right))
+		}
+		if indent.FirstLine != nil {
+			d.set("text-indent", twipsCSS(int(*indent.FirstLine)))
+		}
+		if indent.Hanging != nil {
+			d.set("text-indent", twipsCSS(-int(*indent.Hanging)))
+		}
+	}
+	if fill := shadingFill(prop.Shading); fill != "" {
+		d.set("background-color", fill)
+	}
+	if border := prop.Border; border != nil {
+		d.border("border-top", border.Top)
+		d.border("border-left", border.Left)
+		d.border("border-bottom", border.Bottom)
+		d.border("border-right", border.Right)
+	}
+	if enabled(prop.PageBreakBefore) {
+		d.set("break-before", "page")
+	}
+}
+
+// table adds the declarations of table properties.
+func (d *cssDecls) table(prop *ctypes.TableProp) {
+	if width := prop.Width; width != nil && width.Width != nil && width.WidthType != nil {
+		switch *width.WidthType {
+		case stypes.TableWidthDxa:
+			d.set("width", twipsCSS(*width.Width))
+		case stypes.TableWidthPct:
+			d.set("width", strconv.FormatFloat(float64(*width.Width)/50, 'f', -1, 64)+"%")
+		}
+	}
+	if prop.Justification != nil {
+		switch prop.Justification.Val {
+		case stypes.JustificationCenter:
+			d.set("margin-left", "auto")
+			d.set("margin-right", "auto")
+		case stypes.JustificationRight:
+			d.set("margin-left", "auto")
+		}
+	}
+	if border := prop.Borders; border != nil {
+		d.border("border-top", border.Top)
+		d.border("border-left", border.Left)
+		d.border("border-bottom", border.Bottom)
+		d.border("border-right", border.Right)
+	}
+	if fill := shadingFill(prop.Shading); fill != "" {
+		d.set("background-color", fill)
+	}
+}
+
+// insideBorders adds the borders between the cells of a table as borders of the cells.
+func (d *cssDecls) insideBorders(borders *ctypes.TableBorders) {
+	if borders == nil {
+		return
+	}
+	d.border("border-top", borders.InsideH)
+	d.border("border-bottom", borders.InsideH)
+	d.border("border-left", borders.InsideV)
+	d.border("border-right", borders.InsideV)
+}
+
+// cell adds the declarations of cell properties, after the inside borders of the table.
+func (d *cssDecls) cell(prop *ctypes.CellProperty, tableBorders *ctypes.TableBorders) {
+	d.insideBorders(tableBorders)
+	if prop == nil {
+		return
+	}
+
+	if width := prop.Width; width != nil && width.Width != nil && width.WidthType != nil &&
+		*width.WidthType == stypes.TableWidthDxa {
+		d.set("width", twipsCSS(*width.Width))
+	}
+	if fill := shadingFill(prop.Shading); fill != "" {
+		d.set("background-color", fill)
+	}
+	if prop.VAlign != nil {
+		switch prop.VAlign.Val {
+		case stypes.VerticalJcCenter:
+			d.set("vertical-align", "middle")
+		case stypes.VerticalJcBottom:
+			d.set("vertical-align", "bottom")
+		}
+	}
+	if border := prop.Borders; border != nil {
+		d.border("border-top", border.Top)
+		d.border("border-left", border.Left)
+		d.border("border-bottom", border.Bottom)
+		d.border("border-right", border.Right)
+	}
+}
+
+// border adds a border property, if the border is set.
+func (d *cssDecls) border(name string, border *ctypes.Border) {
+	if border == nil {
+		return
+	}
+
+	style := "solid"
+	switch border.Val {
+	case stypes.BorderStyleNone, stypes.BorderStyleNil:
+		d.set(name, "none")
+		return
+	case stypes.BorderStyleDouble:
+		style = "double"
+	case stypes.BorderStyleDotted:
+		style = "dotted"
+	case stypes.BorderStyleDashed, stypes.BorderStyleDashSmallGap:
+		style = "dashed"
+	}
+
+	// Border widths are in eighths of a point
+	width := 4
+	if border.Size != nil && *border.Size > 0 {
+		width = *border.Size
+	}
+	color := "currentColor"
+	if border.Color != nil && *border.Color != "" && *border.Color != "auto" {
+		color = "#" + *border.Color
+	}
+	d.set(name, strconv.FormatFloat(float64(width)/8, 'f', -1, 64)+"pt "+style+" "+color)
+}
+
+// runFont returns the latin font of the run fonts, looked up in the theme fonts if needed.
+func runFont(runFonts *ctypes.RunFonts, fonts themeFonts) string {
+	if runFonts == nil {
+		return ""
+	}
+	if runFonts.Ascii != "" {
+		return runFonts.Ascii
+	}
+	switch {
+	case strings.HasPrefix(string(runFonts.AsciiTheme), "major"):
+		return fonts.Major
+	case strings.HasPrefix(string(runFonts.AsciiTheme), "minor"):
+		return fonts.Minor
+	}
+	return runFonts.HAnsi
+}
+
+// underlineCSS returns the text decoration of an underline, or "".
+func underlineCSS(underline *ctypes.GenSingleStrVal[stypes.Underline]) string {
+	if underline == nil {
+		return ""
+	}
+	value := string(underline.Val)
+	switch {
+	case value == "none":
+		return "none"
+	case strings.Contains(value, "ouble"):
+		return "underline double"
+	case strings.HasPrefix(value, "dot") && !strings.Contains(value, "Dash"):
+		return "underline dotted"
+	case strings.Contains(strings.ToLower(value), "dash"):
+		return "underline dashed"
+	case strings.HasPrefix(value, "wav"):
+		return "underline wavy"
+	}
+	return "underline"
+}
+
+// shadingFill returns the CSS color of the fill of a shading, or "".
+func shadingFill(shading *ctypes.Shading) string {
+	if shading == nil || shading.Fill == nil || *shading.Fill == "" || *shading.Fill == "auto" {
+		return ""
+	}
+	return "#" + *shading.Fill
+}
+
+// twipsCSS returns a length in twips as CSS points.
+func twipsCSS(twips int) string {
+	return strconv.FormatFloat(float64(twips)/20, 'f', -1, 64) + "pt"
+}
+
+func cssToggle(on bool, onValue, offValue string) string {
+	if on {
+		return onValue
+	}
+	return offValue
+}
+
+// highlightColors maps the highlight colors of runs to CSS colors.
+var highlightColors = map[string]string{
+	"black":       "black",
+	"blue":        "blue",
+	"cyan":        "cyan",
+	"green":       "lime",
+	"magenta":     "magenta",
+	"red":         "red",
+	"yellow":      "yellow",
+	"white":       "white",
+	"darkBlue":    "navy",
+	"darkCyan":    "teal",
+	"darkGreen":   "green",
+	"darkMagenta": "purple",
+	"darkRed":     "maroon",
+	"darkYellow":  "olive",
+	"darkGray":    "gray",
+	"lightGray":   "silver",
+}
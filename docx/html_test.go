@@ -0,0 +1,166 @@
+package docx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// addTestStyle adds a style with the given properties to the document.
+func addTestStyle(rd *RootDoc, styleType stypes.StyleType, id string, basedOn string, runProp *ctypes.RunProperty) {
+	style := ctypes.Style{
+		Type:    internal.ToPtr(styleType),
+		ID:      internal.ToPtr(id),
+		Name:    ctypes.NewCTString(id),
+		RunProp: runProp,
+	}
+	if basedOn != "" {
+		style.BasedOn = ctypes.NewCTString(basedOn)
+	}
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, style)
+}
+
+func TestRootDoc_ToHTML(t *testing.T) {
+	rd := NewRootDoc()
+	addTestStyle(rd, stypes.StyleTypeParagraph, "Normal", "", &ctypes.RunProperty{Fonts: &ctypes.RunFonts{Ascii: "Georgia"}})
+	rd.DocStyles.StyleList[0].Default = internal.ToPtr(stypes.OnOffOne)
+	addTestStyle(rd, stypes.StyleTypeParagraph, "Heading1", "Normal", &ctypes.RunProperty{
+		Bold:  &ctypes.OnOff{},
+		Color: &ctypes.Color{Val: "2F5496"},
+	})
+	addTestStyle(rd, stypes.StyleTypeCharacter, "Code", "", &ctypes.RunProperty{Fonts: &ctypes.RunFonts{Ascii: "Consolas"}})
+	addTestStyle(rd, stypes.StyleTypeParagraph, "Unused", "", &ctypes.RunProperty{Bold: &ctypes.OnOff{}})
+
+	rd.AddParagraph("Summary").Style("Heading1")
+	p := rd.AddEmptyParagraph()
+	p.AddText("Sales ")
+	p.AddText("grew").Bold(true).Color("FF0000").Highlight("yellow")
+	p.AddText(" <fast> ")
+	p.AddText("go test").Style("Code")
+	p.AddLink("dashboard", "https://example.com/?a=1&b=2")
+	p.AddFootnote("Unaudited.")
+	p.Justification(stypes.JustificationCenter)
+
+	steps := rd.AddNumberedList()
+	steps.AddItem("Plan", 0)
+	steps.AddItem("Detail", 1)
+	steps.AddItem("Review", 0)
+	rd.AddEmptyParagraph()
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToHTML(&out, HTMLOptions{}))
+	html := out.String()
+
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>\n"))
+	assert.Contains(t, html, `<h1 class="Heading1">Summary</h1>`)
+	assert.Contains(t, html, `<p class="Normal" style="text-align: center">Sales `+
+		`<span style="color: #FF0000; background-color: yellow"><strong>grew</strong></span> &lt;fast&gt; `+
+		`<span class="Code">go test</span>`+
+		`<a href="https://example.com/?a=1&amp;b=2"><span class="Hyperlink">dashboard</span></a>`+
+		`<sup><a href="#fn1" id="fn1ref">1</a></sup></p>`)
+	assert.Contains(t, html, "<ol>\n<li value=\"1\">Plan<ol type=\"a\">\n<li value=\"1\">Detail</li>\n</ol>\n</li>\n"+
+		"<li value=\"2\">Review</li>\n</ol>\n")
+	assert.Contains(t, html, `<p class="Normal"><br></p>`)
+	assert.Contains(t, html, `<section class="footnotes">`)
+	assert.Contains(t, html, `Unaudited.`)
+
+	// Styles hold the properties of the styles they are based on, unused styles are left out
+	assert.Contains(t, html, ".docx .Heading1 { font-family: 'Georgia'; color: #2F5496; font-weight: bold; }\n")
+	assert.Contains(t, html, ".docx .Code { font-family: 'Consolas'; }\n")
+	assert.NotContains(t, html, ".Unused")
+}
+
+func TestRootDoc_ToHTML_Tables(t *testing.T) {
+	rd := NewRootDoc()
+	tbl := rd.AddTable()
+	tbl.Style("TableGrid")
+
+	header := tbl.AddRow()
+	header.ct.Property = &ctypes.RowProperty{Header: &ctypes.OnOff{}}
+	header.AddCell().ColSpan(2).AddParagraph("Region")
+	header.AddCell().AddParagraph("Sales")
+
+	row := tbl.AddRow()
+	merged := row.AddCell()
+	merged.ct.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: internal.ToPtr(stypes.MergeCellRestart)}
+	merged.AddParagraph("North")
+	row.AddCell().AddParagraph("East")
+	row.AddCell().AddParagraph("120")
+
+	row = tbl.AddRow()
+	row.AddCell().ct.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+	row.AddCell().AddParagraph("West")
+	row.AddCell().AddParagraph("80").Justification(stypes.JustificationRight)
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToHTML(&out, HTMLOptions{Fragment: true}))
+	html := out.String()
+
+	assert.True(t, strings.HasPrefix(html, "<style>\n"))
+	assert.NotContains(t, html, "<html>")
+	assert.Contains(t, html, "<table class=\"TableGrid\">\n<thead>\n<tr>\n<th colspan=\"2\"")
+	assert.Contains(t, html, "</thead>\n<tbody>\n")
+	assert.Contains(t, html, `<td rowspan="2"`)
+	assert.Equal(t, 1, strings.Count(html, "North"))
+	assert.Equal(t, 5, strings.Count(html, "<td"))
+	assert.Contains(t, html, `<p style="text-align: right">80</p>`)
+}
+
+func TestRootDoc_ToHTML_Images(t *testing.T) {
+	rd := NewRootDoc()
+	imgPath := filepath.Join(t.TempDir(), "logo.png")
+	assert.NoError(t, os.WriteFile(imgPath, []byte("\x89PNG\r\n\x1a\n"), 0o644))
+	_, err := rd.AddEmptyParagraph().AddPicture(imgPath, 1, 0.5)
+	assert.NoError(t, err)
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToHTML(&out, HTMLOptions{}))
+	assert.Contains(t, out.String(), `<img src="data:image/png;base64,iVBORw0KGgo=" alt="Image1" width="96" height="48">`)
+
+	dir := filepath.Join(t.TempDir(), "images")
+	out.Reset()
+	assert.NoError(t, rd.ToHTML(&out, HTMLOptions{ImageDir: dir}))
+	assert.Contains(t, out.String(), `<img src="`+filepath.ToSlash(dir)+`/image1.png"`)
+	data, err := os.ReadFile(filepath.Join(dir, "image1.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("\x89PNG\r\n\x1a\n"), data)
+}
+
+func TestRootDoc_ToHTML_NoteLinks(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Revenue").AddFootnote("Source:").AddParagraph("").AddLink("the report", "https://example.com/report")
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToHTML(&out, HTMLOptions{}))
+	assert.Contains(t, out.String(), `<a href="https://example.com/report"><span class="Hyperlink">the report</span></a>`)
+}
+
+func TestCSSDecls(t *testing.T) {
+	var css cssDecls
+	css.run(&ctypes.RunProperty{
+		Size:      &ctypes.FontSize{Value: 23},
+		Underline: ctypes.NewGenSingleStrVal(stypes.UnderlineDouble),
+		Strike:    &ctypes.OnOff{},
+		Caps:      &ctypes.OnOff{},
+		Shading:   &ctypes.Shading{Val: stypes.ShdClear, Fill: internal.ToPtr("D9D9D9")},
+		Fonts:     &ctypes.RunFonts{AsciiTheme: "majorHAnsi"},
+	}, themeFonts{Major: "Calibri Light"}, false)
+	assert.Equal(t, "font-family: 'Calibri Light'; font-size: 11.5pt; background-color: #D9D9D9; "+
+		"text-transform: uppercase; text-decoration: underline double line-through", css.String())
+
+	css = nil
+	css.paragraph(&ctypes.ParagraphProp{
+		Spacing: &ctypes.Spacing{Before: internal.ToPtr(uint64(240)), Line: internal.ToPtr(360)},
+		Indent:  &ctypes.Indent{Left: internal.ToPtr(720), Hanging: internal.ToPtr(uint64(360))},
+	}, false)
+	assert.Equal(t, "margin-top: 12pt; line-height: 1.50; margin-left: 36pt; text-indent: -18pt", css.String())
+
+	assert.Equal(t, "Heading_1", cssClass("Heading 1"))
+	assert.Equal(t, "_1Column", cssClass("1Column"))
+}
@@ -0,0 +1,1107 @@
+package docx
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// HTMLImportOptions sets how HTML is added to a document.
+type HTMLImportOptions struct {
+	// Directory relative image paths are resolved against. When empty, they are relative to the working
+	// directory.
+	BaseDir string
+}
+
+// FromHTML appends the content of an HTML document or fragment to the body of the document, see
+// FromHTMLWithOptions.
+func (rd *RootDoc) FromHTML(r io.Reader) error {
+	return rd.FromHTMLWithOptions(r, HTMLImportOptions{})
+}
+
+// FromHTMLWithOptions appends the content of an HTML document or fragment to the body of the document:
+//   - p, div and the other block elements become paragraphs, h1 to h6 paragraphs with the Heading1 to
+//     Heading6 styles, blockquote paragraphs with the Quote style and hr empty paragraphs with a
+//     bottom border. Text is laid out as browsers do, collapsing white space outside of pre.
+//   - b, strong, i, em, u, s, del, sup, sub and code become formatted runs, br line breaks.
+//   - a with an href becomes a hyperlink, leading to the bookmark of that name for "#name". Elements
+//     with an id, and a with a name, become bookmarks.
+//   - ul and ol become bulleted and numbered lists, nested by list level, starting at the start of ol.
+//   - table, tr, td and th become tables with the TableGrid style unless the table has border="0".
+//     Colspan and rowspan become merged cells and th cells are bold, rows of thead repeat as headers.
+//   - img with a data URI or a local path becomes a picture of the size its width and height set, or
+//     else of the size of the image at 96 DPI, reduced to the width of the text if needed. Images from
+//     URLs become links.
+//   - The color, background-color, font-size, font-family, font-weight, font-style, text-decoration and
+//     vertical-align declarations of style attributes format the runs, text-align and align attributes
+//     align the paragraphs.
+//
+// Other elements add their content. Scripts, style sheets and the head of a document are left out.
+//
+// Example:
+//
+//	err := document.FromHTMLWithOptions(strings.NewReader(article.Body), docx.HTMLImportOptions{
+//		BaseDir: "uploads",
+//	})
+func (rd *RootDoc) FromHTMLWithOptions(r io.Reader, opts HTMLImportOptions) error {
+	root, err := parseHTML(r)
+	if err != nil {
+		return err
+	}
+
+	h := &htmlReader{root: rd, opts: opts}
+	return h.blocks(root, &htmlContext{target: bodyTarget(rd)})
+}
+
+// FromHTML appends the content of an HTML document or fragment to the cell, see FromHTMLWithOptions.
+func (c *Cell) FromHTML(r io.Reader) error {
+	return c.FromHTMLWithOptions(r, HTMLImportOptions{})
+}
+
+// FromHTMLWithOptions appends the content of an HTML document or fragment to the cell as
+// RootDoc.FromHTMLWithOptions does. When the cell only holds empty paragraphs, as new cells and
+// placeholder cells of templates do, they are replaced by the content.
+func (c *Cell) FromHTMLWithOptions(r io.Reader, opts HTMLImportOptions) error {
+	root, err := parseHTML(r)
+	if err != nil {
+		return err
+	}
+
+	empty := true
+	for _, block := range c.ct.Contents {
+		if block.Paragraph == nil || len(block.Paragraph.Children) > 0 {
+			empty = false
+		}
+	}
+	if empty {
+		c.ct.Contents = nil
+	}
+
+	h := &htmlReader{root: c.root, opts: opts}
+	err = h.blocks(root, &htmlContext{target: cellTarget(c)})
+	endCell(c)
+	return err
+}
+
+// htmlNode is an element of an HTML document or, when its tag is empty, a text.
+type htmlNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*htmlNode
+}
+
+// htmlVoid lists the elements without content, which have no end tag.
+var htmlVoid = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlBlocks lists the block elements, which end the paragraph of the text before them.
+var htmlBlocks = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "center": true, "dd": true,
+	"details": true, "div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "header": true, "hr": true, "li": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "summary": true, "table": true, "ul": true,
+}
+
+// htmlSkipped lists the elements whose content is not shown.
+var htmlSkipped = map[string]bool{
+	"head": true, "noscript": true, "script": true, "style": true, "template": true, "title": true,
+}
+
+// parseHTML reads an HTML document or fragment into a tree under an element with an empty tag. As
+// browsers do, it closes the elements whose end tag is left out and ignores end tags of elements that
+// are not open.
+func parseHTML(r io.Reader) (*htmlNode, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+
+	root := &htmlNode{}
+	open := []*htmlNode{root}
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing HTML: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			tag := htmlTag(tok.Name)
+			open = closeImplied(open, tag)
+			n := &htmlNode{tag: tag, attrs: make(map[string]string, len(tok.Attr))}
+			for _, attr := range tok.Attr {
+				n.attrs[strings.ToLower(attr.Name.Local)] = attr.Value
+			}
+			parent := open[len(open)-1]
+			parent.children = append(parent.children, n)
+			if !htmlVoid[tag] {
+				open = append(open, n)
+			}
+
+		case xml.EndElement:
+			tag := htmlTag(tok.Name)
+			for i := len(open) - 1; i > 0; i-- {
+				if open[i].tag == tag {
+					open = open[:i]
+					break
+				}
+			}
+
+		case xml.CharData:
+			parent := open[len(open)-1]
+			if n := len(parent.children); n > 0 && parent.children[n-1].tag == "" {
+				parent.children[n-1].text += string(tok)
+			} else {
+				parent.children = append(parent.children, &htmlNode{text: string(tok)})
+			}
+		}
+	}
+	return root, nil
+}
+
+// htmlTag returns the lowercase tag of an element name, keeping the prefix of names such as "o:p".
+func htmlTag(name xml.Name) string {
+	if name.Space != "" {
+		return strings.ToLower(name.Space + ":" + name.Local)
+	}
+	return strings.ToLower(name.Local)
+}
+
+// closeImplied closes the open elements that an element with the given tag ends.
+func closeImplied(open []*htmlNode, tag string) []*htmlNode {
+	if htmlBlocks[tag] {
+		open = closeOpen(open, []string{"p"}, []string{"table", "td", "th", "caption", "button"})
+	}
+
+	switch tag {
+	case "li":
+		open = closeOpen(open, []string{"li"}, []string{"ul", "ol"})
+	case "dt", "dd":
+		open = closeOpen(open, []string{"dt", "dd"}, []string{"dl"})
+	case "td", "th":
+		open = closeOpen(open, []string{"td", "th"}, []string{"tr", "table"})
+	case "tr":
+		open = closeOpen(open, []string{"tr"}, []string{"table", "thead", "tbody", "tfoot"})
+	case "thead", "tbody", "tfoot":
+		open = closeOpen(open, []string{"thead", "tbody", "tfoot"}, []string{"table"})
+	}
+	return open
+}
+
+// closeOpen closes the innermost open element with one of the tags and the elements within it, unless
+// an element with one of the scope tags is opened after it.
+func closeOpen(open []*htmlNode, tags, scope []string) []*htmlNode {
+	for i := len(open) - 1; i > 0; i-- {
+		switch {
+		case containsString(tags, open[i].tag):
+			return open[:i]
+		case containsString(scope, open[i].tag):
+			return open
+		}
+	}
+	return open
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlTarget adds the blocks of HTML to the body of a document or to a cell.
+type htmlTarget struct {
+	paragraph func() *Paragraph
+	table     func() *Table
+}
+
+func bodyTarget(rd *RootDoc) htmlTarget {
+	return htmlTarget{paragraph: rd.AddEmptyParagraph, table: rd.AddTable}
+}
+
+func cellTarget(c *Cell) htmlTarget {
+	return htmlTarget{
+		paragraph: c.AddEmptyPara,
+		table: func() *Table {
+			tbl := &Table{root: c.root, ct: ctypes.DefaultTable(), rels: c.rels}
+			c.ct.Contents = append(c.ct.Contents, ctypes.TCBlockContent{Table: tbl.ct})
+			return tbl
+		},
+	}
+}
+
+// endCell adds an empty paragraph to the cell when it does not end with one, as Word requires.
+func endCell(c *Cell) {
+	if n := len(c.ct.Contents); n == 0 || c.ct.Contents[n-1].Paragraph == nil {
+		c.AddEmptyPara()
+	}
+}
+
+// htmlContext holds what the enclosing elements change in the paragraphs and runs of an element.
+type htmlContext struct {
+	listContext
+
+	target htmlTarget
+	format htmlFormat
+
+	// Paragraph style the element sets, such as the style of a heading.
+	style string
+	quote bool
+	pre   bool
+	align stypes.Justification
+}
+
+// htmlReader adds the content of HTML elements to a document.
+type htmlReader struct {
+	root *RootDoc
+	opts HTMLImportOptions
+
+	// Paragraph the text of inline elements is added to, nil until the first text of a block.
+	p *Paragraph
+
+	// Whether the text of p ends with a space, or p has no text yet. Spaces after it are collapsed.
+	space bool
+
+	// Bookmarks the next paragraph starts with.
+	anchors []string
+}
+
+// blocks adds the content of the element and ends its last paragraph.
+func (h *htmlReader) blocks(n *htmlNode, ctx *htmlContext) error {
+	err := h.within(n, ctx, *ctx)
+	h.end()
+	return err
+}
+
+// within adds the child nodes of the element with the inner context and passes back to the context of
+// the element whether the first paragraph of its list item has been added.
+func (h *htmlReader) within(n *htmlNode, ctx *htmlContext, inner htmlContext) error {
+	defer func() { ctx.item = inner.item }()
+	for _, child := range n.children {
+		if err := h.node(child, &inner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *htmlReader) node(n *htmlNode, ctx *htmlContext) error {
+	if n.tag == "" {
+		h.text(n.text, ctx)
+		return nil
+	}
+	if htmlSkipped[n.tag] {
+		return nil
+	}
+
+	if name := n.attrs["id"]; name != "" {
+		h.anchor(name, n.tag)
+	} else if name := n.attrs["name"]; name != "" && n.tag == "a" {
+		h.anchor(name, n.tag)
+	}
+
+	inner := *ctx
+	inner.format = ctx.format.element(n)
+	if htmlBlocks[n.tag] {
+		h.end()
+		inner.align = blockAlign(n, ctx.align)
+	}
+
+	switch n.tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		inner.style = "Heading" + n.tag[1:]
+	case "blockquote":
+		inner.quote = true
+	case "pre":
+		inner.pre = true
+		inner.format.code = true
+		trimPreText(n)
+	case "center":
+		inner.align = stypes.JustificationCenter
+	case "ul", "ol":
+		return h.list(n, ctx, inner)
+	case "table":
+		return h.table(n, ctx, inner)
+	case "hr":
+		addRule(h.paragraph(ctx))
+		h.end()
+		return nil
+	case "br":
+		h.lineBreak(ctx)
+		return nil
+	case "img":
+		return h.image(n, ctx)
+	case "a":
+		if n.attrs["href"] != "" {
+			return h.link(n, ctx, inner)
+		}
+	}
+
+	err := h.within(n, ctx, inner)
+	if htmlBlocks[n.tag] {
+		h.end()
+	}
+	return err
+}
+
+// anchor adds a bookmark to the paragraph of an inline element, or to the next paragraph.
+func (h *htmlReader) anchor(id, tag string) {
+	name := anchorBookmark(id)
+	if validBookmarkName(name) != nil || h.root.Bookmark(name) != nil || containsString(h.anchors, name) {
+		return
+	}
+	if h.p != nil && !htmlBlocks[tag] {
+		h.p.addBookmark(name)
+		return
+	}
+	h.anchors = append(h.anchors, name)
+}
+
+// paragraph returns the paragraph inline content is added to, adding one with the style and alignment
+// the context sets when the current block has none yet.
+func (h *htmlReader) paragraph(ctx *htmlContext) *Paragraph {
+	if h.p != nil {
+		return h.p
+	}
+
+	p := ctx.target.paragraph()
+	style := ctx.style
+
+	if ctx.listParagraph(p) && style == "" {
+		style = "ListParagraph"
+	}
+	if ctx.quote && style == "" {
+		style = "Quote"
+	}
+
+	if style != "" {
+		p.Style(style)
+	}
+	if ctx.align != "" {
+		p.Justification(ctx.align)
+	}
+	for _, name := range h.anchors {
+		p.addBookmark(name)
+	}
+	h.anchors = nil
+
+	h.p = p
+	h.space = true
+	return p
+}
+
+// end ends the current paragraph, removing the spaces at the end of its text.
+func (h *htmlReader) end() {
+	if h.p != nil {
+		trimTrailingSpace(h.p)
+	}
+	h.p = nil
+}
+
+// trimTrailingSpace removes the spaces at the end of the last text of the paragraph.
+func trimTrailingSpace(p *Paragraph) {
+	children := p.ct.Children
+	if len(children) == 0 || children[len(children)-1].Run == nil {
+		return
+	}
+	runChildren := children[len(children)-1].Run.Children
+	if n := len(runChildren); n > 0 && runChildren[n-1].Text != nil {
+		runChildren[n-1].Text.Text = strings.TrimRight(runChildren[n-1].Text.Text, " ")
+	}
+}
+
+// text adds a text to the current paragraph. Outside of pre, white space collapses into a single space
+// that is left out at the start of a paragraph and after another space.
+func (h *htmlReader) text(s string, ctx *htmlContext) {
+	if ctx.pre {
+		if s == "" {
+			return
+		}
+		p := h.paragraph(ctx)
+		lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+		for i, line := range lines {
+			run := p.AddText(line)
+			ctx.format.apply(run)
+			if i < len(lines)-1 {
+				run.AddBreak(nil)
+			}
+		}
+		h.space = false
+		return
+	}
+
+	s = collapseSpace(s)
+	if h.p == nil || h.space {
+		s = strings.TrimPrefix(s, " ")
+	}
+	if s == "" {
+		return
+	}
+
+	ctx.format.apply(h.paragraph(ctx).AddText(s))
+	h.space = strings.HasSuffix(s, " ")
+}
+
+// collapseSpace replaces each sequence of HTML white space with a single space. Non-breaking spaces
+// are kept.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		b.WriteRune(r)
+		space = false
+	}
+	return b.String()
+}
+
+// trimPreText removes the line feed that browsers leave out after the start tag of a pre element, and
+// the line feed before its end tag.
+func trimPreText(n *htmlNode) {
+	if len(n.children) == 0 {
+		return
+	}
+	if first := n.children[0]; first.tag == "" {
+		first.text = strings.TrimPrefix(strings.TrimPrefix(first.text, "\r"), "\n")
+	}
+	if last := n.children[len(n.children)-1]; last.tag == "" {
+		last.text = strings.TrimSuffix(strings.TrimSuffix(last.text, "\n"), "\r")
+	}
+}
+
+func (h *htmlReader) lineBreak(ctx *htmlContext) {
+	p := h.paragraph(ctx)
+	trimTrailingSpace(p)
+	p.AddRun().AddBreak(nil)
+	h.space = true
+}
+
+// link adds a hyperlink with the text of the element, formatted as its first text.
+func (h *htmlReader) link(n *htmlNode, ctx *htmlContext, inner htmlContext) error {
+	text, format := elementText(n, inner.format)
+	text = collapseSpace(text)
+	if strings.TrimSpace(text) == "" {
+		// Links around images keep the images
+		return h.within(n, ctx, inner)
+	}
+
+	p := h.paragraph(ctx)
+	if strings.HasPrefix(text, " ") && !h.space {
+		ctx.format.apply(p.AddText(" "))
+	}
+
+	var link *Hyperlink
+	target := n.attrs["href"]
+	if strings.HasPrefix(target, "#") {
+		link = p.AddInternalLink(strings.TrimSpace(text), anchorBookmark(target[1:]))
+	} else {
+		link = p.AddLink(strings.TrimSpace(text), target)
+	}
+	if format != (htmlFormat{}) {
+		format.setProperty(link.getProp())
+	}
+
+	h.space = false
+	if strings.HasSuffix(text, " ") {
+		ctx.format.apply(p.AddText(" "))
+		h.space = true
+	}
+	return nil
+}
+
+// elementText returns the text of the element and the format of its first text.
+func elementText(n *htmlNode, f htmlFormat) (string, htmlFormat) {
+	var b strings.Builder
+	first := f
+	found := false
+
+	var walk func(n *htmlNode, f htmlFormat)
+	walk = func(n *htmlNode, f htmlFormat) {
+		for _, child := range n.children {
+			switch {
+			case child.tag == "":
+				if !found && strings.TrimSpace(child.text) != "" {
+					first, found = f, true
+				}
+				b.WriteString(child.text)
+			case child.tag == "br":
+				b.WriteByte(' ')
+			case !htmlSkipped[child.tag]:
+				walk(child, f.element(child))
+			}
+		}
+	}
+	walk(n, f)
+	return b.String(), first
+}
+
+// image adds the picture of an image from a data URI or a local file. Images with a URL become links.
+func (h *htmlReader) image(n *htmlNode, ctx *htmlContext) error {
+	src := strings.TrimSpace(n.attrs["src"])
+	alt := n.attrs["alt"]
+	css := parseCSS(n.attrs["style"])
+
+	var data []byte
+	var ext string
+	switch {
+	case src == "":
+		return nil
+
+	case strings.HasPrefix(src, "data:"):
+		var err error
+		if data, ext, err = decodeDataURI(src); err != nil {
+			return fmt.Errorf("image data URI: %w", err)
+		}
+
+	case strings.Contains(src, "://"):
+		text := alt
+		if text == "" {
+			text = src
+		}
+		link := &htmlNode{tag: "a", attrs: map[string]string{"href": src}, children: []*htmlNode{{text: text}}}
+		return h.link(link, ctx, *ctx)
+
+	default:
+		if u, err := url.PathUnescape(src); err == nil {
+			src = u
+		}
+		path := filepath.FromSlash(src)
+		if !filepath.IsAbs(path) && h.opts.BaseDir != "" {
+			path = filepath.Join(h.opts.BaseDir, path)
+		}
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return err
+		}
+		ext = filepath.Ext(path)
+	}
+
+	width := htmlPixels(n.attrs["width"])
+	if v, ok := css["width"]; ok {
+		width = htmlPixels(v)
+	}
+	height := htmlPixels(n.attrs["height"])
+	if v, ok := css["height"]; ok {
+		height = htmlPixels(v)
+	}
+
+	if err := h.paragraph(ctx).addImageData(data, ext, alt, width, height); err != nil {
+		if strings.HasPrefix(src, "data:") {
+			return fmt.Errorf("image data URI: %w", err)
+		}
+		return fmt.Errorf("image %s: %w", src, err)
+	}
+	h.space = false
+	return nil
+}
+
+// decodeDataURI returns the data of a data URI and the file extension of its image type.
+func decodeDataURI(uri string) ([]byte, string, error) {
+	meta, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, "", fmt.Errorf("missing data")
+	}
+
+	params := strings.Split(meta, ";")
+	var ext string
+	switch strings.ToLower(strings.TrimSpace(params[0])) {
+	case "image/png":
+		ext = ".png"
+	case "image/jpeg", "image/jpg":
+		ext = ".jpeg"
+	case "image/gif":
+		ext = ".gif"
+	case "image/bmp":
+		ext = ".bmp"
+	case "image/tiff":
+		ext = ".tiff"
+	default:
+		return nil, "", fmt.Errorf("unsupported image type %q", params[0])
+	}
+
+	if strings.EqualFold(params[len(params)-1], "base64") {
+		payload = strings.Map(func(r rune) rune {
+			if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+				return -1
+			}
+			return r
+		}, payload)
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, ext, nil
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	return []byte(data), ext, nil
+}
+
+// htmlPixels returns the number of pixels of an HTML or CSS length, or zero for relative lengths.
+func htmlPixels(s string) int {
+	s = strings.ToLower(strings.TrimSpace(s))
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(s, "px"):
+		s = strings.TrimSuffix(s, "px")
+	case strings.HasSuffix(s, "pt"):
+		s = strings.TrimSuffix(s, "pt")
+		scale = 96.0 / 72
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 {
+		return 0
+	}
+	return int(math.Round(v * scale))
+}
+
+// list adds the items of a list. Lists nested in a list of the same kind continue its numbering
+// definition at the next level.
+func (h *htmlReader) list(n *htmlNode, ctx *htmlContext, inner htmlContext) error {
+	ordered := n.tag == "ol"
+	start := 1
+	if v, err := strconv.Atoi(strings.TrimSpace(n.attrs["start"])); err == nil {
+		start = v
+	}
+
+	list, level := ctx.nestedList(h.root, ordered, start)
+	for _, child := range n.children {
+		if child.tag != "li" {
+			if err := h.node(child, &inner); err != nil {
+				return err
+			}
+			continue
+		}
+
+		itemCtx := inner
+		itemCtx.listContext = ctx.itemContext(list, level, ordered)
+		if err := h.node(child, &itemCtx); err != nil {
+			return err
+		}
+		if itemCtx.item != nil {
+			h.paragraph(&itemCtx)
+			h.end()
+		}
+	}
+	return nil
+}
+
+// table adds a table with the rows of the element. Cells spanning several rows are merged with the
+// cells added to the rows below them.
+func (h *htmlReader) table(n *htmlNode, ctx *htmlContext, inner htmlContext) error {
+	var rows []*htmlNode
+	header := map[*htmlNode]bool{}
+	for _, child := range n.children {
+		switch child.tag {
+		case "caption":
+			if err := h.within(child, ctx, inner); err != nil {
+				return err
+			}
+			h.end()
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			for _, row := range child.children {
+				if row.tag == "tr" {
+					rows = append(rows, row)
+					header[row] = child.tag == "thead"
+				}
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tbl := ctx.target.table()
+	if strings.TrimSpace(n.attrs["border"]) != "0" {
+		tbl.Style("TableGrid")
+	}
+
+	// Rows the cell starting at each grid column still spans below, and the columns it spans
+	var spanRows, spanCols []int
+	columns := 0
+	for i, tr := range rows {
+		row := tbl.AddRow()
+		if header[tr] {
+			row.ct.Property.Header = &ctypes.OnOff{}
+		}
+
+		col := 0
+		continueSpans := func() {
+			for col < len(spanRows) && spanRows[col] > 0 {
+				cell := row.AddCell()
+				cell.ct.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+				if spanCols[col] > 1 {
+					cell.ColSpan(spanCols[col])
+				}
+				cell.AddEmptyPara()
+				spanRows[col]--
+				col += spanCols[col]
+			}
+		}
+
+		for _, td := range tr.children {
+			if td.tag != "td" && td.tag != "th" {
+				continue
+			}
+			continueSpans()
+
+			colspan := htmlSpan(td.attrs["colspan"], 1)
+			rowspan := htmlSpan(td.attrs["rowspan"], len(rows)-i)
+			cell := row.AddCell()
+			if colspan > 1 {
+				cell.ColSpan(colspan)
+			}
+			if rowspan > 1 {
+				cell.ct.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: internal.ToPtr(stypes.MergeCellRestart)}
+				for len(spanRows) < col+colspan {
+					spanRows = append(spanRows, 0)
+					spanCols = append(spanCols, 1)
+				}
+				spanRows[col], spanCols[col] = rowspan-1, colspan
+			}
+			if err := h.cell(cell, td, inner); err != nil {
+				return err
+			}
+			col += colspan
+		}
+		continueSpans()
+
+		if col > columns {
+			columns = col
+		}
+	}
+
+	if columns > 0 {
+		widths := make([]uint64, columns)
+		for i := range widths {
+			widths[i] = uint64(h.root.textWidth() / columns)
+		}
+		tbl.Grid(widths...)
+	}
+	return nil
+}
+
+// cell adds the content of a td or th element to the cell.
+func (h *htmlReader) cell(cell *Cell, td *htmlNode, ctx htmlContext) error {
+	if td.tag == "th" {
+		ctx.format.bold = true
+	}
+	css := parseCSS(td.attrs["style"])
+	if color, ok := cssColor(td.attrs["bgcolor"]); ok {
+		cell.BackgroundColor(color)
+	}
+	if color, ok := cssColor(css["background-color"]); ok {
+		cell.BackgroundColor(color)
+	}
+	valign := td.attrs["valign"]
+	if v, ok := css["vertical-align"]; ok {
+		valign = v
+	}
+	cell.VerticalAlign(strings.ToLower(valign))
+
+	cellCtx := htmlContext{
+		target: cellTarget(cell),
+		format: ctx.format.element(td),
+		align:  blockAlign(td, ""),
+	}
+	err := h.blocks(td, &cellCtx)
+	endCell(cell)
+	return err
+}
+
+// htmlSpan returns the number of columns or rows of a colspan or rowspan attribute, where zero stands
+// for the rest of them.
+func htmlSpan(s string, rest int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	switch {
+	case err != nil || v < 0:
+		return 1
+	case v == 0:
+		return rest
+	case v > 1000:
+		return 1000
+	}
+	return v
+}
+
+// blockAlign returns the alignment the align attribute or text-align declaration of a block element
+// sets, or the inherited alignment.
+func blockAlign(n *htmlNode, inherited stypes.Justification) stypes.Justification {
+	align := n.attrs["align"]
+	if v, ok := parseCSS(n.attrs["style"])["text-align"]; ok {
+		align = v
+	}
+
+	switch strings.ToLower(strings.TrimSpace(align)) {
+	case "left", "start":
+		return stypes.JustificationLeft
+	case "center":
+		return stypes.JustificationCenter
+	case "right", "end":
+		return stypes.JustificationRight
+	case "justify":
+		return stypes.JustificationBoth
+	}
+	return inherited
+}
+
+// htmlFormat is the formatting of inline HTML.
+type htmlFormat struct {
+	bold, italic, underline, strike, code bool
+
+	vertAlign stypes.VerticalAlignRun
+	color     string
+	fill      string
+	font      string
+
+	// Font size in half-points
+	size uint64
+}
+
+// element returns the format within an element, after its tag and style attribute.
+func (f htmlFormat) element(n *htmlNode) htmlFormat {
+	switch n.tag {
+	case "b", "strong":
+		f.bold = true
+	case "i", "em", "cite", "dfn", "var":
+		f.italic = true
+	case "u", "ins":
+		f.underline = true
+	case "s", "strike", "del":
+		f.strike = true
+	case "code", "kbd", "samp", "tt":
+		f.code = true
+	case "sup":
+		f.vertAlign = stypes.VerticalAlignRunSuperscript
+	case "sub":
+		f.vertAlign = stypes.VerticalAlignRunSubscript
+	case "mark":
+		f.fill = "FFFF00"
+	case "font":
+		if color, ok := cssColor(n.attrs["color"]); ok {
+			f.color = color
+		}
+		if face := cssFontFamily(n.attrs["face"]); face != "" {
+			f.font = face
+		}
+	}
+
+	for prop, raw := range parseCSS(n.attrs["style"]) {
+		value := strings.ToLower(raw)
+		switch prop {
+		case "font-weight":
+			if weight, err := strconv.Atoi(value); err == nil {
+				f.bold = weight >= 600
+			} else if value == "bold" || value == "bolder" {
+				f.bold = true
+			} else if value == "normal" || value == "lighter" {
+				f.bold = false
+			}
+		case "font-style":
+			f.italic = value == "italic" || value == "oblique"
+		case "text-decoration", "text-decoration-line":
+			f.underline = strings.Contains(value, "underline")
+			f.strike = strings.Contains(value, "line-through")
+		case "color":
+			if color, ok := cssColor(value); ok {
+				f.color = color
+			}
+		case "background-color", "background":
+			if color, ok := cssColor(value); ok {
+				f.fill = color
+			}
+		case "font-size":
+			if size := cssFontSize(value); size > 0 {
+				f.size = size
+			}
+		case "font-family":
+			if font := cssFontFamily(raw); font != "" {
+				f.font = font
+			}
+		case "vertical-align":
+			switch value {
+			case "super":
+				f.vertAlign = stypes.VerticalAlignRunSuperscript
+			case "sub":
+				f.vertAlign = stypes.VerticalAlignRunSubscript
+			case "baseline":
+				f.vertAlign = ""
+			}
+		}
+	}
+	return f
+}
+
+func (f htmlFormat) apply(r *Run) {
+	if f != (htmlFormat{}) {
+		f.setProperty(r.getProp())
+	}
+}
+
+// setProperty sets the properties of the format in the run properties.
+func (f htmlFormat) setProperty(prop *ctypes.RunProperty) {
+	if f.bold {
+		prop.Bold = ctypes.OnOffFromBool(true)
+	}
+	if f.italic {
+		prop.Italic = ctypes.OnOffFromBool(true)
+	}
+	if f.underline {
+		prop.Underline = ctypes.NewGenSingleStrVal(stypes.UnderlineSingle)
+	}
+	if f.strike {
+		prop.Strike = ctypes.OnOffFromBool(true)
+	}
+	if f.vertAlign != "" {
+		prop.VertAlign = ctypes.NewGenSingleStrVal(f.vertAlign)
+	}
+	if f.color != "" {
+		prop.Color = ctypes.NewColor(f.color)
+	}
+	if f.fill != "" {
+		prop.Shading = ctypes.NewShading().SetShadingType(stypes.ShdClear).SetColor("auto").SetFill(f.fill)
+	}
+	if f.size > 0 {
+		prop.Size = ctypes.NewFontSize(f.size)
+	}
+
+	font := f.font
+	if f.code && font == "" {
+		font = codeFont
+	}
+	if font != "" {
+		prop.Fonts = &ctypes.RunFonts{Ascii: font, HAnsi: font}
+	}
+}
+
+// parseCSS returns the declarations of a style attribute by lowercase property name.
+func parseCSS(style string) map[string]string {
+	decls := map[string]string{}
+	for _, decl := range strings.Split(style, ";") {
+		prop, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		decls[strings.ToLower(strings.TrimSpace(prop))] = value
+	}
+	return decls
+}
+
+// cssColors holds the hexadecimal values of the basic CSS color names.
+var cssColors = map[string]string{
+	"aqua": "00FFFF", "black": "000000", "blue": "0000FF", "cyan": "00FFFF", "fuchsia": "FF00FF",
+	"gray": "808080", "green": "008000", "grey": "808080", "lime": "00FF00", "magenta": "FF00FF",
+	"maroon": "800000", "navy": "000080", "olive": "808000", "orange": "FFA500", "purple": "800080",
+	"red": "FF0000", "silver": "C0C0C0", "teal": "008080", "white": "FFFFFF", "yellow": "FFFF00",
+}
+
+// cssColor returns the hexadecimal value of a CSS color given by name, as #rgb, #rrggbb or rgb().
+func cssColor(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if color, ok := cssColors[value]; ok {
+		return color, true
+	}
+
+	if hex := strings.TrimPrefix(value, "#"); hex != value {
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+			return "", false
+		}
+		switch len(hex) {
+		case 3:
+			return strings.ToUpper(string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})), true
+		case 6:
+			return strings.ToUpper(hex), true
+		}
+		return "", false
+	}
+
+	if args := strings.TrimPrefix(strings.TrimPrefix(value, "rgba("), "rgb("); args != value {
+		parts := strings.Split(strings.TrimSuffix(args, ")"), ",")
+		if len(parts) < 3 {
+			return "", false
+		}
+		var color string
+		for _, part := range parts[:3] {
+			part = strings.TrimSpace(part)
+			scale := 1.0
+			if strings.HasSuffix(part, "%") {
+				part = strings.TrimSuffix(part, "%")
+				scale = 255.0 / 100
+			}
+			v, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return "", false
+			}
+			color += fmt.Sprintf("%02X", int(math.Round(math.Max(0, math.Min(255, v*scale)))))
+		}
+		return color, true
+	}
+	return "", false
+}
+
+// cssFontSize returns the size in half-points of a font-size in points or pixels.
+func cssFontSize(value string) uint64 {
+	value = strings.TrimSpace(value)
+	scale := 2.0
+	switch {
+	case strings.HasSuffix(value, "pt"):
+		value = strings.TrimSuffix(value, "pt")
+	case strings.HasSuffix(value, "px"):
+		value = strings.TrimSuffix(value, "px")
+		scale = 1.5
+	default:
+		return 0
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || v <= 0 {
+		return 0
+	}
+	return uint64(math.Round(v * scale))
+}
+
+// cssFontFamily returns the first font of a font-family list. The generic monospace family is the code
+// font, the other generic families are left to the style.
+func cssFontFamily(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+	font := strings.Trim(strings.TrimSpace(first), `"'`)
+	switch strings.ToLower(font) {
+	case "monospace":
+		return codeFont
+	case "serif", "sans-serif", "cursive", "fantasy", "system-ui", "inherit", "initial":
+		return ""
+	}
+	return font
+}
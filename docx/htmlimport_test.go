@@ -0,0 +1,181 @@
+package docx
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_FromHTML(t *testing.T) {
+	input := `<!DOCTYPE html><html><head><title>Ignored</title><style>p { color: red }</style></head><body>
+<h1 id="terms">Terms &amp; conditions</h1>
+<p style="text-align: center">The   <b>buyer</b> pays <span style="color: #c00; font-size: 14pt">within
+  30 days</span>, see <a href="https://example.com/terms"><em>the terms</em></a> and <a href="#terms">above</a>.
+<p>First line<br>second&nbsp;line
+<ul>
+  <li>Delivery
+    <ol start="3"><li>Packing<li>Shipping</ol>
+  <li><s>Storage</s>
+</ul>
+<pre>
+func main() {
+	run()
+}
+</pre>
+<hr>
+<blockquote>Quoted</blockquote>
+</body></html>`
+
+	rd := NewRootDoc()
+	assert.NoError(t, rd.FromHTML(strings.NewReader(input)))
+	assert.Equal(t, "Terms & conditions|The buyer pays within 30 days, see the terms and above.|"+
+		"First line\nsecond\u00a0line|Delivery|Packing|Shipping|Storage|func main() {\n\trun()\n}||Quoted", bodyText(rd))
+
+	children := rd.Document.Body.Children
+	assert.Equal(t, "Heading1", children[0].Para.ct.Property.Style.Val)
+	assert.NotNil(t, rd.Bookmark("terms"))
+
+	p := children[1].Para.ct
+	assert.Equal(t, stypes.JustificationCenter, p.Property.Justification.Val)
+	assert.NotNil(t, p.Children[1].Run.Property.Bold)
+	span := p.Children[3].Run.Property
+	assert.Equal(t, "CC0000", span.Color.Val)
+	assert.Equal(t, uint64(28), span.Size.Value)
+	assert.NotNil(t, p.Children[5].Link.Run.Property.Italic)
+	assert.Equal(t, "terms", *p.Children[7].Link.Anchor)
+
+	assert.NotNil(t, children[2].Para.ct.Children[1].Run.Children[0].Break)
+
+	// The nested list of another kind gets its own numbering, starting at 3 on its level
+	delivery := children[3].Para.ct.Property
+	packing := children[4].Para.ct.Property
+	assert.Equal(t, "ListParagraph", delivery.Style.Val)
+	assert.Equal(t, 0, delivery.NumProp.ILvl.Val)
+	assert.Equal(t, 1, packing.NumProp.ILvl.Val)
+	assert.NotEqual(t, delivery.NumProp.NumID.Val, packing.NumProp.NumID.Val)
+	num := rd.numbering().Num(packing.NumProp.NumID.Val)
+	assert.Equal(t, 3, num.Override(1).StartOverride.Val)
+	assert.Nil(t, num.Override(0))
+	assert.Equal(t, delivery.NumProp.NumID.Val, children[6].Para.ct.Property.NumProp.NumID.Val)
+	assert.NotNil(t, children[6].Para.ct.Children[0].Run.Property.Strike)
+
+	assert.Equal(t, codeFont, children[7].Para.ct.Children[0].Run.Property.Fonts.Ascii)
+	assert.NotNil(t, children[8].Para.ct.Property.Border.Bottom)
+	assert.Equal(t, "Quote", children[9].Para.ct.Property.Style.Val)
+}
+
+func TestRootDoc_FromHTML_Tables(t *testing.T) {
+	input := `<table>
+<thead><tr><th colspan="2">Region<th>Sales</thead>
+<tbody>
+<tr><td rowspan="2" style="background-color: rgb(217, 217, 217)">North<td>East<td align="right">120
+<tr><td>West<td>80
+</tbody>
+</table>
+<table border="0"><tr><td>Plain</td></tr></table>`
+
+	rd := NewRootDoc()
+	assert.NoError(t, rd.FromHTML(strings.NewReader(input)))
+
+	children := rd.Document.Body.Children
+	tbl := children[0].Table
+	assert.Equal(t, "TableGrid", tbl.ct.TableProp.Style.Val)
+	assert.Len(t, tbl.ct.Grid.Col, 3)
+
+	rows := tbl.ct.RowContents
+	assert.NotNil(t, rows[0].Row.Property.Header)
+	header := rows[0].Row.Contents
+	assert.Len(t, header, 2)
+	assert.Equal(t, 2, header[0].Cell.Property.GridSpan.Val)
+	assert.NotNil(t, header[0].Cell.Contents[0].Paragraph.Children[0].Run.Property.Bold)
+
+	north := rows[1].Row.Contents[0].Cell
+	assert.Equal(t, stypes.MergeCellRestart, *north.Property.VMerge.Val)
+	assert.Equal(t, "D9D9D9", *north.Property.Shading.Fill)
+	assert.Equal(t, stypes.JustificationRight, rows[1].Row.Contents[2].Cell.Contents[0].Paragraph.Property.Justification.Val)
+
+	// The row below gets the continuation of the merged cell before its own cells
+	below := rows[2].Row.Contents
+	assert.Len(t, below, 3)
+	assert.NotNil(t, below[0].Cell.Property.VMerge)
+	assert.Nil(t, below[0].Cell.Property.VMerge.Val)
+	assert.Equal(t, "West", below[1].Cell.Contents[0].Paragraph.Children[0].Run.Children[0].Text.Text)
+
+	assert.Nil(t, children[1].Table.ct.TableProp.Style)
+}
+
+func TestCell_FromHTML(t *testing.T) {
+	dataURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(testPNG(t, 96, 48))
+
+	rd := NewRootDoc()
+	cell := rd.AddTable().AddRow().AddCell()
+	cell.AddEmptyPara()
+
+	input := `<p>Signed by <u>both</u> parties</p><img src="` + dataURI + `" alt="Seal" width="192">` +
+		`<table><tr><td>Nested</td></tr></table>`
+	assert.NoError(t, cell.FromHTML(strings.NewReader(input)))
+
+	// The empty placeholder paragraph is replaced, and the cell ends with a paragraph after the table
+	contents := cell.ct.Contents
+	assert.Len(t, contents, 4)
+	assert.Equal(t, "Signed by both parties", runText(contents[0].Paragraph.Children[0].Run)+
+		runText(contents[0].Paragraph.Children[1].Run)+runText(contents[0].Paragraph.Children[2].Run))
+	assert.NotNil(t, contents[0].Paragraph.Children[1].Run.Property.Underline)
+
+	inline := contents[1].Paragraph.Children[0].Run.Children[0].Drawing.Inline[0]
+	assert.Equal(t, "Seal", inline.DocProp.Description)
+	assert.Equal(t, uint64(2*914400), inline.Extent.Width)
+	assert.Equal(t, uint64(914400), inline.Extent.Height)
+	_, stored := rd.FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+
+	assert.NotNil(t, contents[2].Table)
+	assert.NotNil(t, contents[3].Paragraph)
+}
+
+func TestRootDoc_FromHTMLWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart.png"), testPNG(t, 192, 96), 0o644))
+
+	rd := NewRootDoc()
+	input := `<p><img src="chart.png" alt="Chart" style="height: 48px"><img src="https://example.com/logo.png" alt="Logo"></p>`
+	assert.NoError(t, rd.FromHTMLWithOptions(strings.NewReader(input), HTMLImportOptions{BaseDir: dir}))
+
+	pictures := rd.Document.Body.Children[0].Para.ct.Children
+	inline := pictures[0].Run.Children[0].Drawing.Inline[0]
+	assert.Equal(t, uint64(914400), inline.Extent.Width)
+	assert.Equal(t, uint64(914400/2), inline.Extent.Height)
+
+	rel := rd.Document.relationByID(pictures[1].Link.ID)
+	assert.Equal(t, "https://example.com/logo.png", rel.Target)
+
+	assert.Error(t, rd.FromHTML(strings.NewReader(`<img src="missing.png">`)))
+	assert.Error(t, rd.FromHTML(strings.NewReader(`<img src="data:text/plain,hello">`)))
+}
+
+func TestCSSColor(t *testing.T) {
+	tests := []struct {
+		input string
+		color string
+		ok    bool
+	}{
+		{"Navy", "000080", true},
+		{"#1a2B3c", "1A2B3C", true},
+		{"#abc", "AABBCC", true},
+		{"rgb(255, 0, 128)", "FF0080", true},
+		{"rgba(100%, 0%, 0%, 0.5)", "FF0000", true},
+		{"#12345", "", false},
+		{"transparent", "", false},
+	}
+
+	for _, tt := range tests {
+		color, ok := cssColor(tt.input)
+		assert.Equal(t, tt.color, color, tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+	}
+}
@@ -1,6 +1,8 @@
 package docx
 
 import (
+	"fmt"
+
 	"github.com/mrlijnden/godocx/wml/ctypes"
 	"github.com/mrlijnden/godocx/wml/stypes"
 )
@@ -8,18 +10,63 @@ import (
 type Hyperlink struct {
 	root *RootDoc          // root is the root document to which this hyperlink belongs.
 	ct   *ctypes.Hyperlink // ct is the underlying hyperlink element from the wml/ctypes package.
+	rels *Relationships    // rels are the relationships of the part holding the hyperlink; nil for the document.
 }
 
-func newHyperlink(root *RootDoc, ct *ctypes.Hyperlink) *Hyperlink {
-	return &Hyperlink{root: root, ct: ct}
+func newHyperlink(root *RootDoc, rels *Relationships, ct *ctypes.Hyperlink) *Hyperlink {
+	return &Hyperlink{root: root, ct: ct, rels: rels}
 }
 
 // getProp returns the hyperlink properties. If not initialized, it creates and returns a new instance.
+//
+// Hyperlinks read from an opened document may hold several runs; the properties of the first one are used.
 func (r *Hyperlink) getProp() *ctypes.RunProperty {
-	if r.ct.Run.Property == nil {
-		r.ct.Run.Property = &ctypes.RunProperty{}
+	run := r.ct.Run
+	if run == nil {
+		if runs := r.ct.Runs(); len(runs) > 0 {
+			run = runs[0]
+		} else {
+			r.ct.Run = &ctypes.Run{}
+			run = r.ct.Run
+		}
+	}
+
+	if run.Property == nil {
+		run.Property = &ctypes.RunProperty{}
+	}
+	return run.Property
+}
+
+// GetCT returns a pointer to the underlying Hyperlink Complex Type.
+func (r *Hyperlink) GetCT() *ctypes.Hyperlink {
+	return r.ct
+}
+
+// Target returns the URL the hyperlink points to, as recorded in the relationships of the part holding it.
+//
+// Returns:
+//   - string: The target of the hyperlink relationship.
+//   - error: An error if the hyperlink has no relationship ID or the relationship cannot be found.
+func (r *Hyperlink) Target() (string, error) {
+	if r.ct.ID == "" {
+		return "", fmt.Errorf("hyperlink has no relationship id")
+	}
+
+	rel := r.root.partRelationByID(r.rels, r.ct.ID)
+	if rel == nil {
+		return "", fmt.Errorf("relationship %s not found", r.ct.ID)
+	}
+
+	return rel.Target, nil
+}
+
+// Anchor returns the name of the bookmark the hyperlink points to, or an empty string for links to
+// external targets.
+func (r *Hyperlink) Anchor() string {
+	if r.ct.Anchor == nil {
+		return ""
 	}
-	return r.ct.Run.Property
+	return *r.ct.Anchor
 }
 
 // Sets the color of the Hyperlink.
@@ -55,3 +55,44 @@ func (doc *Document) addRelation(relType string, fileName string) string {
 
 	return "rId" + strconv.Itoa(rID)
 }
+
+// relationByID returns the document relationship with the given ID, or nil if it does not exist.
+func (doc *Document) relationByID(rID string) *Relationship {
+	return doc.DocRels.relationByID(rID)
+}
+
+// addPartRelation adds a relationship to the relationships of a part and returns its ID. A nil rels
+// stands for the document, whose IDs are handed out by IncRelationID.
+func (rd *RootDoc) addPartRelation(rels *Relationships, relType string, target string, targetMode string) string {
+	if rels != nil && rels != &rd.Document.DocRels {
+		return rels.add(relType, target, targetMode)
+	}
+
+	rID := "rId" + strconv.Itoa(rd.Document.IncRelationID())
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID:         rID,
+		Type:       relType,
+		Target:     target,
+		TargetMode: targetMode,
+	})
+	return rID
+}
+
+// partRelationByID returns the relationship with the given ID of a part, or nil if it does not exist.
+// A nil rels stands for the document.
+func (rd *RootDoc) partRelationByID(rels *Relationships, rID string) *Relationship {
+	if rels == nil {
+		return rd.Document.relationByID(rID)
+	}
+	return rels.relationByID(rID)
+}
+
+// relationByType returns the first relationship of the given type, or nil if there is none.
+func (doc *Document) relationByType(relType string) *Relationship {
+	for _, rel := range doc.DocRels.Relationships {
+		if rel.Type == relType {
+			return rel
+		}
+	}
+	return nil
+}
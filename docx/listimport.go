@@ -0,0 +1,71 @@
+package docx
+
+import "github.com/mrlijnden/godocx/wml/ctypes"
+
+// listContext holds what the lists enclosing a block of imported Markdown or HTML change in its
+// paragraphs.
+type listContext struct {
+	// Number of enclosing list items, whose text paragraphs are indented to.
+	depth int
+
+	// List the first paragraph of the item belongs to, cleared once it is added.
+	item *itemLevel
+
+	// Innermost enclosing list and whether it is ordered.
+	list    *List
+	ordered bool
+}
+
+// itemLevel is the list level of the first paragraph of a list item.
+type itemLevel struct {
+	list  *List
+	level int
+}
+
+// nestedList returns the list for the items of a list within the context and the level of its items.
+// Lists nested in a list of the same kind continue its numbering definition at the next level, unless
+// an ordered list starts at a number other than 1, which it then starts at on the level of its items.
+func (lc listContext) nestedList(rd *RootDoc, ordered bool, start int) (*List, int) {
+	level := lc.depth
+	if level > maxListLevel {
+		level = maxListLevel
+	}
+
+	switch {
+	case lc.list != nil && lc.ordered == ordered && (!ordered || start == 1):
+		return lc.list, level
+	case ordered:
+		list := rd.AddNumberedList()
+		if start != 1 {
+			list = list.RestartLevelAt(level, start)
+		}
+		return list, level
+	default:
+		return rd.AddBulletList(), level
+	}
+}
+
+// itemContext returns the context of an item of the list, whose items are at the given level.
+func (lc listContext) itemContext(list *List, level int, ordered bool) listContext {
+	return listContext{
+		depth:   lc.depth + 1,
+		item:    &itemLevel{list: list, level: level},
+		list:    list,
+		ordered: ordered,
+	}
+}
+
+// listParagraph numbers the paragraph as the first paragraph of the list item of the context, or
+// indents it to the enclosing list items, and reports whether it was numbered.
+func (lc *listContext) listParagraph(p *Paragraph) bool {
+	switch {
+	case lc.item != nil:
+		p.Numbering(lc.item.list.NumID(), lc.item.level)
+		lc.item = nil
+		return true
+	case lc.depth > 0:
+		left := listIndentStep * lc.depth
+		p.Indent(&ctypes.Indent{Left: &left})
+	}
+	return false
+}
@@ -0,0 +1,602 @@
+package docx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mrlijnden/godocx/dml"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// MarkdownOptions sets how a document is exported to Markdown.
+type MarkdownOptions struct {
+	// Directory the pictures of the document are written to, created if needed. The Markdown refers to
+	// the pictures with this path, so a relative path is relative to the Markdown file. When empty, no
+	// picture is written and the Markdown refers to the pictures by their name in the package, such as
+	// "media/image1.png".
+	ImageDir string
+}
+
+// ToMarkdown writes the body of the document as GitHub Flavored Markdown, see ToMarkdownWithOptions.
+func (rd *RootDoc) ToMarkdown(w io.Writer) error {
+	return rd.ToMarkdownWithOptions(w, MarkdownOptions{})
+}
+
+// ToMarkdownWithOptions writes the body of the document as GitHub Flavored Markdown:
+//   - Paragraphs with the Title style become "#" headings, those with the Heading1 to Heading9 styles
+//     headings of the same level, up to "######".
+//   - Bold, italic and struck through runs become emphasis, and hyperlinks links.
+//   - Numbered and bulleted paragraphs become ordered and unordered lists, nested by list level.
+//   - Tables become tables whose first row is the header row. Cells hold their paragraphs on one line.
+//   - Pictures become images, written to opts.ImageDir.
+//   - Footnotes and endnotes become footnotes, listed at the end.
+//
+// Deleted and hidden text, headers and footers are left out.
+//
+// Example:
+//
+//	f, err := os.Create("report.md")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = document.ToMarkdownWithOptions(f, docx.MarkdownOptions{ImageDir: "report-images"})
+func (rd *RootDoc) ToMarkdownWithOptions(w io.Writer, opts MarkdownOptions) error {
+	m := &markdownWriter{
+		root:   rd,
+		opts:   opts,
+		text:   newTextWriter(rd, TextOptions{}),
+		images: map[string]string{},
+	}
+
+	blocks, err := m.blocks(rd.Document.Body.Children)
+	if err != nil {
+		return err
+	}
+
+	var notes []string
+	for _, ref := range m.notes {
+		note := ref.notes.Note(ref.id)
+		if note == nil {
+			continue
+		}
+		text, err := m.noteText(note)
+		if err != nil {
+			return err
+		}
+		notes = append(notes, "[^"+ref.label+"]: "+text)
+	}
+	if len(notes) > 0 {
+		blocks = append(blocks, strings.Join(notes, "\n"))
+	}
+
+	if len(blocks) == 0 {
+		return nil
+	}
+	_, err = io.WriteString(w, strings.Join(blocks, "\n\n")+"\n")
+	return err
+}
+
+// markdownWriter renders the content of a document as Markdown.
+type markdownWriter struct {
+	root *RootDoc
+	opts MarkdownOptions
+	text *textWriter    // Counts the list items
+	rels *Relationships // Relationships of the part being rendered, nil for the document
+
+	images map[string]string // Markdown paths of the pictures written, by relationship target
+	notes  []markdownNote
+}
+
+// markdownNote is a reference to a footnote or an endnote.
+type markdownNote struct {
+	notes *Notes
+	id    int
+	label string
+}
+
+// blocks returns the Markdown blocks of the content. The items of a list form a single block.
+func (m *markdownWriter) blocks(children []DocumentChild) ([]string, error) {
+	var blocks []ctypes.TCBlockContent
+	for _, child := range children {
+		switch {
+		case child.Para != nil:
+			blocks = append(blocks, ctypes.TCBlockContent{Paragraph: child.Para.ct})
+		case child.Table != nil:
+			blocks = append(blocks, ctypes.TCBlockContent{Table: child.Table.ct})
+		case child.Sdt != nil:
+			blocks = append(blocks, ctypes.TCBlockContent{Sdt: child.Sdt})
+		}
+	}
+	return m.cellBlocks(blocks)
+}
+
+func (m *markdownWriter) cellBlocks(blocks []ctypes.TCBlockContent) ([]string, error) {
+	var out []string
+	var list []string
+	listNumID := 0
+	endList := func() {
+		if len(list) > 0 {
+			out = append(out, strings.Join(list, "\n"))
+			list = nil
+		}
+	}
+
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			text, err := m.inline(block.Paragraph.Children)
+			if err != nil {
+				return nil, err
+			}
+
+			if entry, ok := m.text.listEntry(block.Paragraph); ok {
+				// Another list starts a new Markdown list
+				if entry.ilvl == 0 && entry.numID != listNumID {
+					endList()
+				}
+				listNumID = entry.numID
+				marker := "-"
+				if !entry.bullet() {
+					marker = strconv.Itoa(entry.number()) + "."
+				}
+				list = append(list, strings.Repeat("    ", entry.ilvl)+marker+" "+text)
+				continue
+			}
+
+			endList()
+			if text == "" {
+				continue
+			}
+			if level := headingLevel(block.Paragraph); level > 0 {
+				out = append(out, strings.Repeat("#", level)+" "+text)
+			} else {
+				out = append(out, escapeLineStart(text))
+			}
+		case block.Table != nil:
+			endList()
+			table, err := m.table(block.Table)
+			if err != nil {
+				return nil, err
+			}
+			if table != "" {
+				out = append(out, table)
+			}
+		case block.Sdt != nil:
+			endList()
+			inner, err := m.cellBlocks(block.Sdt.Content)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, inner...)
+		}
+	}
+	endList()
+	return out, nil
+}
+
+// headingLevel returns the Markdown heading level of a paragraph with a heading or title style, or 0.
+func headingLevel(p *ctypes.Paragraph) int {
+	if p.Property == nil || p.Property.Style == nil {
+		return 0
+	}
+
+	style := p.Property.Style.Val
+	if style == "Title" {
+		return 1
+	}
+	if !strings.HasPrefix(style, "Heading") {
+		return 0
+	}
+	level, err := strconv.Atoi(strings.TrimPrefix(style, "Heading"))
+	if err != nil || level < 1 || level > 9 {
+		return 0
+	}
+	if level > 6 {
+		level = 6
+	}
+	return level
+}
+
+// table returns the table in GFM syntax, the first row being the header row.
+func (m *markdownWriter) table(tbl *ctypes.Table) (string, error) {
+	var rows [][]string
+	var addRows func(rowContents []ctypes.RowContent) error
+	addRows = func(rowContents []ctypes.RowContent) error {
+		for _, rowContent := range rowContents {
+			switch {
+			case rowContent.Sdt != nil:
+				if err := addRows(rowContent.Sdt.Content); err != nil {
+					return err
+				}
+			case rowContent.Row != nil:
+				if prop := rowContent.Row.Property; prop != nil && prop.Del != nil {
+					continue
+				}
+				cells, err := m.rowCells(rowContent.Row.Contents)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, cells)
+			}
+		}
+		return nil
+	}
+	if err := addRows(tbl.RowContents); err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// rowCells returns the content of the cells of a row, each on one line.
+func (m *markdownWriter) rowCells(cellContents []ctypes.TRCellContent) ([]string, error) {
+	var cells []string
+	for _, cellContent := range cellContents {
+		switch {
+		case cellContent.Sdt != nil:
+			inner, err := m.rowCells(cellContent.Sdt.Content)
+			if err != nil {
+				return nil, err
+			}
+			cells = append(cells, inner...)
+		case cellContent.Cell != nil:
+			blocks, err := m.cellBlocks(cellContent.Cell.Contents)
+			if err != nil {
+				return nil, err
+			}
+			cell := strings.Join(blocks, "<br>")
+			cell = strings.ReplaceAll(cell, "\\\n", "<br>")
+			cells = append(cells, strings.ReplaceAll(cell, "\n", "<br>"))
+		}
+	}
+	return cells, nil
+}
+
+// noteText returns the content of a note on one line, without its reference mark.
+func (m *markdownWriter) noteText(note *Note) (string, error) {
+	m.rels = note.rels
+	blocks, err := m.blocks(note.Children)
+	if err != nil {
+		return "", err
+	}
+	text := strings.Join(blocks, " ")
+	text = strings.ReplaceAll(text, "\\\n", " ")
+	return strings.TrimSpace(strings.ReplaceAll(text, "\n", " ")), nil
+}
+
+// markdownSpan is a piece of the Markdown of a paragraph with its emphasis. Links, pictures and note
+// references are spans without emphasis of their own.
+type markdownSpan struct {
+	text                 string
+	bold, italic, strike bool
+}
+
+// inline returns the Markdown of paragraph content.
+func (m *markdownWriter) inline(children []ctypes.ParagraphChild) (string, error) {
+	spans, err := m.spans(children)
+	if err != nil {
+		return "", err
+	}
+	return joinSpans(spans), nil
+}
+
+func (m *markdownWriter) spans(children []ctypes.ParagraphChild) ([]markdownSpan, error) {
+	var spans []markdownSpan
+	for _, child := range children {
+		var inner []markdownSpan
+		var err error
+		switch {
+		case child.Run != nil:
+			inner, err = m.runSpans(child.Run)
+		case child.Link != nil:
+			inner, err = m.link(child.Link)
+		case child.Ins != nil:
+			inner, err = m.spans(child.Ins.Children)
+		case child.MoveTo != nil:
+			inner, err = m.spans(child.MoveTo.Children)
+		case child.Sdt != nil:
+			inner, err = m.spans(child.Sdt.Content)
+		}
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, inner...)
+	}
+	return spans, nil
+}
+
+func (m *markdownWriter) link(link *ctypes.Hyperlink) ([]markdownSpan, error) {
+	var children []ctypes.ParagraphChild
+	if link.Run != nil {
+		children = append(children, ctypes.ParagraphChild{Run: link.Run})
+	}
+	children = append(children, link.Children...)
+	text, err := m.inline(children)
+	if err != nil {
+		return nil, err
+	}
+
+	target := ""
+	switch {
+	case link.ID != "":
+		if rel := m.root.partRelationByID(m.rels, link.ID); rel != nil {
+			target = rel.Target
+		}
+	case link.Anchor != nil:
+		target = "#" + *link.Anchor
+	}
+	if target == "" {
+		return []markdownSpan{{text: text}}, nil
+	}
+	return []markdownSpan{{text: "[" + text + "](" + markdownURL(target) + ")"}}, nil
+}
+
+func (m *markdownWriter) runSpans(run *ctypes.Run) ([]markdownSpan, error) {
+	prop := run.Property
+	if prop != nil && prop.Vanish != nil && prop.Vanish.Enabled() {
+		return nil, nil
+	}
+
+	style := markdownSpan{}
+	if prop != nil {
+		style.bold = prop.Bold != nil && prop.Bold.Enabled()
+		style.italic = prop.Italic != nil && prop.Italic.Enabled()
+		style.strike = (prop.Strike != nil && prop.Strike.Enabled()) ||
+			(prop.DoubleStrike != nil && prop.DoubleStrike.Enabled())
+	}
+
+	var spans []markdownSpan
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			span := style
+			span.text = text.String()
+			spans = append(spans, span)
+			text.Reset()
+		}
+	}
+
+	for _, child := range run.Children {
+		switch {
+		case child.Text != nil:
+			text.WriteString(escapeMarkdown(child.Text.Text))
+		case child.Tab != nil, child.PTab != nil:
+			text.WriteString(" ")
+		case child.Break != nil, child.CarrRtn != nil:
+			text.WriteString("\\\n")
+		case child.NoBreakHyphen != nil:
+			text.WriteString("-")
+		case child.Drawing != nil:
+			flush()
+			images, err := m.drawing(child.Drawing)
+			if err != nil {
+				return nil, err
+			}
+			spans = append(spans, images...)
+		case child.FootnoteReference != nil:
+			flush()
+			spans = append(spans, m.noteReference(m.root.Footnotes, child.FootnoteReference.ID, ""))
+		case child.EndnoteReference != nil:
+			flush()
+			spans = append(spans, m.noteReference(m.root.Endnotes, child.EndnoteReference.ID, "e"))
+		}
+	}
+	flush()
+	return spans, nil
+}
+
+// noteReference records a reference to a note and returns its Markdown footnote reference. The notes of
+// a part are numbered in the order of their references, after the prefix of the part.
+func (m *markdownWriter) noteReference(notes *Notes, id int, prefix string) markdownSpan {
+	if notes == nil {
+		return markdownSpan{}
+	}
+
+	number := 1
+	for _, ref := range m.notes {
+		if ref.notes == notes {
+			number++
+		}
+	}
+	label := prefix + strconv.Itoa(number)
+	m.notes = append(m.notes, markdownNote{notes: notes, id: id, label: label})
+	return markdownSpan{text: "[^" + label + "]"}
+}
+
+// drawing returns the images of the pictures of a drawing, writing the pictures to the image directory.
+func (m *markdownWriter) drawing(drawing *dml.Drawing) ([]markdownSpan, error) {
+	type picture struct {
+		docProp dml.DocProp
+		graphic dml.Graphic
+	}
+	var pictures []picture
+	for _, inline := range drawing.Inline {
+		pictures = append(pictures, picture{inline.DocProp, inline.Graphic})
+	}
+	for _, anchor := range drawing.Anchor {
+		pictures = append(pictures, picture{anchor.DocProp, anchor.Graphic})
+	}
+
+	var spans []markdownSpan
+	for _, pic := range pictures {
+		data := pic.graphic.Data
+		if data == nil || data.Pic == nil || data.Pic.BlipFill.Blip == nil {
+			continue
+		}
+		rel := m.root.partRelationByID(m.rels, data.Pic.BlipFill.Blip.EmbedID)
+		if rel == nil {
+			continue
+		}
+
+		src, err := m.image(rel.Target)
+		if err != nil {
+			return nil, err
+		}
+		alt := pic.docProp.Description
+		if alt == "" {
+			alt = pic.docProp.Name
+		}
+		spans = append(spans, markdownSpan{text: "![" + escapeMarkdown(alt) + "](" + markdownURL(src) + ")"})
+	}
+	return spans, nil
+}
+
+// image writes the picture stored at the relationship target to the image directory, once, and returns
+// the path the Markdown refers to it with.
+func (m *markdownWriter) image(target string) (string, error) {
+	if src, ok := m.images[target]; ok {
+		return src, nil
+	}
+
+	src := target
+	if m.opts.ImageDir != "" {
+		content, ok := m.root.FileMap.Load(path.Join("word", target))
+		if !ok {
+			return "", fmt.Errorf("picture %s not found in the package", target)
+		}
+		if err := os.MkdirAll(m.opts.ImageDir, 0o755); err != nil {
+			return "", err
+		}
+		name := path.Base(target)
+		if err := os.WriteFile(filepath.Join(m.opts.ImageDir, name), content.([]byte), 0o644); err != nil {
+			return "", err
+		}
+		src = path.Join(filepath.ToSlash(m.opts.ImageDir), name)
+	}
+
+	m.images[target] = src
+	return src, nil
+}
+
+// joinSpans joins the spans, merging the neighbours with the same emphasis so that the markers enclose
+// them together. The markers leave out the spaces at the ends of the text, as Markdown requires.
+func joinSpans(spans []markdownSpan) string {
+	var out strings.Builder
+	for i := 0; i < len(spans); {
+		group := spans[i]
+		j := i + 1
+		for ; j < len(spans); j++ {
+			next := spans[j]
+			if next.bold != group.bold || next.italic != group.italic || next.strike != group.strike {
+				break
+			}
+			group.text += next.text
+		}
+		i = j
+
+		marker := ""
+		if group.strike {
+			marker += "~~"
+		}
+		if group.bold {
+			marker += "**"
+		}
+		if group.italic {
+			marker += "*"
+		}
+		lead, core, trail := splitSpaces(group.text)
+		if marker == "" || core == "" {
+			out.WriteString(group.text)
+			continue
+		}
+		out.WriteString(lead + marker + core + reverseMarker(marker) + trail)
+	}
+	return out.String()
+}
+
+// splitSpaces splits the Markdown text into its leading spaces, its core and its trailing spaces and
+// line breaks.
+func splitSpaces(text string) (lead, core, trail string) {
+	core = strings.TrimLeft(text, " ")
+	lead = text[:len(text)-len(core)]
+	end := len(core)
+	for end > 0 {
+		switch {
+		case core[end-1] == ' ':
+			end--
+		case strings.HasSuffix(core[:end], "\\\n"):
+			end -= 2
+		default:
+			trail = core[end:]
+			return lead, core[:end], trail
+		}
+	}
+	return lead, "", core
+}
+
+// reverseMarker returns the closing markers of an opening sequence of emphasis markers.
+func reverseMarker(marker string) string {
+	var parts []string
+	for _, part := range []string{"~~", "**", "*"} {
+		if strings.Contains(marker, part) {
+			parts = append([]string{part}, parts...)
+			marker = strings.Replace(marker, part, "", 1)
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// markdownEscaper escapes the characters that Markdown could read as syntax within a line.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "|", `\|`, "~", `\~`,
+)
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// escapeLineStart escapes the start of a paragraph that Markdown would read as a heading, a list item or
+// a thematic break.
+func escapeLineStart(text string) string {
+	if text == "" {
+		return text
+	}
+	switch text[0] {
+	case '#', '-', '+', '=':
+		return `\` + text
+	}
+
+	digits := 0
+	for digits < len(text) && text[digits] >= '0' && text[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(text) && (text[digits] == '.' || text[digits] == ')') {
+		return text[:digits] + `\` + text[digits:]
+	}
+	return text
+}
+
+// markdownURL encloses a link destination in angle brackets when it holds spaces or parentheses.
+func markdownURL(url string) string {
+	if strings.ContainsAny(url, " ()") {
+		return "<" + url + ">"
+	}
+	return url
+}
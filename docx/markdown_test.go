@@ -0,0 +1,96 @@
+package docx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_ToMarkdown(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Quarterly report").Style("Title")
+	rd.AddParagraph("Summary").Style("Heading2")
+
+	p := rd.AddEmptyParagraph()
+	p.AddText("Sales ")
+	p.AddText("grew ").Bold(true)
+	p.AddText("strongly").Bold(true).Italic(true)
+	p.AddText(", see ")
+	p.AddLink("the dashboard", "https://example.com/q1")
+	p.AddText(". Cost: 5*3")
+	p.AddFootnote("Unaudited.")
+
+	steps := rd.AddNumberedList()
+	steps.AddItem("Plan", 0)
+	steps.AddItem("Detail", 1)
+	steps.AddItem("Review", 0)
+	rd.AddBulletList().AddItem("Done", 0)
+
+	tbl := rd.AddTable()
+	header := tbl.AddRow()
+	header.AddCell().AddParagraph("Region")
+	header.AddCell().AddParagraph("Sales")
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("North | East")
+	row.AddCell().AddParagraph("120")
+
+	rd.AddParagraph("1. Not a list")
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToMarkdown(&out))
+
+	expected := "# Quarterly report\n\n" +
+		"## Summary\n\n" +
+		"Sales **grew** ***strongly***, see [the dashboard](https://example.com/q1). Cost: 5\\*3[^1]\n\n" +
+		"1. Plan\n" +
+		"    1. Detail\n" +
+		"2. Review\n\n" +
+		"- Done\n\n" +
+		"| Region | Sales |\n" +
+		"| --- | --- |\n" +
+		"| North \\| East | 120 |\n\n" +
+		"1\\. Not a list\n\n" +
+		"[^1]: Unaudited.\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestRootDoc_ToMarkdown_Images(t *testing.T) {
+	rd := NewRootDoc()
+	imgPath := filepath.Join(t.TempDir(), "logo.png")
+	assert.NoError(t, os.WriteFile(imgPath, []byte("\x89PNG\r\n\x1a\n"), 0o644))
+	_, err := rd.AddEmptyParagraph().AddPicture(imgPath, 1, 1)
+	assert.NoError(t, err)
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToMarkdown(&out))
+	assert.Contains(t, out.String(), "](media/image1.png)")
+
+	dir := filepath.Join(t.TempDir(), "images")
+	out.Reset()
+	assert.NoError(t, rd.ToMarkdownWithOptions(&out, MarkdownOptions{ImageDir: dir}))
+	assert.Contains(t, out.String(), "]("+filepath.ToSlash(dir)+"/image1.png)")
+	data, err := os.ReadFile(filepath.Join(dir, "image1.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("\x89PNG\r\n\x1a\n"), data)
+}
+
+func TestRootDoc_ToMarkdown_NoteLinks(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Revenue").AddFootnote("Source:").AddParagraph("").AddLink("the report", "https://example.com/report")
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToMarkdown(&out))
+	assert.Contains(t, out.String(), "[^1]: Source: [the report](https://example.com/report)\n")
+}
+
+func TestJoinSpans(t *testing.T) {
+	spans := []markdownSpan{
+		{text: "a "},
+		{text: " bold ", bold: true},
+		{text: "end\\\n", italic: true, strike: true},
+	}
+	assert.Equal(t, "a  **bold** ~~*end*~~\\\n", joinSpans(spans))
+}
@@ -0,0 +1,1193 @@
+package docx
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// MarkdownStyles names the styles FromMarkdownWithOptions gives to the paragraphs and runs it adds. Empty
+// names take the defaults of the default template, so only the styles a template names differently need
+// to be set.
+type MarkdownStyles struct {
+	// Paragraph styles of the headings of level 1 to 6, Heading1 to Heading6 by default.
+	Headings [6]string
+
+	// Paragraph style of ordinary paragraphs. When empty, they keep the default paragraph style.
+	Paragraph string
+
+	// Paragraph style of list items, ListParagraph by default.
+	List string
+
+	// Paragraph style of the paragraphs of block quotes, Quote by default.
+	Quote string
+
+	// Paragraph style of code blocks, MacroText by default. When the document does not define the
+	// style, the code is set in Courier New.
+	CodeBlock string
+
+	// Character style of code spans. When empty, code spans are set in Courier New.
+	InlineCode string
+
+	// Style of tables, TableGrid by default.
+	Table string
+}
+
+// MarkdownImportOptions sets how Markdown is added to a document.
+type MarkdownImportOptions struct {
+	// Styles used for the added content.
+	Styles MarkdownStyles
+
+	// Directory relative image paths are resolved against. When empty, they are relative to the working
+	// directory.
+	BaseDir string
+}
+
+// FromMarkdown appends the content of a Markdown text to the body of the document, see
+// FromMarkdownWithOptions.
+func (rd *RootDoc) FromMarkdown(r io.Reader) error {
+	return rd.FromMarkdownWithOptions(r, MarkdownImportOptions{})
+}
+
+// FromMarkdownWithOptions appends the content of a CommonMark text with GitHub Flavored Markdown tables
+// and strikethrough to the body of the document:
+//   - Headings get the heading styles and a bookmark named after their GitHub anchor, so that links
+//     such as "#getting-started" lead to them.
+//   - Emphasis, strong emphasis and strikethrough become italic, bold and struck through runs.
+//   - Links become hyperlinks, and links to "#anchor" links to the bookmark of that name.
+//   - Ordered and bullet lists become numbered and bulleted lists, nested by list level.
+//   - Code blocks become paragraphs with the code block style, block quotes paragraphs with the quote
+//     style and thematic breaks empty paragraphs with a bottom border.
+//   - Tables become tables with the table style, the header row first. Cells take the alignment of
+//     their column.
+//   - Images from local files become pictures as wide as the image at 96 DPI, reduced to the width of
+//     the text if needed. Images from URLs become links.
+//
+// Reference links and raw HTML are not interpreted and are added as text.
+//
+// Example:
+//
+//	f, err := os.Open("README.md")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = document.FromMarkdownWithOptions(f, docx.MarkdownImportOptions{
+//		Styles:  docx.MarkdownStyles{CodeBlock: "CorporateCode", Quote: "CorporateQuote"},
+//		BaseDir: ".",
+//	})
+func (rd *RootDoc) FromMarkdownWithOptions(r io.Reader, opts MarkdownImportOptions) error {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	m := &markdownReader{
+		root:   rd,
+		opts:   opts,
+		styles: opts.Styles.withDefaults(),
+	}
+	return m.blocks(parseMarkdownBlocks(lines), markdownContext{})
+}
+
+func (s MarkdownStyles) withDefaults() MarkdownStyles {
+	for i := range s.Headings {
+		if s.Headings[i] == "" {
+			s.Headings[i] = fmt.Sprintf("Heading%d", i+1)
+		}
+	}
+	if s.List == "" {
+		s.List = "ListParagraph"
+	}
+	if s.Quote == "" {
+		s.Quote = "Quote"
+	}
+	if s.CodeBlock == "" {
+		s.CodeBlock = "MacroText"
+	}
+	if s.Table == "" {
+		s.Table = "TableGrid"
+	}
+	return s
+}
+
+// codeFont is the font of code when no style sets one.
+const codeFont = "Courier New"
+
+// addRule gives the paragraph the bottom border that horizontal rules are shown with.
+func addRule(p *Paragraph) {
+	p.ensureProp()
+	p.ct.Property.Border = &ctypes.ParaBorder{Bottom: &ctypes.Border{
+		Val:   stypes.BorderStyleSingle,
+		Color: internal.ToPtr("auto"),
+		Space: internal.ToPtr("1"),
+		Size:  internal.ToPtr(6),
+	}}
+}
+
+// markdownBlockKind is the kind of a Markdown block.
+type markdownBlockKind int
+
+const (
+	markdownParagraph markdownBlockKind = iota
+	markdownHeading
+	markdownCode
+	markdownQuote
+	markdownList
+	markdownTable
+	markdownRule
+)
+
+// markdownBlock is a block of a Markdown text.
+type markdownBlock struct {
+	kind markdownBlockKind
+
+	// Inline text of paragraphs and headings, content of code blocks.
+	text string
+
+	// Level of headings.
+	level int
+
+	// Blocks of block quotes.
+	children []*markdownBlock
+
+	// Lists: whether the list is ordered, its start number and the blocks of its items.
+	ordered bool
+	start   int
+	items   [][]*markdownBlock
+
+	// Tables: the cells of the rows, the header row first, and the alignment of the columns.
+	rows  [][]string
+	align []stypes.Justification
+}
+
+// parseMarkdownBlocks parses lines of Markdown into blocks.
+func parseMarkdownBlocks(lines []string) []*markdownBlock {
+	var blocks []*markdownBlock
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			text := strings.TrimRight(strings.Join(para, "\n"), " ")
+			blocks = append(blocks, &markdownBlock{kind: markdownParagraph, text: text})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		indent := leadingSpaces(line)
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+			i++
+
+		case indent >= 4 && len(para) == 0:
+			var code []string
+			for ; i < len(lines) && (leadingSpaces(lines[i]) >= 4 || strings.TrimSpace(lines[i]) == ""); i++ {
+				code = append(code, stripIndent(lines[i], 4))
+			}
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, &markdownBlock{kind: markdownCode, text: strings.Join(code, "\n")})
+
+		case indent < 4 && len(para) > 0 && setextLevel(trimmed) > 0:
+			text := strings.TrimSpace(strings.Join(para, "\n"))
+			blocks = append(blocks, &markdownBlock{kind: markdownHeading, text: text, level: setextLevel(trimmed)})
+			para = nil
+			i++
+
+		case indent < 4 && fenceMarker(trimmed) != "":
+			flush()
+			fence := fenceMarker(trimmed)
+			var code []string
+			for i++; i < len(lines); i++ {
+				closing := strings.TrimSpace(lines[i])
+				if leadingSpaces(lines[i]) < 4 && strings.HasPrefix(closing, fence) &&
+					strings.Trim(closing, fence[:1]) == "" {
+					i++
+					break
+				}
+				code = append(code, stripIndent(lines[i], indent))
+			}
+			blocks = append(blocks, &markdownBlock{kind: markdownCode, text: strings.Join(code, "\n")})
+
+		case indent < 4 && atxLevel(trimmed) > 0:
+			flush()
+			blocks = append(blocks, &markdownBlock{kind: markdownHeading, text: atxText(trimmed), level: atxLevel(trimmed)})
+			i++
+
+		case indent < 4 && isThematicBreak(trimmed):
+			flush()
+			blocks = append(blocks, &markdownBlock{kind: markdownRule})
+			i++
+
+		case indent < 4 && strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quoted []string
+			lazy := false
+			for ; i < len(lines); i++ {
+				content := strings.TrimSpace(lines[i])
+				if leadingSpaces(lines[i]) < 4 && strings.HasPrefix(content, ">") {
+					content = strings.TrimPrefix(strings.TrimLeft(lines[i], " \t"), ">")
+					content = strings.TrimPrefix(content, " ")
+					quoted = append(quoted, content)
+					lazy = strings.TrimSpace(content) != "" && !startsMarkdownBlock(content)
+					continue
+				}
+				if !lazy || content == "" || startsMarkdownBlock(lines[i]) {
+					break
+				}
+				quoted = append(quoted, lines[i])
+			}
+			blocks = append(blocks, &markdownBlock{kind: markdownQuote, children: parseMarkdownBlocks(quoted)})
+
+		case indent < 4 && isListStart(line, len(para) > 0):
+			flush()
+			var list *markdownBlock
+			list, i = parseMarkdownList(lines, i)
+			blocks = append(blocks, list)
+
+		case indent < 4 && i+1 < len(lines) && isTableStart(line, lines[i+1]):
+			flush()
+			table := &markdownBlock{kind: markdownTable, rows: [][]string{splitTableRow(line)}}
+			for _, cell := range splitTableRow(lines[i+1]) {
+				table.align = append(table.align, cellAlignment(cell))
+			}
+			for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsMarkdownBlock(lines[i]); i++ {
+				table.rows = append(table.rows, splitTableRow(lines[i]))
+			}
+			blocks = append(blocks, table)
+
+		default:
+			para = append(para, strings.TrimLeft(line, " \t"))
+			i++
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// parseMarkdownList parses the list starting at lines[i] and returns it with the index of the line after it.
+func parseMarkdownList(lines []string, i int) (*markdownBlock, int) {
+	first, _ := listMarker(lines[i])
+	list := &markdownBlock{kind: markdownList, ordered: first.ordered, start: first.start}
+
+	for i < len(lines) {
+		marker, ok := listMarker(lines[i])
+		if !ok || marker.ordered != first.ordered || marker.delimiter != first.delimiter || leadingSpaces(lines[i]) >= 4 {
+			break
+		}
+
+		item := []string{marker.content}
+		blank := false
+		lazy := item[0] != ""
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			switch {
+			case strings.TrimSpace(line) == "":
+				item = append(item, "")
+				blank = true
+				lazy = false
+				continue
+			case leadingSpaces(line) >= marker.width:
+				content := stripIndent(line, marker.width)
+				item = append(item, content)
+				blank = false
+				lazy = !startsMarkdownBlock(content)
+				continue
+			case isListStart(line, false):
+				// A new item at the indentation of the list
+			case lazy && !blank && !startsMarkdownBlock(line):
+				item = append(item, line)
+				continue
+			}
+			break
+		}
+		list.items = append(list.items, parseMarkdownBlocks(item))
+
+		if blank {
+			// A blank line ends the list unless another item follows it.
+			if next, ok := listMarker(safeLine(lines, i)); !ok || next.ordered != first.ordered {
+				break
+			}
+		}
+	}
+
+	return list, i
+}
+
+func safeLine(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+// markdownListMarker describes the marker of a list item.
+type markdownListMarker struct {
+	ordered   bool
+	start     int
+	delimiter byte   // '-', '+' or '*' for bullets, '.' or ')' for ordered lists
+	width     int    // indentation of the content of the item
+	empty     bool   // whether the line holds nothing after the marker
+	content   string // text of the line after the marker
+}
+
+// listMarker returns the marker the line starts with, if any.
+func listMarker(line string) (markdownListMarker, bool) {
+	indent := leadingSpaces(line)
+	if indent >= 4 {
+		return markdownListMarker{}, false
+	}
+	rest := stripIndent(line, indent)
+
+	var marker markdownListMarker
+	var size int
+	switch {
+	case rest == "":
+		return markdownListMarker{}, false
+	case rest[0] == '-' || rest[0] == '+' || rest[0] == '*':
+		marker.delimiter = rest[0]
+		size = 1
+	default:
+		digits := 0
+		for digits < len(rest) && digits < 9 && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 || digits >= len(rest) || (rest[digits] != '.' && rest[digits] != ')') {
+			return markdownListMarker{}, false
+		}
+		marker.ordered = true
+		marker.start, _ = strconv.Atoi(rest[:digits])
+		marker.delimiter = rest[digits]
+		size = digits + 1
+	}
+
+	after := rest[size:]
+	if after != "" && after[0] != ' ' && after[0] != '\t' {
+		return markdownListMarker{}, false
+	}
+	spaces := leadingSpaces(after)
+	marker.empty = strings.TrimSpace(after) == ""
+	if marker.empty || spaces > 4 {
+		// The content starts one space after the marker, the rest is code indentation.
+		spaces = 1
+	}
+	marker.width = indent + size + spaces
+	if !marker.empty {
+		marker.content = stripIndent(after, spaces)
+	}
+	return marker, true
+}
+
+// isListStart reports whether the line starts a list. Within a paragraph, only bullets and ordered items
+// starting at 1 that hold text do.
+func isListStart(line string, inParagraph bool) bool {
+	marker, ok := listMarker(line)
+	if !ok || isThematicBreak(strings.TrimSpace(line)) {
+		return false
+	}
+	if inParagraph {
+		return !marker.empty && (!marker.ordered || marker.start == 1)
+	}
+	return true
+}
+
+// startsMarkdownBlock reports whether the line starts a block other than a paragraph, which ends a lazy
+// continuation.
+func startsMarkdownBlock(line string) bool {
+	if leadingSpaces(line) >= 4 {
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	return fenceMarker(trimmed) != "" || atxLevel(trimmed) > 0 || isThematicBreak(trimmed) ||
+		strings.HasPrefix(trimmed, ">") || isListStart(line, true)
+}
+
+// fenceMarker returns the backticks or tildes that open a fenced code block, or "".
+func fenceMarker(trimmed string) string {
+	for _, c := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if n < 3 {
+			continue
+		}
+		if c == "`" && strings.Contains(trimmed[n:], "`") {
+			return ""
+		}
+		return trimmed[:n]
+	}
+	return ""
+}
+
+// atxLevel returns the level of the "#" heading the line is, or 0.
+func atxLevel(trimmed string) int {
+	n := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if n == 0 || n > 6 || (n < len(trimmed) && trimmed[n] != ' ') {
+		return 0
+	}
+	return n
+}
+
+// atxText returns the text of a "#" heading, without the closing sequence of "#".
+func atxText(trimmed string) string {
+	text := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+	closed := strings.TrimRight(text, "#")
+	if closed == "" || strings.HasSuffix(closed, " ") {
+		text = strings.TrimSpace(closed)
+	}
+	return text
+}
+
+// setextLevel returns the level of the heading a line of "=" or "-" underlines, or 0.
+func setextLevel(trimmed string) int {
+	switch {
+	case trimmed != "" && strings.Trim(trimmed, "=") == "":
+		return 1
+	case trimmed != "" && strings.Trim(trimmed, "-") == "":
+		return 2
+	}
+	return 0
+}
+
+func isThematicBreak(trimmed string) bool {
+	if trimmed == "" {
+		return false
+	}
+	c := trimmed[0]
+	if c != '-' && c != '*' && c != '_' {
+		return false
+	}
+	count := 0
+	for i := 0; i < len(trimmed); i++ {
+		switch trimmed[i] {
+		case c:
+			count++
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+// isTableStart reports whether the line is the header row of a table whose delimiter row follows.
+func isTableStart(line, next string) bool {
+	if !strings.Contains(line, "|") || !strings.Contains(next, "|") && !strings.Contains(next, "-") {
+		return false
+	}
+	delimiters := splitTableRow(next)
+	if len(delimiters) != len(splitTableRow(line)) {
+		return false
+	}
+	for _, cell := range delimiters {
+		cell = strings.TrimSuffix(strings.TrimPrefix(cell, ":"), ":")
+		if cell == "" || strings.Trim(cell, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTableRow returns the trimmed cells of a table row. Escaped pipes belong to the cells.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// cellAlignment returns the alignment a cell of the delimiter row sets, or "" for the default.
+func cellAlignment(delimiter string) stypes.Justification {
+	left, right := strings.HasPrefix(delimiter, ":"), strings.HasSuffix(delimiter, ":")
+	switch {
+	case left && right:
+		return stypes.JustificationCenter
+	case right:
+		return stypes.JustificationRight
+	case left:
+		return stypes.JustificationLeft
+	}
+	return ""
+}
+
+// leadingSpaces returns the width of the indentation of the line, where tabs advance to the next tab
+// stop of 4 columns.
+func leadingSpaces(line string) int {
+	width := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// stripIndent removes up to n columns of indentation from the start of the line. The columns of a tab
+// beyond n are kept as spaces.
+func stripIndent(line string, n int) string {
+	width := 0
+	for i := 0; i < len(line); i++ {
+		switch {
+		case width >= n:
+			return line[i:]
+		case line[i] == ' ':
+			width++
+		case line[i] == '\t':
+			width += 4 - width%4
+			if width > n {
+				return strings.Repeat(" ", width-n) + line[i+1:]
+			}
+		default:
+			return line[i:]
+		}
+	}
+	return ""
+}
+
+// markdownContext holds what the enclosing blocks change in the paragraphs of a block.
+type markdownContext struct {
+	listContext
+	quote bool
+}
+
+// markdownReader adds Markdown blocks to a document.
+type markdownReader struct {
+	root   *RootDoc
+	opts   MarkdownImportOptions
+	styles MarkdownStyles
+}
+
+func (m *markdownReader) blocks(blocks []*markdownBlock, ctx markdownContext) error {
+	for _, block := range blocks {
+		if err := m.block(block, &ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *markdownReader) block(block *markdownBlock, ctx *markdownContext) error {
+	switch block.kind {
+	case markdownParagraph:
+		p := m.paragraph(ctx, m.styles.Paragraph)
+		return m.inline(p, block.text)
+
+	case markdownHeading:
+		p := m.paragraph(ctx, m.styles.Headings[block.level-1])
+		if err := m.inline(p, block.text); err != nil {
+			return err
+		}
+		if name := anchorBookmark(headingAnchor(p.Text())); validBookmarkName(name) == nil && m.root.Bookmark(name) == nil {
+			p.addBookmark(name)
+		}
+
+	case markdownCode:
+		p := m.paragraph(ctx, m.styles.CodeBlock)
+		styled := m.root.GetStyleByID(m.styles.CodeBlock, stypes.StyleTypeParagraph) != nil
+		lines := strings.Split(block.text, "\n")
+		for i, line := range lines {
+			run := p.AddText(line)
+			if !styled {
+				run.Font(codeFont)
+			}
+			if i < len(lines)-1 {
+				run.AddBreak(nil)
+			}
+		}
+
+	case markdownRule:
+		addRule(m.paragraph(ctx, ""))
+
+	case markdownQuote:
+		quoted := *ctx
+		quoted.quote = true
+		err := m.blocks(block.children, quoted)
+		ctx.item = quoted.item
+		return err
+
+	case markdownList:
+		return m.list(block, ctx)
+
+	case markdownTable:
+		return m.table(block)
+	}
+
+	return nil
+}
+
+// paragraph appends a paragraph with the given style, or the style the context sets, to the body.
+func (m *markdownReader) paragraph(ctx *markdownContext, style string) *Paragraph {
+	p := m.root.AddEmptyParagraph()
+
+	if ctx.listParagraph(p) && (style == m.styles.Paragraph || style == "") {
+		style = m.styles.List
+	}
+	if ctx.quote && (style == m.styles.Paragraph || style == "") {
+		style = m.styles.Quote
+	}
+
+	if style != "" {
+		p.Style(style)
+	}
+	return p
+}
+
+// list adds the items of a list.
+func (m *markdownReader) list(block *markdownBlock, ctx *markdownContext) error {
+	list, level := ctx.nestedList(m.root, block.ordered, block.start)
+	for _, item := range block.items {
+		itemCtx := markdownContext{
+			listContext: ctx.itemContext(list, level, block.ordered),
+			quote:       ctx.quote,
+		}
+		if len(item) == 0 {
+			m.paragraph(&itemCtx, "")
+			continue
+		}
+		if err := m.blocks(item, itemCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *markdownReader) table(block *markdownBlock) error {
+	tbl := m.root.AddTable()
+	tbl.Style(m.styles.Table)
+
+	columns := len(block.rows[0])
+	for _, cells := range block.rows {
+		row := tbl.AddRow()
+		for c := 0; c < columns; c++ {
+			p := row.AddCell().AddEmptyPara()
+			if block.align[c] != "" {
+				p.Justification(block.align[c])
+			}
+			if c >= len(cells) {
+				continue
+			}
+			if err := m.inline(p, cells[c]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markdownFormat is the formatting of inline Markdown.
+type markdownFormat struct {
+	bold, italic, strike bool
+}
+
+func (f markdownFormat) apply(r *Run) {
+	if f.bold {
+		r.Bold(true)
+	}
+	if f.italic {
+		r.Italic(true)
+	}
+	if f.strike {
+		r.Strike(true)
+	}
+}
+
+// inline adds the runs, links and pictures of inline Markdown to the paragraph.
+func (m *markdownReader) inline(p *Paragraph, text string) error {
+	return m.spans(p, text, markdownFormat{})
+}
+
+func (m *markdownReader) spans(p *Paragraph, s string, f markdownFormat) error {
+	var text strings.Builder
+	var last *Run
+
+	flush := func() {
+		if text.Len() > 0 {
+			last = p.AddText(text.String())
+			f.apply(last)
+			text.Reset()
+		}
+	}
+	hardBreak := func() {
+		flush()
+		if last == nil {
+			last = p.AddRun()
+		}
+		last.AddBreak(nil)
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			hardBreak()
+			i += 2
+			continue
+
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '\n':
+			if strings.HasSuffix(text.String(), "  ") {
+				trimmed := strings.TrimRight(text.String(), " ")
+				text.Reset()
+				text.WriteString(trimmed)
+				hardBreak()
+			} else {
+				trimmed := strings.TrimRight(text.String(), " ")
+				text.Reset()
+				text.WriteString(trimmed + " ")
+			}
+			i++
+			continue
+
+		case c == '`':
+			if code, end, ok := codeSpan(s, i); ok {
+				flush()
+				last = p.AddText(code)
+				f.apply(last)
+				if m.styles.InlineCode != "" {
+					last.Style(m.styles.InlineCode)
+				} else {
+					last.Font(codeFont)
+				}
+				i = end
+				continue
+			}
+			n := runLength(s, i)
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if label, dest, end, ok := parseLink(s, i+1); ok {
+				flush()
+				if err := m.image(p, plainMarkdown(label), dest, f); err != nil {
+					return err
+				}
+				last = nil
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if label, dest, end, ok := parseLink(s, i); ok {
+				flush()
+				m.link(p, plainMarkdown(label), dest, f)
+				last = nil
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				if dest := s[i+1 : i+end]; isAutolink(dest) {
+					flush()
+					target := dest
+					if !strings.Contains(dest, ":") {
+						target = "mailto:" + dest
+					}
+					m.link(p, dest, target, f)
+					last = nil
+					i += end + 1
+					continue
+				}
+			}
+
+		case c == '&':
+			if end := strings.IndexByte(s[i:], ';'); end > 1 && end < 32 {
+				if entity := html.UnescapeString(s[i : i+end+1]); entity != s[i:i+end+1] {
+					text.WriteString(entity)
+					i += end + 1
+					continue
+				}
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			n := runLength(s, i)
+			if inner, end, format, ok := emphasis(s, i, n, f); ok {
+				flush()
+				if err := m.spans(p, inner, format); err != nil {
+					return err
+				}
+				last = nil
+				i = end
+				continue
+			}
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+
+		text.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return nil
+}
+
+// link adds a hyperlink. Targets starting with "#" lead to the bookmark of the anchor.
+func (m *markdownReader) link(p *Paragraph, text, target string, f markdownFormat) {
+	if text == "" {
+		text = target
+	}
+
+	var link *Hyperlink
+	if strings.HasPrefix(target, "#") {
+		link = p.AddInternalLink(text, anchorBookmark(target[1:]))
+	} else {
+		link = p.AddLink(text, target)
+	}
+	if f.bold {
+		link.Bold(true)
+	}
+	if f.italic {
+		link.Italic(true)
+	}
+	if f.strike {
+		link.Strike(true)
+	}
+}
+
+// image adds the picture of a local image file at its size at 96 DPI, no wider than the text. Images
+// with a URL become links.
+func (m *markdownReader) image(p *Paragraph, alt, dest string, f markdownFormat) error {
+	if strings.Contains(dest, "://") || strings.HasPrefix(dest, "data:") {
+		m.link(p, alt, dest, f)
+		return nil
+	}
+
+	path := filepath.FromSlash(dest)
+	if !filepath.IsAbs(path) && m.opts.BaseDir != "" {
+		path = filepath.Join(m.opts.BaseDir, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := p.addImageData(data, filepath.Ext(path), alt, 0, 0); err != nil {
+		return fmt.Errorf("image %s: %w", dest, err)
+	}
+	return nil
+}
+
+// codeSpan returns the content of the code span whose backticks start at s[i] and the index after it.
+func codeSpan(s string, i int) (string, int, bool) {
+	n := runLength(s, i)
+	for j := i + n; j < len(s); {
+		k := strings.IndexByte(s[j:], '`')
+		if k < 0 {
+			break
+		}
+		j += k
+		m := runLength(s, j)
+		if m == n {
+			code := strings.ReplaceAll(s[i+n:j], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			return code, j + m, true
+		}
+		j += m
+	}
+	return "", 0, false
+}
+
+// parseLink parses the link whose text starts with the bracket at s[i], returning its text, its
+// destination and the index after it.
+func parseLink(s string, i int) (label, dest string, end int, ok bool) {
+	depth := 0
+	j := i
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+			continue
+		case '`':
+			if _, after, found := codeSpan(s, j); found {
+				j = after - 1
+			}
+			continue
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if j >= len(s) || j+1 >= len(s) || s[j+1] != '(' {
+		return "", "", 0, false
+	}
+	label = s[i+1 : j]
+
+	k := j + 2
+	for k < len(s) && (s[k] == ' ' || s[k] == '\n') {
+		k++
+	}
+	if k < len(s) && s[k] == '<' {
+		close := strings.IndexByte(s[k:], '>')
+		if close < 0 {
+			return "", "", 0, false
+		}
+		dest = s[k+1 : k+close]
+		k += close + 1
+	} else {
+		start, parens := k, 0
+		for ; k < len(s) && s[k] != ' ' && s[k] != '\n'; k++ {
+			if s[k] == '(' {
+				parens++
+			} else if s[k] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = s[start:k]
+	}
+
+	for k < len(s) && (s[k] == ' ' || s[k] == '\n') {
+		k++
+	}
+	if k < len(s) && (s[k] == '"' || s[k] == '\'' || s[k] == '(') {
+		quote := s[k]
+		if quote == '(' {
+			quote = ')'
+		}
+		close := strings.IndexByte(s[k+1:], quote)
+		if close < 0 {
+			return "", "", 0, false
+		}
+		k += close + 2
+		for k < len(s) && (s[k] == ' ' || s[k] == '\n') {
+			k++
+		}
+	}
+	if k >= len(s) || s[k] != ')' {
+		return "", "", 0, false
+	}
+
+	return label, unescapeMarkdown(dest), k + 1, true
+}
+
+// emphasis returns the content and formatting of the emphasis whose delimiter run of length n starts
+// at s[i], and the index after its closing delimiter.
+func emphasis(s string, i, n int, f markdownFormat) (string, int, markdownFormat, bool) {
+	c := s[i]
+	if !opensEmphasis(s, i, n) {
+		return "", 0, f, false
+	}
+
+	switch {
+	case c == '~' && n == 2:
+		f.strike = true
+	case c == '~' || n > 3:
+		return "", 0, f, false
+	case n == 1:
+		f.italic = true
+	case n == 2:
+		f.bold = true
+	default:
+		f.bold, f.italic = true, true
+	}
+
+	start := i + n
+	closer, ok := emphasisCloser(s, start, c, n)
+	if !ok || closer == start {
+		return "", 0, f, false
+	}
+	return s[start:closer], closer + n, f, true
+}
+
+// emphasisCloser returns the index of the delimiter run of at least n characters c, starting at or after
+// start, that closes an emphasis. Emphasis opened in between is skipped.
+func emphasisCloser(s string, start int, c byte, n int) (int, bool) {
+	for j := start; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			if _, end, ok := codeSpan(s, j); ok {
+				j = end
+				continue
+			}
+		case c:
+			m := runLength(s, j)
+			if closesEmphasis(s, j, m) && m >= n && j > start {
+				return j, true
+			}
+			if opensEmphasis(s, j, m) {
+				if inner, ok := emphasisCloser(s, j+m, c, m); ok {
+					j = inner + m
+					continue
+				}
+			}
+			j += m
+			continue
+		}
+		j++
+	}
+	return 0, false
+}
+
+// opensEmphasis reports whether the delimiter run of length n at s[i] can open emphasis.
+func opensEmphasis(s string, i, n int) bool {
+	if i+n >= len(s) || isSpace(s[i+n]) {
+		return false
+	}
+	return s[i] != '_' || i == 0 || !isAlnum(s[i-1])
+}
+
+// closesEmphasis reports whether the delimiter run of length n at s[i] can close emphasis.
+func closesEmphasis(s string, i, n int) bool {
+	if i == 0 || isSpace(s[i-1]) {
+		return false
+	}
+	return s[i] != '_' || i+n >= len(s) || !isAlnum(s[i+n])
+}
+
+// plainMarkdown returns the text of inline Markdown without its markup, for link texts and alt texts.
+func plainMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			b.WriteByte(s[i+1])
+			i++
+		case c == '`':
+			if code, end, ok := codeSpan(s, i); ok {
+				b.WriteString(code)
+				i = end - 1
+			} else {
+				b.WriteByte(c)
+			}
+		case c == '*' || c == '~' || (c == '_' && (i == 0 || !isAlnum(s[i-1]) || i+1 == len(s) || !isAlnum(s[i+1]))):
+		case c == '\n':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return html.UnescapeString(b.String())
+}
+
+// unescapeMarkdown removes the backslashes of escaped punctuation.
+func unescapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return html.UnescapeString(b.String())
+}
+
+// isAutolink reports whether the text between angle brackets is a URL or an email address.
+func isAutolink(s string) bool {
+	if s == "" || strings.ContainsAny(s, " <>\n") {
+		return false
+	}
+	if scheme := strings.IndexByte(s, ':'); scheme >= 2 {
+		for _, r := range s[:scheme] {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '.' && r != '-' {
+				return false
+			}
+		}
+		return true
+	}
+	at := strings.IndexByte(s, '@')
+	return at > 0 && strings.Contains(s[at:], ".")
+}
+
+// headingAnchor returns the anchor GitHub gives a heading with the given text.
+func headingAnchor(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// anchorBookmark returns the bookmark name of an anchor. Bookmark names cannot hold hyphens, which
+// become underscores, and are at most 40 characters long.
+func anchorBookmark(anchor string) string {
+	name := strings.ReplaceAll(anchor, "-", "_")
+	if len(name) > maxBookmarkName {
+		name = name[:maxBookmarkName]
+	}
+	return name
+}
+
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < 0x80 && unicode.IsPunct(rune(c)) || c < 0x80 && unicode.IsSymbol(rune(c))
+}
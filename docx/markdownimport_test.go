@@ -0,0 +1,207 @@
+package docx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_FromMarkdown(t *testing.T) {
+	input := "# Quarterly report\n\n" +
+		"## Summary\n\n" +
+		"Sales **grew** ***strongly***, see [the dashboard](https://example.com/q1). Cost: 5\\*3\n\n" +
+		"1. Plan\n" +
+		"    1. Detail\n" +
+		"2. Review\n\n" +
+		"- Done\n\n" +
+		"| Region | Sales |\n" +
+		"| --- | --- |\n" +
+		"| North \\| East | 120 |\n"
+
+	rd := NewRootDoc()
+	assert.NoError(t, rd.FromMarkdown(strings.NewReader(input)))
+
+	var out strings.Builder
+	assert.NoError(t, rd.ToMarkdown(&out))
+	assert.Equal(t, input, out.String())
+
+	children := rd.Document.Body.Children
+	assert.Equal(t, "Heading1", children[0].Para.ct.Property.Style.Val)
+	assert.NotNil(t, rd.Bookmark("quarterly_report"))
+	assert.Equal(t, "ListParagraph", children[3].Para.ct.Property.Style.Val)
+
+	// Nested lists of the same kind share the numbering definition
+	assert.Equal(t, children[3].Para.ct.Property.NumProp.NumID.Val, children[4].Para.ct.Property.NumProp.NumID.Val)
+	assert.Equal(t, 1, children[4].Para.ct.Property.NumProp.ILvl.Val)
+}
+
+func TestRootDoc_FromMarkdown_NestedStart(t *testing.T) {
+	rd := NewRootDoc()
+	assert.NoError(t, rd.FromMarkdown(strings.NewReader("- Tasks\n\n  3. Third\n  4. Fourth\n")))
+
+	// The nested list starts at 3 on the level of its items
+	var texts []string
+	for _, child := range rd.Document.Body.Children {
+		texts = append(texts, child.Para.Text())
+	}
+	assert.Equal(t, []string{"•\tTasks", "c.\tThird", "d.\tFourth"}, texts)
+	third := rd.Document.Body.Children[1].Para.ct.Property.NumProp
+	assert.Equal(t, 1, third.ILvl.Val)
+	num := rd.numbering().Num(third.NumID.Val)
+	assert.Equal(t, 3, num.Override(1).StartOverride.Val)
+	assert.Nil(t, num.Override(0))
+}
+
+func TestRootDoc_FromMarkdown_Blocks(t *testing.T) {
+	input := "Setext title\n============\n\n" +
+		"> Quoted *text*\ncontinued lazily\n\n" +
+		"```go\nfunc main() {\n\tfmt.Println(`hi`)\n}\n```\n\n" +
+		"Use `go test` and see [install](#install-steps).\n" +
+		"Line one  \nline two\n\n" +
+		"***\n\n" +
+		"## Install steps\n\n" +
+		"3) Third\n4) Fourth\n\n" +
+		"   continued item text\n"
+
+	rd := NewRootDoc()
+	assert.NoError(t, rd.FromMarkdown(strings.NewReader(input)))
+	assert.Equal(t, "Setext title|Quoted text continued lazily|func main() {\n\tfmt.Println(`hi`)\n}|"+
+		"Use go test and see install. Line one\nline two||Install steps|Third|Fourth|continued item text", bodyText(rd))
+
+	children := rd.Document.Body.Children
+	assert.Equal(t, "Heading1", children[0].Para.ct.Property.Style.Val)
+	assert.Equal(t, "Quote", children[1].Para.ct.Property.Style.Val)
+	assert.NotNil(t, children[1].Para.ct.Children[1].Run.Property.Italic)
+	assert.Equal(t, "MacroText", children[2].Para.ct.Property.Style.Val)
+
+	code := children[3].Para.ct.Children[1].Run
+	assert.Equal(t, "Courier New", code.Property.Fonts.Ascii)
+	link := children[3].Para.ct.Children[3].Link
+	assert.Equal(t, "install_steps", *link.Anchor)
+	assert.NotNil(t, rd.Bookmark("install_steps"))
+
+	assert.NotNil(t, children[3].Para.ct.Children[4].Run.Children[1].Break)
+	assert.NotNil(t, children[4].Para.ct.Property.Border.Bottom)
+
+	// The list starts at 3 and the paragraph after the blank line belongs to the last item
+	third := children[6].Para.ct.Property.NumProp
+	assert.Equal(t, third.NumID.Val, children[7].Para.ct.Property.NumProp.NumID.Val)
+	num := rd.numbering().Num(third.NumID.Val)
+	assert.Equal(t, 3, num.Override(0).StartOverride.Val)
+	assert.Nil(t, children[8].Para.ct.Property.NumProp)
+	assert.Equal(t, listIndentStep, *children[8].Para.ct.Property.Indent.Left)
+}
+
+func TestRootDoc_FromMarkdownWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart.png"), testPNG(t, 192, 96), 0o644))
+
+	input := "# Results\n\n> A quote\n\n```\ncode\n```\n\nSee `x`.\n\n" +
+		"![Sales chart](chart.png) ![Logo](https://example.com/logo.png)\n\n" +
+		"| A |\n| - |\n| 1 |\n"
+
+	rd := NewRootDoc()
+	err := rd.FromMarkdownWithOptions(strings.NewReader(input), MarkdownImportOptions{
+		Styles: MarkdownStyles{
+			Headings:   [6]string{"CorpHeading"},
+			Paragraph:  "CorpBody",
+			Quote:      "CorpQuote",
+			CodeBlock:  "CorpCode",
+			InlineCode: "CorpCodeChar",
+			Table:      "CorpTable",
+		},
+		BaseDir: dir,
+	})
+	assert.NoError(t, err)
+
+	children := rd.Document.Body.Children
+	assert.Equal(t, "CorpHeading", children[0].Para.ct.Property.Style.Val)
+	assert.Equal(t, "CorpQuote", children[1].Para.ct.Property.Style.Val)
+	assert.Equal(t, "CorpCode", children[2].Para.ct.Property.Style.Val)
+	assert.Equal(t, "Courier New", children[2].Para.ct.Children[0].Run.Property.Fonts.Ascii)
+	assert.Equal(t, "CorpBody", children[3].Para.ct.Property.Style.Val)
+	assert.Equal(t, "CorpCodeChar", children[3].Para.ct.Children[1].Run.Property.Style.Val)
+	assert.Equal(t, "CorpTable", children[5].Table.ct.TableProp.Style.Val)
+
+	pictures := children[4].Para.ct.Children
+	inline := pictures[0].Run.Children[0].Drawing.Inline[0]
+	assert.Equal(t, "Sales chart", inline.DocProp.Description)
+	assert.Equal(t, uint64(2*914400), inline.Extent.Width)
+	assert.Equal(t, uint64(914400), inline.Extent.Height)
+	_, stored := rd.FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+
+	rel := rd.Document.relationByID(pictures[2].Link.ID)
+	assert.Equal(t, "https://example.com/logo.png", rel.Target)
+
+	err = rd.FromMarkdown(strings.NewReader("![Missing](missing.png)"))
+	assert.Error(t, err)
+}
+
+func TestParseMarkdownBlocks(t *testing.T) {
+	tests := []struct {
+		input string
+		kinds []markdownBlockKind
+	}{
+		{"Text\n- item", []markdownBlockKind{markdownParagraph, markdownList}},
+		{"Text\n2. not a list", []markdownBlockKind{markdownParagraph}},
+		{"    code\n\nText", []markdownBlockKind{markdownCode, markdownParagraph}},
+		{"Text\n---", []markdownBlockKind{markdownHeading}},
+		{"---\nText", []markdownBlockKind{markdownRule, markdownParagraph}},
+		{"a | b\n--|--\n1 | 2\n\nText", []markdownBlockKind{markdownTable, markdownParagraph}},
+		{"a | b\n--|--|--", []markdownBlockKind{markdownParagraph}},
+		{"#Not a heading", []markdownBlockKind{markdownParagraph}},
+		{"- a\n\n- b\n\nText", []markdownBlockKind{markdownList, markdownParagraph}},
+	}
+
+	for _, tt := range tests {
+		var kinds []markdownBlockKind
+		for _, block := range parseMarkdownBlocks(strings.Split(tt.input, "\n")) {
+			kinds = append(kinds, block.kind)
+		}
+		assert.Equal(t, tt.kinds, kinds, tt.input)
+	}
+}
+
+func TestMarkdownEmphasis(t *testing.T) {
+	tests := []struct {
+		input string
+		runs  []string
+	}{
+		{"a *b* c", []string{"a ", "<i>b", " c"}},
+		{"**bold *and italic* text**", []string{"<b>bold ", "<b><i>and italic", "<b> text"}},
+		{"*a **b** c*", []string{"<i>a ", "<b><i>b", "<i> c"}},
+		{"snake_case_name", []string{"snake_case_name"}},
+		{"~~gone~~ and ~one~", []string{"<s>gone", " and ~one~"}},
+		{"2 * 3 * 4", []string{"2 * 3 * 4"}},
+		{"`*code*` &amp; \\*", []string{"*code*", " & *"}},
+	}
+
+	for _, tt := range tests {
+		rd := NewRootDoc()
+		p := rd.AddEmptyParagraph()
+		m := &markdownReader{root: rd, styles: MarkdownStyles{}.withDefaults()}
+		assert.NoError(t, m.inline(p, tt.input))
+
+		var runs []string
+		for _, child := range p.ct.Children {
+			prefix := ""
+			if prop := child.Run.Property; prop != nil {
+				if prop.Bold != nil {
+					prefix += "<b>"
+				}
+				if prop.Italic != nil {
+					prefix += "<i>"
+				}
+				if prop.Strike != nil {
+					prefix += "<s>"
+				}
+			}
+			runs = append(runs, prefix+runText(child.Run))
+		}
+		assert.Equal(t, tt.runs, runs, tt.input)
+	}
+}
@@ -0,0 +1,484 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// noteKind describes what differs between footnotes and endnotes.
+type noteKind struct {
+	partName    string // root element of the part
+	noteName    string // element of a single note
+	fileName    string // default location of the part
+	relType     string // type of the relationship that targets the part
+	contentType string // content type of the part
+	refStyle    string // character style of reference marks
+	textStyle   string // paragraph style of note text
+}
+
+var (
+	footnoteKind = &noteKind{
+		partName:    "w:footnotes",
+		noteName:    "w:footnote",
+		fileName:    "word/footnotes.xml",
+		relType:     constants.SourceRelationshipFootnotes,
+		contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.footnotes+xml",
+		refStyle:    "FootnoteReference",
+		textStyle:   "FootnoteText",
+	}
+
+	endnoteKind = &noteKind{
+		partName:    "w:endnotes",
+		noteName:    "w:endnote",
+		fileName:    "word/endnotes.xml",
+		relType:     constants.SourceRelationshipEndnotes,
+		contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.endnotes+xml",
+		refStyle:    "EndnoteReference",
+		textStyle:   "EndnoteText",
+	}
+)
+
+var notesAttrs = map[string]string{
+	"xmlns:w":      "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r":      "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+	"xmlns:wp":     "http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing",
+	"xmlns:w14":    "http://schemas.microsoft.com/office/word/2010/wordml",
+	"xmlns:mc":     "http://schemas.openxmlformats.org/markup-compatibility/2006",
+	"mc:Ignorable": "w14",
+}
+
+// Notes represents the footnotes part (word/footnotes.xml) or the endnotes part (word/endnotes.xml) of a document.
+type Notes struct {
+	Root  *RootDoc
+	Notes []*Note
+
+	// Attributes of the root element of an opened part (namespace declarations, mc:Ignorable, ...)
+	Attr []xml.Attr
+
+	// Relationships of the part, e.g. to the images and hyperlinks of the notes
+	Rels Relationships
+
+	kind     *noteKind
+	filename string
+}
+
+// Note represents a footnote or an endnote. Like the body of the document, its content is made of
+// paragraphs and tables.
+type Note struct {
+	Root     *RootDoc
+	Children []DocumentChild
+
+	ID   int
+	Type *stypes.FtnEdn // Type of special notes such as separators, nil for ordinary notes
+
+	// Attributes that are not modelled, kept for round trip
+	Attr []xml.Attr
+
+	kind *noteKind
+	rels *Relationships // relationships of the part holding the note
+}
+
+func newNotes(root *RootDoc, kind *noteKind, filename string) *Notes {
+	return &Notes{
+		Root:     root,
+		kind:     kind,
+		filename: filename,
+		Rels:     newPartRels(filename),
+	}
+}
+
+// Note returns the note with the given ID, or nil if there is none.
+func (ns *Notes) Note(id int) *Note {
+	for _, note := range ns.Notes {
+		if note.ID == id {
+			return note
+		}
+	}
+	return nil
+}
+
+// nextID returns an ID that is not used by any note of the part. IDs of ordinary notes start at 1.
+func (ns *Notes) nextID() int {
+	id := 1
+	for _, note := range ns.Notes {
+		if note.ID >= id {
+			id = note.ID + 1
+		}
+	}
+	return id
+}
+
+// addSeparators adds the notes Word uses for the line between the page text and the notes.
+func (ns *Notes) addSeparators() {
+	separators := []struct {
+		id    int
+		typ   stypes.FtnEdn
+		child ctypes.RunChild
+	}{
+		{-1, stypes.FtnEdnSeparator, ctypes.RunChild{Separator: &ctypes.Empty{}}},
+		{0, stypes.FtnEdnContinuationSeparator, ctypes.RunChild{ContSeparator: &ctypes.Empty{}}},
+	}
+
+	for _, separator := range separators {
+		typ := separator.typ
+		note := &Note{Root: ns.Root, ID: separator.id, Type: &typ, kind: ns.kind, rels: &ns.Rels}
+		p := &Paragraph{root: ns.Root, ct: &ctypes.Paragraph{}, rels: &ns.Rels}
+		p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{
+			Run: &ctypes.Run{Children: []ctypes.RunChild{separator.child}},
+		})
+		note.Children = append(note.Children, DocumentChild{Para: p})
+		ns.Notes = append(ns.Notes, note)
+	}
+}
+
+// MarshalXML implements the xml.Marshaler interface for the Notes type
+func (ns Notes) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = ns.kind.partName
+
+	declared := make(map[string]bool, len(ns.Attr))
+	for _, attr := range ns.Attr {
+		declared[attr.Name.Local] = true
+		start.Attr = append(start.Attr, attr)
+	}
+
+	for key, value := range notesAttrs {
+		if declared[key] {
+			continue
+		}
+		attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
+		start.Attr = append(start.Attr, attr)
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, note := range ns.Notes {
+		if err := note.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the Notes type
+func (ns *Notes) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	ns.Attr = ctypes.QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "footnote", "endnote":
+				note := &Note{Root: ns.Root, kind: ns.kind, rels: &ns.Rels}
+				if err = note.UnmarshalXML(d, elem); err != nil {
+					return err
+				}
+				ns.Notes = append(ns.Notes, note)
+			default:
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXML implements the xml.Marshaler interface for the Note type
+func (n Note) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = n.kind.noteName
+
+	if n.Type != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:type"}, Value: string(*n.Type)})
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(n.ID)})
+	start.Attr = append(start.Attr, n.Attr...)
+
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	if err = marshalDocumentChildren(e, n.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the Note type
+func (n *Note) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return err
+			}
+			n.ID = id
+		case "type":
+			typ, err := stypes.FtnEdnFromStr(attr.Value)
+			if err != nil {
+				return err
+			}
+			n.Type = &typ
+		default:
+			attrs = append(attrs, attr)
+		}
+	}
+	n.Attr = ctypes.QualifyAttrs(attrs)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			child, err := unmarshalDocumentChild(n.Root, n.rels, d, elem)
+			if err != nil {
+				return err
+			}
+			n.Children = append(n.Children, child)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// AddParagraph adds a paragraph with the given text to the note
+func (n *Note) AddParagraph(text string) *Paragraph {
+	p := n.AddEmptyParagraph()
+	p.AddText(text)
+	return p
+}
+
+// AddEmptyParagraph adds an empty paragraph to the note
+func (n *Note) AddEmptyParagraph() *Paragraph {
+	p := newParagraph(n.Root, paraInPart(n.rels))
+	if n.Type == nil && n.Root.GetStyleByID(n.kind.textStyle, stypes.StyleTypeParagraph) != nil {
+		p.Style(n.kind.textStyle)
+	}
+	n.Children = append(n.Children, DocumentChild{Para: p})
+	return p
+}
+
+// AddFootnote adds a footnote with the given text and places its reference mark at the end of the paragraph.
+// The returned note can be given more paragraphs and formatting.
+//
+// Example:
+//
+//	p := document.AddParagraph("The court disagreed.")
+//	p.AddFootnote("Smith v. Jones, 2019.")
+func (p *Paragraph) AddFootnote(text string) *Note {
+	note := p.root.addNote(footnoteKind, text)
+	p.appendRun(p.root.noteReference(note))
+	return note
+}
+
+// AddEndnote adds an endnote with the given text and places its reference mark at the end of the paragraph.
+// The returned note can be given more paragraphs and formatting.
+func (p *Paragraph) AddEndnote(text string) *Note {
+	note := p.root.addNote(endnoteKind, text)
+	p.appendRun(p.root.noteReference(note))
+	return note
+}
+
+// AddFootnote adds a footnote with the given text and places its reference mark right after the run.
+//
+// Example:
+//
+//	p := document.AddParagraph("")
+//	p.AddText("Revenue grew by 12%").AddFootnote("Unaudited figures.")
+//	p.AddText(" in the last quarter.")
+func (r *Run) AddFootnote(text string) *Note {
+	note := r.root.addNote(footnoteKind, text)
+	r.insertAfter(r.root.noteReference(note))
+	return note
+}
+
+// AddEndnote adds an endnote with the given text and places its reference mark right after the run.
+func (r *Run) AddEndnote(text string) *Note {
+	note := r.root.addNote(endnoteKind, text)
+	r.insertAfter(r.root.noteReference(note))
+	return note
+}
+
+// insertAfter places the run in the paragraph of r, right after r. Without a known paragraph, the content of
+// the run is appended to r instead.
+func (r *Run) insertAfter(run *ctypes.Run) {
+	if r.parent != nil {
+		children := r.parent.ct.Children
+		for i, child := range children {
+			if child.Run != r.ct {
+				continue
+			}
+			children = append(children[:i+1], append([]ctypes.ParagraphChild{r.root.trackedRun(run)}, children[i+1:]...)...)
+			r.parent.ct.Children = children
+			return
+		}
+	}
+
+	r.ct.Children = append(r.ct.Children, run.Children...)
+}
+
+// Footnotes returns the footnotes referenced by the runs of the paragraph, in order.
+func (p *Paragraph) Footnotes() []*Note {
+	return p.notes(footnoteKind)
+}
+
+// Endnotes returns the endnotes referenced by the runs of the paragraph, in order.
+func (p *Paragraph) Endnotes() []*Note {
+	return p.notes(endnoteKind)
+}
+
+func (p *Paragraph) notes(kind *noteKind) []*Note {
+	part := *p.root.notesField(kind)
+	if part == nil {
+		return nil
+	}
+
+	var notes []*Note
+	for _, run := range p.Runs() {
+		for _, runChild := range run.ct.Children {
+			ref := runChild.FootnoteReference
+			if kind == endnoteKind {
+				ref = runChild.EndnoteReference
+			}
+			if ref == nil {
+				continue
+			}
+			if note := part.Note(ref.ID); note != nil {
+				notes = append(notes, note)
+			}
+		}
+	}
+	return notes
+}
+
+// addNote adds a note of the given kind whose first paragraph starts with the note's reference mark,
+// followed by the text.
+func (rd *RootDoc) addNote(kind *noteKind, text string) *Note {
+	part := rd.notes(kind)
+
+	note := &Note{Root: rd, ID: part.nextID(), kind: kind, rels: &part.Rels}
+	p := note.AddEmptyParagraph()
+
+	mark := ctypes.RunChild{FootnoteRef: &ctypes.Empty{}}
+	if kind == endnoteKind {
+		mark = ctypes.RunChild{EndnoteRef: &ctypes.Empty{}}
+	}
+	p.appendRun(&ctypes.Run{Property: rd.noteReferenceProp(kind), Children: []ctypes.RunChild{mark}})
+	if text != "" {
+		p.AddText(" " + text)
+	}
+
+	part.Notes = append(part.Notes, note)
+	return note
+}
+
+// noteReference returns a run holding the reference mark of the note.
+func (rd *RootDoc) noteReference(note *Note) *ctypes.Run {
+	ref := ctypes.RunChild{FootnoteReference: ctypes.NewFtnEdnRef(note.ID)}
+	if note.kind == endnoteKind {
+		ref = ctypes.RunChild{EndnoteReference: ctypes.NewFtnEdnRef(note.ID)}
+	}
+
+	return &ctypes.Run{Property: rd.noteReferenceProp(note.kind), Children: []ctypes.RunChild{ref}}
+}
+
+// noteReferenceProp returns the formatting of reference marks: the character style Word uses for them when the
+// document defines it, superscript otherwise.
+func (rd *RootDoc) noteReferenceProp(kind *noteKind) *ctypes.RunProperty {
+	if rd.GetStyleByID(kind.refStyle, stypes.StyleTypeCharacter) != nil {
+		return &ctypes.RunProperty{Style: ctypes.NewRunStyle(kind.refStyle)}
+	}
+	return &ctypes.RunProperty{VertAlign: ctypes.NewGenSingleStrVal(stypes.VerticalAlignRunSuperscript)}
+}
+
+// notesField returns the field of the root document that holds the part of the given kind.
+func (rd *RootDoc) notesField(kind *noteKind) **Notes {
+	if kind == endnoteKind {
+		return &rd.Endnotes
+	}
+	return &rd.Footnotes
+}
+
+// notes returns the part holding the notes of the given kind. Documents without one get a new part, which is
+// registered in the document relationships and content types.
+func (rd *RootDoc) notes(kind *noteKind) *Notes {
+	field := rd.notesField(kind)
+	if *field != nil {
+		return *field
+	}
+
+	if content, ok := rd.FileMap.Load(kind.fileName); ok {
+		if part, err := loadNotesXml(rd, kind, kind.fileName, content.([]byte)); err == nil && rd.loadPartRels(&part.Rels) == nil {
+			*field = part
+			return part
+		}
+	}
+
+	part := newNotes(rd, kind, kind.fileName)
+	part.addSeparators()
+	*field = part
+
+	if rd.Document.relationByType(kind.relType) == nil {
+		rd.Document.addRelation(kind.relType, kind.fileName[len("word/"):])
+	}
+	if !rd.ContentType.hasOverride("/" + kind.fileName) {
+		rd.ContentType.AddOverride("/"+kind.fileName, kind.contentType)
+	}
+
+	return part
+}
+
+// LoadFootnotesXml decodes the footnotes part of an opened document.
+//
+// Parameters:
+//   - rd: The root document the footnotes belong to.
+//   - fileName: The path of the part within the package, e.g. "word/footnotes.xml".
+//   - fileBytes: The XML data of the part.
+//
+// Returns:
+//   - *Notes: The decoded footnotes.
+//   - error: An error, if any occurred during the decoding process.
+func LoadFootnotesXml(rd *RootDoc, fileName string, fileBytes []byte) (*Notes, error) {
+	return loadNotesXml(rd, footnoteKind, fileName, fileBytes)
+}
+
+// LoadEndnotesXml decodes the endnotes part of an opened document.
+//
+// Parameters:
+//   - rd: The root document the endnotes belong to.
+//   - fileName: The path of the part within the package, e.g. "word/endnotes.xml".
+//   - fileBytes: The XML data of the part.
+//
+// Returns:
+//   - *Notes: The decoded endnotes.
+//   - error: An error, if any occurred during the decoding process.
+func LoadEndnotesXml(rd *RootDoc, fileName string, fileBytes []byte) (*Notes, error) {
+	return loadNotesXml(rd, endnoteKind, fileName, fileBytes)
+}
+
+func loadNotesXml(rd *RootDoc, kind *noteKind, fileName string, fileBytes []byte) (*Notes, error) {
+	ns := newNotes(rd, kind, fileName)
+	if err := xml.Unmarshal(fileBytes, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
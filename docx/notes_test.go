@@ -0,0 +1,136 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraph_AddFootnote(t *testing.T) {
+	rd := NewRootDoc()
+
+	p := rd.AddParagraph("The court disagreed.")
+	note := p.AddFootnote("Smith v. Jones, 2019.")
+	note.AddParagraph("See also the appeal.")
+
+	assert.NotNil(t, rd.Footnotes)
+	assert.Nil(t, rd.Endnotes)
+	assert.NotNil(t, rd.Document.relationByType(constants.SourceRelationshipFootnotes))
+	assert.True(t, rd.ContentType.hasOverride("/word/footnotes.xml"))
+
+	assert.Equal(t, 1, note.ID)
+	assert.Len(t, rd.Footnotes.Notes, 3) // separator, continuation separator and the note
+	assert.Equal(t, []*Note{note}, p.Footnotes())
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:r><w:rPr><w:vertAlign w:val="superscript"></w:vertAlign></w:rPr><w:footnoteReference w:id="1"></w:footnoteReference></w:r></w:p>`)
+
+	output, err = xml.Marshal(rd.Footnotes)
+	assert.NoError(t, err)
+	expected := `<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator></w:separator></w:r></w:p></w:footnote>` +
+		`<w:footnote w:type="continuationSeparator" w:id="0"><w:p><w:r><w:continuationSeparator></w:continuationSeparator></w:r></w:p></w:footnote>` +
+		`<w:footnote w:id="1">` +
+		`<w:p><w:r><w:rPr><w:vertAlign w:val="superscript"></w:vertAlign></w:rPr><w:footnoteRef></w:footnoteRef></w:r>` +
+		`<w:r><w:t xml:space="preserve"> Smith v. Jones, 2019.</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>See also the appeal.</w:t></w:r></w:p>` +
+		`</w:footnote>`
+	assert.Contains(t, string(output), expected)
+}
+
+func TestRun_AddEndnote(t *testing.T) {
+	rd := NewRootDoc()
+
+	p := rd.AddEmptyParagraph()
+	run := p.AddText("Revenue grew by 12%")
+	tail := p.AddText(" in the last quarter.")
+	first := run.AddEndnote("Unaudited figures.")
+	second := tail.AddEndnote("Source: annual report.")
+
+	assert.NotNil(t, rd.Endnotes)
+	assert.Nil(t, rd.Footnotes)
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, 2, second.ID)
+
+	children := p.ct.Children
+	assert.Len(t, children, 4)
+	assert.Same(t, run.ct, children[0].Run)
+	assert.Equal(t, 1, children[1].Run.Children[0].EndnoteReference.ID)
+	assert.Same(t, tail.ct, children[2].Run)
+	assert.Equal(t, 2, children[3].Run.Children[0].EndnoteReference.ID)
+
+	assert.Equal(t, []*Note{first, second}, p.Endnotes())
+	assert.Empty(t, p.Footnotes())
+}
+
+func TestLoadFootnotesXml(t *testing.T) {
+	input := `<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:id="1" w14:paraId="1A2B3C4D"><w:p><w:pPr><w:pStyle w:val="FootnoteText"/></w:pPr>` +
+		`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:footnoteRef/></w:r>` +
+		`<w:r><w:t xml:space="preserve"> Existing note</w:t></w:r></w:p>` +
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl></w:footnote>` +
+		`</w:footnotes>`
+
+	rd := NewRootDoc()
+	footnotes, err := LoadFootnotesXml(rd, "word/footnotes.xml", []byte(input))
+	assert.NoError(t, err)
+	rd.Footnotes = footnotes
+
+	assert.Len(t, footnotes.Notes, 2)
+	assert.Equal(t, stypes.FtnEdnSeparator, *footnotes.Notes[0].Type)
+	note := footnotes.Note(1)
+	assert.Nil(t, note.Type)
+	assert.Len(t, note.Children, 2)
+	assert.NotNil(t, note.Children[1].Table)
+
+	// New notes continue after the existing ones, in the same part
+	added := rd.AddParagraph("More").AddFootnote("Added")
+	assert.Equal(t, 2, added.ID)
+	assert.Same(t, footnotes, rd.Footnotes)
+	assert.Nil(t, rd.Document.relationByType(constants.SourceRelationshipFootnotes))
+
+	output, err := xml.Marshal(footnotes)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:footnote w:id="1" w14:paraId="1A2B3C4D"><w:p><w:pPr><w:pStyle w:val="FootnoteText"></w:pStyle></w:pPr>`+
+		`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"></w:rStyle></w:rPr><w:footnoteRef></w:footnoteRef></w:r>`+
+		`<w:r><w:t xml:space="preserve"> Existing note</w:t></w:r></w:p>`)
+	assert.Contains(t, string(output), `<w:footnote w:id="2">`)
+}
+
+func TestNotes_Relationships(t *testing.T) {
+	rd := NewRootDoc()
+	docRels := len(rd.Document.DocRels.Relationships)
+
+	note := rd.AddParagraph("Sources").AddEndnote("")
+	link := note.AddParagraph("See ").AddLink("the report", "https://example.com/report")
+	assert.Len(t, rd.Document.DocRels.Relationships, docRels+1) // the endnotes part
+	assert.Len(t, rd.Endnotes.Rels.Relationships, 1)
+
+	target, err := link.Target()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/report", target)
+
+	var buf bytes.Buffer
+	assert.NoError(t, rd.Write(&buf))
+	data, ok := rd.FileMap.Load("word/_rels/endnotes.xml.rels")
+	assert.True(t, ok)
+	assert.Contains(t, string(data.([]byte)), `Target="https://example.com/report" TargetMode="External"`)
+
+	// Parts left in the package are read with their relationships
+	notesData, _ := rd.FileMap.Load("word/endnotes.xml")
+	opened := NewRootDoc()
+	opened.FileMap.Store("word/endnotes.xml", notesData)
+	opened.FileMap.Store("word/_rels/endnotes.xml.rels", data)
+
+	links := opened.notes(endnoteKind).Note(1).Children[1].Para.Hyperlinks()
+	assert.Len(t, links, 1)
+	target, err = links[0].Target()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/report", target)
+}
@@ -0,0 +1,191 @@
+package docx
+
+import (
+	"fmt"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+const numberingFileName = "word/numbering.xml"
+
+// maxListLevel is the deepest level a numbering definition can have; levels are numbered from 0.
+const maxListLevel = 8
+
+// listIndentStep is the indentation, in twentieths of a point, added by each list level.
+const listIndentStep = 720
+
+// listHanging is the space, in twentieths of a point, between the number or bullet of a list item and its text.
+const listHanging = 360
+
+var (
+	bulletSymbols = []string{"•", "◦", "▪"}
+	numberFormats = []stypes.NumFmt{stypes.NumFmtDecimal, stypes.NumFmtLowerLetter, stypes.NumFmtLowerRoman}
+)
+
+// numbering returns the numbering definitions of the document. Documents without a numbering part get a
+// new one, which is registered in the document relationships and content types.
+func (rd *RootDoc) numbering() *ctypes.Numbering {
+	if rd.Numbering != nil {
+		return rd.Numbering
+	}
+
+	if content, ok := rd.FileMap.Load(numberingFileName); ok {
+		if numbering, err := LoadNumbering(numberingFileName, content.([]byte)); err == nil {
+			rd.Numbering = numbering
+			return rd.Numbering
+		}
+	}
+
+	rd.Numbering = ctypes.NewNumbering(numberingFileName)
+	if rd.Document.relationByType(constants.SourceRelationshipNumbering) == nil {
+		rd.Document.addRelation(constants.SourceRelationshipNumbering, "numbering.xml")
+	}
+	if !rd.ContentType.hasOverride("/" + numberingFileName) {
+		rd.ContentType.AddOverride("/"+numberingFileName, "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml")
+	}
+
+	return rd.Numbering
+}
+
+// List is a bulleted or numbered list. Its items are paragraphs that refer to the same numbering
+// definition instance (w:num), so they are numbered in sequence wherever they are in the document.
+type List struct {
+	root *RootDoc
+	num  *ctypes.Num
+}
+
+// AddBulletList adds the definition of a bulleted list with nine levels to the document and returns a
+// list that uses it.
+//
+// Example:
+//
+//	list := document.AddBulletList()
+//	list.AddItem("Fruit", 0)
+//	list.AddItem("Apples", 1)
+//	list.AddItem("Pears", 1)
+func (rd *RootDoc) AddBulletList() *List {
+	abstractNum := &ctypes.AbstractNum{
+		MultiLevelType: ctypes.NewGenSingleStrVal(stypes.MultiLevelTypeHybridMultilevel),
+	}
+
+	for ilvl := 0; ilvl <= maxListLevel; ilvl++ {
+		level := ctypes.NewLevel(ilvl, stypes.NumFmtBullet, bulletSymbols[ilvl%len(bulletSymbols)])
+		level.ParaProp = listLevelProp(ilvl)
+		abstractNum.Levels = append(abstractNum.Levels, level)
+	}
+
+	return rd.AddList(abstractNum)
+}
+
+// AddNumberedList adds the definition of a numbered list with nine levels to the document and returns a
+// list that uses it. The levels are numbered 1., a., i., 1., ... and every list added this way starts
+// counting at 1.
+//
+// Example:
+//
+//	steps := document.AddNumberedList()
+//	steps.AddItem("Unpack the box", 0)
+//	steps.AddItem("Connect the cable", 0)
+//	steps.AddItem("Use the grey one", 1)
+func (rd *RootDoc) AddNumberedList() *List {
+	abstractNum := &ctypes.AbstractNum{
+		MultiLevelType: ctypes.NewGenSingleStrVal(stypes.MultiLevelTypeHybridMultilevel),
+	}
+
+	for ilvl := 0; ilvl <= maxListLevel; ilvl++ {
+		level := ctypes.NewLevel(ilvl, numberFormats[ilvl%len(numberFormats)], fmt.Sprintf("%%%d.", ilvl+1))
+		level.ParaProp = listLevelProp(ilvl)
+		abstractNum.Levels = append(abstractNum.Levels, level)
+	}
+
+	return rd.AddList(abstractNum)
+}
+
+// AddList adds the given abstract numbering definition to the document under a new ID and returns a list
+// that uses it. Use it for lists whose levels are defined by the caller, e.g. with ctypes.NewLevel.
+func (rd *RootDoc) AddList(abstractNum *ctypes.AbstractNum) *List {
+	numbering := rd.numbering()
+	abstractNumID := numbering.AddAbstractNum(abstractNum)
+
+	return &List{
+		root: rd,
+		num:  numbering.AddNum(abstractNumID),
+	}
+}
+
+// listLevelProp returns the indentation of the given list level.
+func listLevelProp(ilvl int) *ctypes.ParagraphProp {
+	left := listIndentStep * (ilvl + 1)
+	hanging := uint64(listHanging)
+
+	return &ctypes.ParagraphProp{
+		Indent: &ctypes.Indent{Left: &left, Hanging: &hanging},
+	}
+}
+
+// NumID returns the ID of the numbering definition instance of the list, which paragraphs refer to
+// through Paragraph.Numbering.
+func (l *List) NumID() int {
+	return l.num.ID
+}
+
+// Definition returns the abstract numbering definition of the list, whose levels can be changed to alter
+// the numbering format, level text and indentation. It returns nil if the definition is missing.
+func (l *List) Definition() *ctypes.AbstractNum {
+	if l.num.AbstractNumID == nil {
+		return nil
+	}
+	return l.root.numbering().AbstractNum(l.num.AbstractNumID.Val)
+}
+
+// AddItem appends a paragraph with the given text to the document as an item of the list at the given
+// level, where 0 is the outermost level. Levels beyond 8 are placed at level 8.
+func (l *List) AddItem(text string, level int) *Paragraph {
+	if level < 0 {
+		level = 0
+	} else if level > maxListLevel {
+		level = maxListLevel
+	}
+
+	p := l.root.AddParagraph(text)
+	p.Numbering(l.NumID(), level)
+	return p
+}
+
+// Restart returns a list with the same definition whose numbering starts again, at the start value of
+// the outermost level. The items of the returned list continue the numbering of each other, not of l.
+func (l *List) Restart() *List {
+	start := 1
+	if definition := l.Definition(); definition != nil {
+		if level := definition.Level(0); level != nil && level.Start != nil {
+			start = level.Start.Val
+		}
+	}
+	return l.RestartAt(start)
+}
+
+// RestartAt returns a list with the same definition whose numbering starts again at the given value.
+func (l *List) RestartAt(start int) *List {
+	return l.RestartLevelAt(0, start)
+}
+
+// RestartLevelAt returns a list with the same definition whose numbering starts again at the given
+// value on the given level, for lists whose items are added at that level. Levels beyond 8 are placed
+// at level 8.
+func (l *List) RestartLevelAt(level, start int) *List {
+	if level < 0 {
+		level = 0
+	} else if level > maxListLevel {
+		level = maxListLevel
+	}
+
+	num := l.root.numbering().AddNum(l.num.AbstractNumID.Val)
+	num.SetStartOverride(level, start)
+
+	return &List{
+		root: l.root,
+		num:  num,
+	}
+}
@@ -0,0 +1,109 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_AddBulletList(t *testing.T) {
+	rd := NewRootDoc()
+
+	list := rd.AddBulletList()
+	fruit := list.AddItem("Fruit", 0)
+	apples := list.AddItem("Apples", 1)
+	deep := list.AddItem("Too deep", 12)
+
+	assert.NotNil(t, rd.Numbering)
+	assert.NotNil(t, rd.Document.relationByType(constants.SourceRelationshipNumbering))
+	assert.True(t, rd.ContentType.hasOverride("/word/numbering.xml"))
+
+	assert.Equal(t, 1, list.NumID())
+	assert.Equal(t, 1, fruit.ct.Property.NumProp.NumID.Val)
+	assert.Equal(t, 0, fruit.ct.Property.NumProp.ILvl.Val)
+	assert.Equal(t, 1, apples.ct.Property.NumProp.ILvl.Val)
+	assert.Equal(t, 8, deep.ct.Property.NumProp.ILvl.Val)
+
+	definition := list.Definition()
+	assert.Len(t, definition.Levels, 9)
+	for _, level := range definition.Levels {
+		assert.Equal(t, stypes.NumFmtBullet, level.NumFmt.Val)
+		assert.Equal(t, 720*(level.ILvl+1), *level.ParaProp.Indent.Left)
+		assert.Equal(t, uint64(360), *level.ParaProp.Indent.Hanging)
+	}
+}
+
+func TestRootDoc_AddNumberedList(t *testing.T) {
+	rd := NewRootDoc()
+
+	first := rd.AddNumberedList()
+	second := rd.AddNumberedList()
+
+	assert.NotEqual(t, first.NumID(), second.NumID())
+	assert.NotEqual(t, first.Definition().ID, second.Definition().ID)
+	assert.Len(t, rd.Numbering.AbstractNums, 2)
+
+	tests := []struct {
+		ilvl   int
+		format stypes.NumFmt
+		text   string
+	}{
+		{0, stypes.NumFmtDecimal, "%1."},
+		{1, stypes.NumFmtLowerLetter, "%2."},
+		{2, stypes.NumFmtLowerRoman, "%3."},
+		{3, stypes.NumFmtDecimal, "%4."},
+	}
+
+	for _, tt := range tests {
+		level := rd.Numbering.Level(first.NumID(), tt.ilvl)
+		assert.Equal(t, tt.format, level.NumFmt.Val)
+		assert.Equal(t, tt.text, level.Text.Val)
+		assert.Equal(t, 1, level.Start.Val)
+	}
+}
+
+func TestList_Restart(t *testing.T) {
+	rd := NewRootDoc()
+
+	steps := rd.AddNumberedList()
+	steps.AddItem("Unpack", 0)
+	steps.Definition().Level(0).Start = ctypes.NewDecimalNum(3)
+
+	restarted := steps.Restart()
+	item := restarted.AddItem("Unpack again", 0)
+
+	assert.NotEqual(t, steps.NumID(), restarted.NumID())
+	assert.Same(t, steps.Definition(), restarted.Definition())
+	assert.Equal(t, restarted.NumID(), item.ct.Property.NumProp.NumID.Val)
+
+	override := rd.Numbering.Num(restarted.NumID()).Override(0)
+	assert.Equal(t, 3, override.StartOverride.Val)
+
+	assert.Equal(t, 10, steps.RestartAt(10).num.Override(0).StartOverride.Val)
+	assert.Nil(t, rd.Numbering.Num(steps.NumID()).Override(0))
+}
+
+func TestRootDoc_AddList_KeepsExistingDefinitions(t *testing.T) {
+	rd := NewRootDoc()
+
+	numbering, err := LoadNumbering(numberingFileName, []byte(`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:abstractNum w:abstractNumId="4"><w:lvl w:ilvl="0"><w:numFmt w:val="upperRoman"/></w:lvl></w:abstractNum>`+
+		`<w:num w:numId="7"><w:abstractNumId w:val="4"/></w:num>`+
+		`</w:numbering>`))
+	assert.NoError(t, err)
+	rd.Numbering = numbering
+
+	list := rd.AddBulletList()
+	assert.Equal(t, 8, list.NumID())
+	assert.Equal(t, 5, list.Definition().ID)
+	assert.Nil(t, rd.Document.relationByType(constants.SourceRelationshipNumbering))
+
+	output, err := xml.Marshal(rd.Numbering)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:abstractNum w:abstractNumId="4"><w:lvl w:ilvl="0"><w:numFmt w:val="upperRoman"></w:numFmt></w:lvl></w:abstractNum>`)
+	assert.Contains(t, string(output), `<w:num w:numId="8"><w:abstractNumId w:val="5"></w:abstractNumId></w:num>`)
+}
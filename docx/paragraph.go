@@ -3,15 +3,11 @@ package docx
 import (
 	"encoding/xml"
 	"errors"
-	"fmt"
 	"path/filepath"
-	"strings"
 
 	"github.com/mrlijnden/godocx/common/constants"
 	"github.com/mrlijnden/godocx/common/units"
 	"github.com/mrlijnden/godocx/dml"
-	"github.com/mrlijnden/godocx/dml/dmlct"
-	"github.com/mrlijnden/godocx/dml/dmlpic"
 	"github.com/mrlijnden/godocx/internal"
 	"github.com/mrlijnden/godocx/wml/ctypes"
 	"github.com/mrlijnden/godocx/wml/stypes"
@@ -19,8 +15,9 @@ import (
 
 // Paragraph represents a paragraph in a DOCX document.
 type Paragraph struct {
-	root *RootDoc         // root is a reference to the root document.
-	ct   ctypes.Paragraph // ct holds the underlying Paragraph Complex Type.
+	root *RootDoc          // root is a reference to the root document.
+	ct   *ctypes.Paragraph // ct holds the underlying Paragraph Complex Type.
+	rels *Relationships    // rels are the relationships of the part holding the paragraph; nil for the document.
 }
 
 func (p *Paragraph) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -42,13 +39,22 @@ type paraOption func(*Paragraph)
 func newParagraph(root *RootDoc, opts ...paraOption) *Paragraph {
 	p := &Paragraph{
 		root: root,
+		ct:   &ctypes.Paragraph{},
 	}
+	p.markInserted()
 	for _, opt := range opts {
 		opt(p)
 	}
 	return p
 }
 
+// paraInPart is an option for a Paragraph held by a part with relationships of its own, such as a header.
+func paraInPart(rels *Relationships) paraOption {
+	return func(p *Paragraph) {
+		p.rels = rels
+	}
+}
+
 // paraWithText is an option for adding text to a Paragraph.
 func paraWithText(text string) paraOption {
 	return func(p *Paragraph) {
@@ -60,11 +66,12 @@ func (p *Paragraph) ensureProp() {
 	if p.ct.Property == nil {
 		p.ct.Property = ctypes.DefaultParaProperty()
 	}
+	p.trackPropChange()
 }
 
 // GetCT returns a pointer to the underlying Paragraph Complex Type.
 func (p *Paragraph) GetCT() *ctypes.Paragraph {
-	return &p.ct
+	return p.ct
 }
 
 // AddParagraph adds a new paragraph with the specified text to the document.
@@ -199,9 +206,11 @@ func (p *Paragraph) AddText(text string) *Run {
 		Children: runChildren,
 	}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+	p.appendRun(run)
 
-	return newRun(p.root, run)
+	r := newRun(p.root, run)
+	r.parent = p
+	return r
 }
 
 // AddEmptyParagraph adds a new empty paragraph to the document.
@@ -224,9 +233,11 @@ func (p *Paragraph) AddRun() *Run {
 
 	run := &ctypes.Run{}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+	p.appendRun(run)
 
-	return newRun(p.root, run)
+	r := newRun(p.root, run)
+	r.parent = p
+	return r
 }
 
 // GetStyle retrieves the style information applied to the Paragraph.
@@ -248,7 +259,7 @@ func (p *Paragraph) GetStyle() (*ctypes.Style, error) {
 }
 
 func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
-	rId := p.root.Document.addLinkRelation(link)
+	rId := p.root.addPartRelation(p.rels, constants.SourceRelationshipHyperLink, link, "External")
 
 	runChildren := []ctypes.RunChild{}
 	runChildren = append(runChildren, ctypes.RunChild{
@@ -270,7 +281,18 @@ func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
 
 	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Link: hyperLink})
 
-	return newHyperlink(p.root, hyperLink)
+	return newHyperlink(p.root, p.rels, hyperLink)
+}
+
+// Hyperlinks returns the hyperlinks contained in the paragraph, including those read from an opened document.
+func (p *Paragraph) Hyperlinks() []*Hyperlink {
+	var links []*Hyperlink
+	for _, child := range p.ct.Children {
+		if child.Link != nil {
+			links = append(links, newHyperlink(p.root, p.rels, child.Link))
+		}
+	}
+	return links
 }
 
 // AddDrawing adds a new drawing (image) to the Paragraph.
@@ -284,17 +306,7 @@ func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
 // Returns:
 //   - *dml.Inline: The created Inline instance representing the added drawing.
 func (p *Paragraph) addDrawing(rID string, imgCount uint, width units.Inch, height units.Inch) *dml.Inline {
-	eWidth := width.ToEmu()
-	eHeight := height.ToEmu()
-
-	inline := dml.NewInline(
-		*dmlct.NewPostvSz2D(eWidth, eHeight),
-		dml.DocProp{
-			ID:   uint64(imgCount),
-			Name: fmt.Sprintf("Image%d", imgCount),
-		},
-		*dml.NewPicGraphic(dmlpic.NewPic(rID, imgCount, eWidth, eHeight)),
-	)
+	inline := newPicInline(rID, imgCount, width, height)
 
 	runChildren := []ctypes.RunChild{}
 	drawing := &dml.Drawing{}
@@ -309,7 +321,7 @@ func (p *Paragraph) addDrawing(rID string, imgCount uint, width units.Inch, heig
 		Children: runChildren,
 	}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+	p.appendRun(run)
 
 	return &inline
 }
@@ -321,34 +333,11 @@ func (p *Paragraph) AddPicture(path string, width units.Inch, height units.Inch)
 		return nil, err
 	}
 
-	imgExt := filepath.Ext(path)
-	p.root.ImageCount += 1
-	fileName := fmt.Sprintf("image%d%s", p.root.ImageCount, imgExt)
-	fileIdxPath := fmt.Sprintf("%s%s", constants.MediaPath, fileName)
-
-	imgExtStripDot := strings.TrimPrefix(imgExt, ".")
-	imgMIME, err := MIMEFromExt(imgExtStripDot)
-	if err != nil {
-		return nil, err
-	}
-
-	err = p.root.ContentType.AddExtension(imgExtStripDot, imgMIME)
-	if err != nil {
-		return nil, err
-	}
-
-	overridePart := fmt.Sprintf("/%s%s", constants.MediaPath, fileName)
-	err = p.root.ContentType.AddOverride(overridePart, imgMIME)
+	rID, err := p.root.addImage(p.rels, imgBytes, filepath.Ext(path))
 	if err != nil {
 		return nil, err
 	}
 
-	p.root.FileMap.Store(fileIdxPath, imgBytes)
-
-	relName := fmt.Sprintf("media/%s", fileName)
-
-	rID := p.root.Document.addRelation(constants.SourceRelationshipImage, relName)
-
 	inline := p.addDrawing(rID, p.root.ImageCount, width, height)
 
 	return &PicMeta{
@@ -3,6 +3,7 @@ package docx
 import (
 	"testing"
 
+	"github.com/mrlijnden/godocx/internal"
 	"github.com/mrlijnden/godocx/wml/ctypes"
 	"github.com/mrlijnden/godocx/wml/stypes"
 	"github.com/stretchr/testify/assert"
@@ -42,7 +43,7 @@ func TestParagraph_Style(t *testing.T) {
 	f := func(styleValue string, expectedStyleValue string) {
 		t.Helper()
 
-		p := &Paragraph{}
+		p := &Paragraph{ct: &ctypes.Paragraph{}}
 
 		p.Style(styleValue)
 
@@ -58,7 +59,7 @@ func TestParagraph_Justification(t *testing.T) {
 	f := func(justificationValue, expectedJustificationValue stypes.Justification) {
 		t.Helper()
 
-		p := &Paragraph{}
+		p := &Paragraph{ct: &ctypes.Paragraph{}}
 
 		p.Justification(justificationValue)
 
@@ -77,7 +78,7 @@ func TestParagraph_Numbering(t *testing.T) {
 	f := func(id int, level int, expectedNumID int, expectedILvl int) {
 		t.Helper()
 
-		p := &Paragraph{}
+		p := &Paragraph{ct: &ctypes.Paragraph{}}
 
 		p.Numbering(id, level)
 
@@ -97,7 +98,7 @@ func TestParagraph_Indent(t *testing.T) {
 	f := func(indentValue, expectedIndentValue ctypes.Indent) {
 		t.Helper()
 
-		p := &Paragraph{}
+		p := &Paragraph{ct: &ctypes.Paragraph{}}
 
 		p.Indent(&indentValue)
 
@@ -130,7 +131,7 @@ func TestParagraph_AddText(t *testing.T) {
 		t.Helper()
 
 		p := &Paragraph{
-			ct: ctypes.Paragraph{
+			ct: &ctypes.Paragraph{
 				Children: []ctypes.ParagraphChild{},
 			},
 		}
@@ -154,7 +155,7 @@ func TestParagraph_AddText(t *testing.T) {
 
 func TestParagraph_AddRun(t *testing.T) {
 	p := &Paragraph{
-		ct: ctypes.Paragraph{
+		ct: &ctypes.Paragraph{
 			Children: []ctypes.ParagraphChild{},
 		},
 	}
@@ -171,3 +172,29 @@ func TestParagraph_AddRun(t *testing.T) {
 
 	assert.Equal(t, 0, len(p.ct.Children[0].Run.Children), "Expected the new Run to have no initial Children")
 }
+
+func TestParagraph_Hyperlinks(t *testing.T) {
+	rd := setupRootDoc(t)
+	para := rd.AddEmptyParagraph()
+	para.AddText("See ")
+	para.AddLink("example", "https://example.com")
+
+	links := para.Hyperlinks()
+	assert.Len(t, links, 1)
+
+	target, err := links[0].Target()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", target)
+}
+
+func TestHyperlink_TargetMissingRelation(t *testing.T) {
+	rd := setupRootDoc(t)
+	link := newHyperlink(rd, nil, &ctypes.Hyperlink{ID: "rId999"})
+
+	_, err := link.Target()
+	assert.Error(t, err)
+
+	link = newHyperlink(rd, nil, &ctypes.Hyperlink{Anchor: internal.ToPtr("intro")})
+	_, err = link.Target()
+	assert.Error(t, err)
+}
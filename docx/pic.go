@@ -1,8 +1,20 @@
 package docx
 
 import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	"github.com/mrlijnden/godocx/common/constants"
 	"github.com/mrlijnden/godocx/common/units"
 	"github.com/mrlijnden/godocx/dml"
+	"github.com/mrlijnden/godocx/dml/dmlct"
+	"github.com/mrlijnden/godocx/dml/dmlpic"
+	"github.com/mrlijnden/godocx/wml/ctypes"
 )
 
 type PicMeta struct {
@@ -39,3 +51,86 @@ func (rd *RootDoc) AddPicture(path string, width units.Inch, height units.Inch)
 
 	return p.AddPicture(path, width, height)
 }
+
+// addImage stores the image in the media folder of the package, registers its content type and returns
+// the ID of the relationship to it in rels, nil for the document. ImageCount is the number of the new image.
+func (rd *RootDoc) addImage(rels *Relationships, imgBytes []byte, imgExt string) (string, error) {
+	rd.ImageCount += 1
+	fileName := fmt.Sprintf("image%d%s", rd.ImageCount, imgExt)
+	fileIdxPath := fmt.Sprintf("%s%s", constants.MediaPath, fileName)
+
+	imgExtStripDot := strings.TrimPrefix(imgExt, ".")
+	imgMIME, err := MIMEFromExt(imgExtStripDot)
+	if err != nil {
+		return "", err
+	}
+
+	err = rd.ContentType.AddExtension(imgExtStripDot, imgMIME)
+	if err != nil {
+		return "", err
+	}
+
+	overridePart := fmt.Sprintf("/%s%s", constants.MediaPath, fileName)
+	err = rd.ContentType.AddOverride(overridePart, imgMIME)
+	if err != nil {
+		return "", err
+	}
+
+	rd.FileMap.Store(fileIdxPath, imgBytes)
+
+	relName := fmt.Sprintf("media/%s", fileName)
+
+	return rd.addPartRelation(rels, constants.SourceRelationshipImage, relName, ""), nil
+}
+
+// addImageData adds a picture of the image data with the given description to the paragraph. Sizes are
+// in pixels at 96 DPI; a size of zero is taken from the image, keeping its aspect ratio. Pictures are no
+// wider than the text.
+func (p *Paragraph) addImageData(data []byte, ext, description string, width, height int) error {
+	if width <= 0 || height <= 0 {
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		switch {
+		case width > 0 && config.Width > 0:
+			height = config.Height * width / config.Width
+		case height > 0 && config.Height > 0:
+			width = config.Width * height / config.Height
+		default:
+			width, height = config.Width, config.Height
+		}
+	}
+
+	w := units.Inch(float64(width) / 96)
+	h := units.Inch(float64(height) / 96)
+	if limit := units.Inch(float64(p.root.textWidth()) / 1440); w > limit && limit > 0 {
+		h = h * limit / w
+		w = limit
+	}
+
+	rID, err := p.root.addImage(p.rels, data, ext)
+	if err != nil {
+		return err
+	}
+
+	inline := newPicInline(rID, p.root.ImageCount, w, h)
+	inline.DocProp.Description = description
+	p.appendRun(&ctypes.Run{Children: []ctypes.RunChild{{Drawing: &dml.Drawing{Inline: []dml.Inline{inline}}}}})
+	return nil
+}
+
+// newPicInline returns an inline picture of the given size that shows the image of the relationship.
+func newPicInline(rID string, imgCount uint, width units.Inch, height units.Inch) dml.Inline {
+	eWidth := width.ToEmu()
+	eHeight := height.ToEmu()
+
+	return dml.NewInline(
+		*dmlct.NewPostvSz2D(eWidth, eHeight),
+		dml.DocProp{
+			ID:   uint64(imgCount),
+			Name: fmt.Sprintf("Image%d", imgCount),
+		},
+		*dml.NewPicGraphic(dmlpic.NewPic(rID, imgCount, eWidth, eHeight)),
+	)
+}
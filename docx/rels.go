@@ -2,6 +2,11 @@ package docx
 
 import (
 	"encoding/xml"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mrlijnden/godocx/common/constants"
 )
 
 // Relationship represents a relationship between elements in an Office Open XML (OOXML) document.
@@ -23,6 +28,58 @@ type Relationships struct {
 	Relationships []*Relationship `xml:"Relationship"`
 }
 
+// newPartRels returns the empty relationships of the part with the given path.
+func newPartRels(partName string) Relationships {
+	return Relationships{
+		RelativePath: partRelsName(partName),
+		Xmlns:        constants.XMLNS,
+	}
+}
+
+// partRelsName returns the path of the relationships of a part, e.g. word/_rels/header1.xml.rels.
+func partRelsName(name string) string {
+	dir, base := path.Split(name)
+	return dir + "_rels/" + base + ".rels"
+}
+
+// loadPartRels reads the relationships of a part that is still in the FileMap into rels, if it has any.
+func (rd *RootDoc) loadPartRels(rels *Relationships) error {
+	content, ok := rd.FileMap.Load(rels.RelativePath)
+	if !ok {
+		return nil
+	}
+	return xml.Unmarshal(content.([]byte), rels)
+}
+
+// relationByID returns the relationship with the given ID, or nil if it does not exist.
+func (r *Relationships) relationByID(rID string) *Relationship {
+	for _, rel := range r.Relationships {
+		if rel.ID == rID {
+			return rel
+		}
+	}
+	return nil
+}
+
+// add appends a relationship with an ID one above the highest "rIdN" in use and returns the ID.
+func (r *Relationships) add(relType string, target string, targetMode string) string {
+	last := 0
+	for _, rel := range r.Relationships {
+		if n, err := strconv.Atoi(strings.TrimPrefix(rel.ID, "rId")); err == nil && n > last {
+			last = n
+		}
+	}
+
+	rID := "rId" + strconv.Itoa(last+1)
+	r.Relationships = append(r.Relationships, &Relationship{
+		ID:         rID,
+		Type:       relType,
+		Target:     target,
+		TargetMode: targetMode,
+	})
+	return rID
+}
+
 func (r Relationship) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name.Local = "Relationship"
 	start.Attr = []xml.Attr{}
@@ -0,0 +1,250 @@
+package docx
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// ReplaceText replaces every occurrence of old with new in the document body, headers, footers,
+// footnotes and endnotes, including the text of hyperlinks and tables.
+//
+// Word often splits a sentence into several runs, for instance around a spelling mark or a change of
+// formatting. The search therefore runs over the text of each paragraph as a whole: a match may span
+// several runs, in which case the replacement takes the formatting of the first run of the match and
+// the matched text is removed from the other runs. Tabs, breaks, fields and drawings end the text a
+// match can span. Deleted text is not searched.
+//
+// Replacements are not recorded as tracked changes.
+//
+// Example:
+//
+//	count := document.ReplaceText("{{client}}", "Acme Corp.")
+//
+// Parameters:
+//   - old: The text to search for. Nothing is replaced when it is empty.
+//   - new: The replacement text.
+//
+// Returns:
+//   - int: The number of replacements.
+func (rd *RootDoc) ReplaceText(old, new string) int {
+	if old == "" {
+		return 0
+	}
+	return rd.replaceText(func(text string) []textMatch {
+		var matches []textMatch
+		for start := 0; ; {
+			i := strings.Index(text[start:], old)
+			if i < 0 {
+				return matches
+			}
+			start += i
+			matches = append(matches, textMatch{start: start, end: start + len(old), repl: new})
+			start += len(old)
+		}
+	})
+}
+
+// ReplaceTextRegexp replaces the matches of the regular expression, see ReplaceText. Inside repl, $
+// signs are interpreted as in regexp.Regexp.Expand, so that "$1" stands for the text of the first
+// submatch.
+//
+// Example:
+//
+//	// Turn "2024-05-01" into "01/05/2024"
+//	re := regexp.MustCompile(`(\d{4})-(\d{2})-(\d{2})`)
+//	document.ReplaceTextRegexp(re, "$3/$2/$1")
+//
+// Returns:
+//   - int: The number of replacements.
+func (rd *RootDoc) ReplaceTextRegexp(re *regexp.Regexp, repl string) int {
+	return rd.replaceText(func(text string) []textMatch {
+		var matches []textMatch
+		for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+			matches = append(matches, textMatch{
+				start: m[0],
+				end:   m[1],
+				repl:  string(re.ExpandString(nil, repl, text, m)),
+			})
+		}
+		return matches
+	})
+}
+
+// textMatch is a match of a search in the text of a paragraph, and the text that replaces it.
+type textMatch struct {
+	start, end int
+	repl       string
+}
+
+// textFinder returns the non-overlapping matches of a search in the text, in order.
+type textFinder func(text string) []textMatch
+
+func (rd *RootDoc) replaceText(find textFinder) int {
+	count := 0
+	replace := func(p *ctypes.Paragraph) {
+		count += replaceInParagraph(p, find)
+	}
+
+	forEachParagraph(rd.Document.Body.Children, replace)
+	for _, header := range rd.Headers {
+		forEachParagraph(header.Children, replace)
+	}
+	for _, footer := range rd.Footers {
+		forEachParagraph(footer.Children, replace)
+	}
+	for _, notes := range []*Notes{rd.Footnotes, rd.Endnotes} {
+		if notes == nil {
+			continue
+		}
+		for _, note := range notes.Notes {
+			forEachParagraph(note.Children, replace)
+		}
+	}
+	return count
+}
+
+// textSegment is a text element of a run, a piece of the text a match may span.
+type textSegment struct {
+	run  *ctypes.Run
+	text *ctypes.Text
+}
+
+// replaceInParagraph replaces the matches in each stretch of text of the paragraph and returns the
+// number of replacements.
+func replaceInParagraph(p *ctypes.Paragraph, find textFinder) int {
+	count := 0
+	for _, segments := range textStretches(p.Children) {
+		count += replaceInSegments(segments, find)
+	}
+	return count
+}
+
+// textStretches splits the text of the paragraph content into stretches that a match may span. Any run
+// content other than text, such as a tab, a break or a field character, ends a stretch.
+func textStretches(children []ctypes.ParagraphChild) [][]textSegment {
+	var stretches [][]textSegment
+	var current []textSegment
+	flush := func() {
+		if len(current) > 0 {
+			stretches = append(stretches, current)
+			current = nil
+		}
+	}
+
+	var walkRun func(run *ctypes.Run)
+	walkRun = func(run *ctypes.Run) {
+		for _, child := range run.Children {
+			switch {
+			case child.Text != nil:
+				current = append(current, textSegment{run: run, text: child.Text})
+			case child.LastRenPgBrk != nil:
+			default:
+				flush()
+			}
+		}
+	}
+
+	var walk func(children []ctypes.ParagraphChild)
+	walk = func(children []ctypes.ParagraphChild) {
+		for _, child := range children {
+			switch {
+			case child.Run != nil:
+				walkRun(child.Run)
+			case child.Link != nil:
+				if child.Link.Run != nil {
+					walkRun(child.Link.Run)
+				}
+				walk(child.Link.Children)
+			case child.Ins != nil:
+				walk(child.Ins.Children)
+			case child.MoveTo != nil:
+				walk(child.MoveTo.Children)
+			case child.Sdt != nil:
+				walk(child.Sdt.Content)
+			}
+		}
+	}
+
+	walk(children)
+	flush()
+	return stretches
+}
+
+// replaceInSegments replaces the matches in the text of the segments. The replacement goes to the
+// segment where the match starts; the rest of the match is removed from the segments that follow.
+func replaceInSegments(segments []textSegment, find textFinder) int {
+	offsets := make([]int, len(segments))
+	var text strings.Builder
+	for i, segment := range segments {
+		offsets[i] = text.Len()
+		text.WriteString(segment.text.Text)
+	}
+
+	matches := find(text.String())
+	if len(matches) == 0 {
+		return 0
+	}
+
+	// Work from the last match so that the offsets of the earlier ones stay valid
+	for m := len(matches) - 1; m >= 0; m-- {
+		match := matches[m]
+		first := segmentAt(segments, offsets, match.start)
+		last := first
+		if match.end > match.start {
+			last = segmentAt(segments, offsets, match.end-1)
+		}
+
+		firstText := segments[first].text.Text
+		head := firstText[:match.start-offsets[first]]
+		if first == last {
+			segments[first].text.Text = head + match.repl + firstText[match.end-offsets[first]:]
+			continue
+		}
+
+		segments[first].text.Text = head + match.repl
+		for i := first + 1; i < last; i++ {
+			segments[i].text.Text = ""
+		}
+		lastText := segments[last].text.Text
+		segments[last].text.Text = lastText[match.end-offsets[last]:]
+	}
+
+	for _, segment := range segments {
+		updateTextSpace(segment.text)
+		removeEmptyText(segment.run)
+	}
+	return len(matches)
+}
+
+// segmentAt returns the index of the segment that holds the byte at offset pos of the stretch, or the
+// last segment when pos is the end of the stretch.
+func segmentAt(segments []textSegment, offsets []int, pos int) int {
+	for i, segment := range segments {
+		if pos < offsets[i]+len(segment.text.Text) {
+			return i
+		}
+	}
+	return len(segments) - 1
+}
+
+// updateTextSpace preserves the spaces of a text that starts or ends with one.
+func updateTextSpace(text *ctypes.Text) {
+	if strings.TrimSpace(text.Text) != text.Text {
+		xmlSpace := ctypes.TextSpacePreserve
+		text.Space = &xmlSpace
+	}
+}
+
+// removeEmptyText removes the text elements of the run left empty by a replacement.
+func removeEmptyText(run *ctypes.Run) {
+	children := run.Children[:0]
+	for _, child := range run.Children {
+		if child.Text != nil && child.Text.Text == "" {
+			continue
+		}
+		children = append(children, child)
+	}
+	run.Children = children
+}
@@ -0,0 +1,100 @@
+package docx
+
+import (
+	"encoding/xml"
+	"regexp"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_ReplaceText(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	p.AddText("Dear {{cli").Bold(true)
+	p.AddText("ent}}, welcome ")
+	p.AddText("{{client}}!").Italic(true)
+
+	count := rd.ReplaceText("{{client}}", "Acme Corp.")
+	assert.Equal(t, 2, count)
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	expected := `<w:p>` +
+		`<w:r><w:rPr><w:b w:val="true"></w:b></w:rPr><w:t>Dear Acme Corp.</w:t></w:r>` +
+		`<w:r><w:t xml:space="preserve">, welcome </w:t></w:r>` +
+		`<w:r><w:rPr><w:i w:val="true"></w:i></w:rPr><w:t>Acme Corp.!</w:t></w:r>` +
+		`</w:p>`
+	assert.Equal(t, expected, string(output))
+
+	assert.Equal(t, 0, rd.ReplaceText("{{client}}", "Acme Corp."))
+	assert.Equal(t, 0, rd.ReplaceText("", "Acme Corp."))
+}
+
+func TestRootDoc_ReplaceText_AcrossRuns(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	p.AddText("The ")
+	p.AddText("sel").Bold(true)
+	p.AddText("l")
+	p.AddText("er agrees.")
+
+	assert.Equal(t, 1, rd.ReplaceText("seller", "buyer"))
+
+	runs := p.Runs()
+	assert.Len(t, runs, 4)
+	assert.Equal(t, "buyer", runs[1].ct.Children[0].Text.Text)
+	assert.NotNil(t, runs[1].ct.Property.Bold)
+	assert.Empty(t, runs[2].ct.Children)
+	assert.Equal(t, " agrees.", runs[3].ct.Children[0].Text.Text)
+}
+
+func TestRootDoc_ReplaceText_Barriers(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	p.AddText("Total").AddBreak(nil)
+	p.AddText("due")
+
+	// A break ends the text a match may span
+	assert.Equal(t, 0, rd.ReplaceText("Totaldue", "x"))
+	assert.Equal(t, 1, rd.ReplaceText("due", "paid"))
+	assert.Equal(t, "paid", p.Runs()[1].ct.Children[0].Text.Text)
+}
+
+func TestRootDoc_ReplaceText_Parts(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddParagraph("Visit ")
+	link := p.AddLink("ACME site", "https://example.com")
+
+	row := rd.AddTable().AddRow()
+	cell := row.AddCell().AddParagraph("ACME Ltd.")
+	header := rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("ACME confidential")
+	footer := rd.AddFooter(stypes.HdrFtrDefault).AddParagraph("© ACME")
+	note := p.AddFootnote("Owned by ACME.")
+
+	assert.Equal(t, 5, rd.ReplaceText("ACME", "Globex"))
+
+	assert.Equal(t, "Globex site", link.ct.Run.Children[0].Text.Text)
+	assert.Equal(t, "Globex Ltd.", cell.ct.Children[0].Run.Children[0].Text.Text)
+	assert.Equal(t, "Globex confidential", header.ct.Children[0].Run.Children[0].Text.Text)
+	assert.Equal(t, "© Globex", footer.ct.Children[0].Run.Children[0].Text.Text)
+
+	output, err := xml.Marshal(note.Children[0].Para.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "Owned by Globex.")
+}
+
+func TestRootDoc_ReplaceTextRegexp(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	p.AddText("Signed on 2024-")
+	p.AddText("05-01 and 2024-06-15.")
+
+	re := regexp.MustCompile(`(\d{4})-(\d{2})-(\d{2})`)
+	assert.Equal(t, 2, rd.ReplaceTextRegexp(re, "$3/$2/$1"))
+
+	runs := p.Runs()
+	assert.Equal(t, "Signed on 01/05/2024", runs[0].ct.Children[0].Text.Text)
+	assert.Equal(t, " and 15/06/2024.", runs[1].ct.Children[0].Text.Text)
+}
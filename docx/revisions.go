@@ -0,0 +1,839 @@
+package docx
+
+import (
+	"time"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// TrackChanges turns on change tracking: from now on, edits made through the library are recorded as
+// revisions by the given author, which can be reviewed, accepted or rejected in Word.
+//
+// While changes are tracked:
+//   - text added with Paragraph.AddText or Paragraph.AddRun is marked as inserted (w:ins), and so are
+//     new paragraphs;
+//   - text removed with Run.Remove or Paragraph.Remove is kept as deleted text (w:del);
+//   - formatting changes of existing runs and paragraphs keep the previous formatting (w:rPrChange,
+//     w:pPrChange).
+//
+// The document is also set to keep tracking the changes made in Word.
+//
+// Example:
+//
+//	document.TrackChanges("Jane Doe")
+//	p := document.AddParagraph("This clause was added by Jane.")
+//	document.StopTrackChanges()
+func (rd *RootDoc) TrackChanges(author string) {
+	rd.trackChanges = true
+	rd.revisionAuthor = author
+	rd.settings().SetOnOff("w:trackRevisions", true)
+}
+
+// StopTrackChanges turns off change tracking. Revisions recorded so far are kept.
+func (rd *RootDoc) StopTrackChanges() {
+	rd.trackChanges = false
+	rd.settings().SetOnOff("w:trackRevisions", false)
+}
+
+// IsTrackingChanges reports whether edits are recorded as revisions.
+func (rd *RootDoc) IsTrackingChanges() bool {
+	return rd.trackChanges
+}
+
+// Runs returns the runs of the paragraph, in order, including the runs of tracked insertions and deletions.
+func (p *Paragraph) Runs() []*Run {
+	var runs []*Run
+	for _, child := range p.ct.Children {
+		switch {
+		case child.Run != nil:
+			runs = append(runs, p.wrapRun(child.Run))
+		case child.Ins != nil:
+			for _, insChild := range child.Ins.Children {
+				if insChild.Run != nil {
+					runs = append(runs, p.wrapRun(insChild.Run))
+				}
+			}
+		case child.Del != nil:
+			for _, delChild := range child.Del.Children {
+				if delChild.Run != nil {
+					runs = append(runs, p.wrapRun(delChild.Run))
+				}
+			}
+		}
+	}
+	return runs
+}
+
+// Remove removes the run from its paragraph. While changes are tracked, the text of the run is kept as
+// deleted text instead, unless the run itself was inserted while tracking changes.
+func (r *Run) Remove() {
+	if r.parent == nil {
+		return
+	}
+
+	p := r.parent.ct
+	for i, child := range p.Children {
+		switch {
+		case child.Run == r.ct:
+			if !r.root.trackChanges {
+				p.Children = append(p.Children[:i], p.Children[i+1:]...)
+				return
+			}
+			del := r.root.runTrackChange()
+			del.Children = []ctypes.ParagraphChild{{Run: r.ct}}
+			markDeleted(r.ct)
+			p.Children[i] = ctypes.ParagraphChild{Del: del}
+			return
+		case child.Ins != nil:
+			// Removing inserted text undoes the insertion
+			for j, insChild := range child.Ins.Children {
+				if insChild.Run != r.ct {
+					continue
+				}
+				child.Ins.Children = append(child.Ins.Children[:j], child.Ins.Children[j+1:]...)
+				if len(child.Ins.Children) == 0 {
+					p.Children = append(p.Children[:i], p.Children[i+1:]...)
+				}
+				return
+			}
+		}
+	}
+}
+
+// Remove removes the paragraph from the document body, along with the relationships of its pictures and
+// hyperlinks that the rest of the document does not use. While changes are tracked, the paragraph is kept
+// with its text and its mark deleted instead, unless the paragraph itself was inserted while tracking
+// changes.
+func (p *Paragraph) Remove() {
+	rd := p.root
+	if !rd.trackChanges || p.inserted() {
+		if rd.Document.Body.removeParagraph(p) {
+			rd.releaseRelations(p.ct)
+		}
+		return
+	}
+
+	for _, run := range p.Runs() {
+		run.Remove()
+	}
+
+	if p.ct.Property == nil {
+		p.ct.Property = ctypes.DefaultParaProperty()
+	}
+	if p.ct.Property.RunProperty == nil {
+		p.ct.Property.RunProperty = &ctypes.RunProperty{}
+	}
+	p.ct.Property.RunProperty.Del = rd.trackChange()
+}
+
+// removeParagraph removes the paragraph from the body, looking into tables as well, and reports whether it
+// was found.
+func (b *Body) removeParagraph(p *Paragraph) bool {
+	for i, child := range b.Children {
+		if child.Para != nil && child.Para.ct == p.ct {
+			b.Children = append(b.Children[:i], b.Children[i+1:]...)
+			return true
+		}
+		if child.Table != nil && removeTableBlock(child.Table.ct, func(block ctypes.TCBlockContent) bool { return block.Paragraph == p.ct }) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeTableBlock removes the first paragraph or table of the cells of the table or its nested tables
+// that matches, and reports whether there was one.
+func removeTableBlock(tbl *ctypes.Table, match func(block ctypes.TCBlockContent) bool) bool {
+	for _, rowContent := range tbl.RowContents {
+		if rowContent.Row == nil {
+			continue
+		}
+		for _, cellContent := range rowContent.Row.Contents {
+			if cellContent.Cell == nil {
+				continue
+			}
+			cell := cellContent.Cell
+			for i, block := range cell.Contents {
+				if match(block) {
+					cell.Contents = append(cell.Contents[:i], cell.Contents[i+1:]...)
+					return true
+				}
+				if block.Table != nil && removeTableBlock(block.Table, match) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// wrapRun returns the Run wrapper of a run of the paragraph.
+func (p *Paragraph) wrapRun(ct *ctypes.Run) *Run {
+	r := newRun(p.root, ct)
+	r.parent = p
+	return r
+}
+
+// appendRun appends the run to the paragraph, as an insertion while changes are tracked.
+func (p *Paragraph) appendRun(run *ctypes.Run) {
+	p.ct.Children = append(p.ct.Children, p.root.trackedRun(run))
+}
+
+// trackedRun returns the paragraph content for a new run: the run itself, or an insertion holding it
+// while changes are tracked.
+func (rd *RootDoc) trackedRun(run *ctypes.Run) ctypes.ParagraphChild {
+	if rd == nil || !rd.trackChanges {
+		return ctypes.ParagraphChild{Run: run}
+	}
+
+	ins := rd.runTrackChange()
+	ins.Children = []ctypes.ParagraphChild{{Run: run}}
+	return ctypes.ParagraphChild{Ins: ins}
+}
+
+// markInserted marks the paragraph mark of a new paragraph as inserted while changes are tracked.
+func (p *Paragraph) markInserted() {
+	if p.root == nil || !p.root.trackChanges {
+		return
+	}
+
+	if p.ct.Property == nil {
+		p.ct.Property = ctypes.DefaultParaProperty()
+	}
+	if p.ct.Property.RunProperty == nil {
+		p.ct.Property.RunProperty = &ctypes.RunProperty{}
+	}
+	p.ct.Property.RunProperty.Ins = p.root.trackChange()
+}
+
+// inserted reports whether the paragraph mark is a tracked insertion.
+func (p *Paragraph) inserted() bool {
+	prop := p.ct.Property
+	return prop != nil && prop.RunProperty != nil && prop.RunProperty.Ins != nil
+}
+
+// trackPropChange records the current paragraph properties as the previous ones, before they are
+// changed while changes are tracked. Inserted paragraphs and paragraphs that already record their
+// previous properties are left as they are.
+func (p *Paragraph) trackPropChange() {
+	if p.root == nil || !p.root.trackChanges || p.inserted() || p.ct.Property.PPrChange != nil {
+		return
+	}
+
+	previous := *p.ct.Property
+	previous.RunProperty = nil
+	previous.SectPr = nil
+	previous.PPrChange = nil
+	if previous.NumProp != nil {
+		// Numbering is changed in place
+		numProp := *previous.NumProp
+		previous.NumProp = &numProp
+	}
+
+	tc := p.root.trackChange()
+	p.ct.Property.PPrChange = &ctypes.PPrChange{ID: tc.ID, Author: tc.Author, Date: tc.Date, ParaProp: &previous}
+}
+
+// inserted reports whether the run is part of a tracked insertion.
+func (r *Run) inserted() bool {
+	if r.parent == nil {
+		return false
+	}
+	for _, child := range r.parent.ct.Children {
+		if child.Ins == nil {
+			continue
+		}
+		for _, insChild := range child.Ins.Children {
+			if insChild.Run == r.ct {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// trackPropChange records the current run properties as the previous ones, before they are changed
+// while changes are tracked. Inserted runs and runs that already record their previous properties are
+// left as they are.
+func (r *Run) trackPropChange() {
+	if r.root == nil || !r.root.trackChanges || r.ct.Property.Change != nil || r.inserted() {
+		return
+	}
+
+	previous := *r.ct.Property
+	previous.Change = nil
+	if previous.Fonts != nil {
+		// Fonts are changed in place
+		fonts := *previous.Fonts
+		previous.Fonts = &fonts
+	}
+
+	tc := r.root.trackChange()
+	r.ct.Property.Change = &ctypes.RPrChange{ID: tc.ID, Author: tc.Author, Date: tc.Date, RunProp: &previous}
+}
+
+// markDeleted turns the text of a deleted run into deleted text.
+func markDeleted(run *ctypes.Run) {
+	for i, child := range run.Children {
+		if child.Text != nil {
+			run.Children[i] = ctypes.RunChild{DelText: child.Text}
+		}
+		if child.InstrText != nil {
+			run.Children[i] = ctypes.RunChild{DelInstrText: child.InstrText}
+		}
+	}
+}
+
+// trackChange returns the revision information of a new change: a unique ID, the author and the
+// current time.
+func (rd *RootDoc) trackChange() *ctypes.TrackChange {
+	date := time.Now().UTC().Format(time.RFC3339)
+	return &ctypes.TrackChange{ID: rd.nextRevisionID(), Author: rd.revisionAuthor, Date: &date}
+}
+
+// runTrackChange returns an empty insertion or deletion of run content for a new change.
+func (rd *RootDoc) runTrackChange() *ctypes.RunTrackChange {
+	tc := rd.trackChange()
+	return ctypes.NewRunTrackChange(tc.ID, tc.Author, tc.Date)
+}
+
+// nextRevisionID returns a revision ID that is not used in the document. The IDs of an opened
+// document are looked up the first time, in the body, headers, footers, notes and comments.
+func (rd *RootDoc) nextRevisionID() int {
+	if rd.revisionID == 0 {
+		stories := [][]DocumentChild{rd.Document.Body.Children}
+		for _, header := range rd.Headers {
+			stories = append(stories, header.Children)
+		}
+		for _, footer := range rd.Footers {
+			stories = append(stories, footer.Children)
+		}
+		for _, notes := range []*Notes{rd.Footnotes, rd.Endnotes} {
+			if notes == nil {
+				continue
+			}
+			for _, note := range notes.Notes {
+				stories = append(stories, note.Children)
+			}
+		}
+		if rd.Comments != nil {
+			for _, comment := range rd.Comments.Comments {
+				stories = append(stories, comment.Children)
+			}
+		}
+
+		for _, children := range stories {
+			forEachParagraph(children, func(p *ctypes.Paragraph) {
+				for _, id := range paragraphRevisionIDs(p) {
+					if id > rd.revisionID {
+						rd.revisionID = id
+					}
+				}
+			})
+		}
+	}
+
+	rd.revisionID++
+	return rd.revisionID
+}
+
+// paragraphRevisionIDs returns the IDs of the revisions recorded in the paragraph.
+func paragraphRevisionIDs(p *ctypes.Paragraph) []int {
+	var ids []int
+
+	runPropIDs := func(prop *ctypes.RunProperty) {
+		if prop == nil {
+			return
+		}
+		for _, tc := range []*ctypes.TrackChange{prop.Ins, prop.Del} {
+			if tc != nil {
+				ids = append(ids, tc.ID)
+			}
+		}
+		if prop.Change != nil {
+			ids = append(ids, prop.Change.ID)
+		}
+	}
+
+	if p.Property != nil {
+		runPropIDs(p.Property.RunProperty)
+		if p.Property.PPrChange != nil {
+			ids = append(ids, p.Property.PPrChange.ID)
+		}
+	}
+
+	var children func([]ctypes.ParagraphChild)
+	children = func(list []ctypes.ParagraphChild) {
+		for _, child := range list {
+			switch {
+			case child.Run != nil:
+				runPropIDs(child.Run.Property)
+			case child.Ins != nil:
+				ids = append(ids, child.Ins.ID)
+				children(child.Ins.Children)
+			case child.Del != nil:
+				ids = append(ids, child.Del.ID)
+				children(child.Del.Children)
+			}
+		}
+	}
+	children(p.Children)
+
+	return ids
+}
+
+// RevisionFilter selects tracked changes by author and date. The zero value selects all of them.
+type RevisionFilter struct {
+	Author string    // Only changes made by this author, if not empty
+	Since  time.Time // Only changes made at or after this time, if not zero
+	Until  time.Time // Only changes made before this time, if not zero
+}
+
+// matches reports whether a change with the given author and date is selected by the filter. Changes
+// without a date are not selected when the filter has a time bound.
+func (f RevisionFilter) matches(author string, date *string) bool {
+	if f.Author != "" && f.Author != author {
+		return false
+	}
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+	if date == nil {
+		return false
+	}
+
+	made, err := time.Parse(time.RFC3339, *date)
+	if err != nil {
+		return false
+	}
+	if !f.Since.IsZero() && made.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !made.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// AcceptAllRevisions accepts all tracked changes of the document, leaving its final text: insertions and
+// moves are kept, deletions are removed and formatting changes are kept. Changes are resolved in the body,
+// headers, footers, footnotes, endnotes and comments.
+func (rd *RootDoc) AcceptAllRevisions() {
+	rd.AcceptRevisions(RevisionFilter{})
+}
+
+// RejectAllRevisions rejects all tracked changes of the document, restoring its original text:
+// insertions are removed, deletions are kept, moved text goes back to where it was and previous
+// formatting is restored. Changes are resolved in the body, headers, footers, footnotes, endnotes and
+// comments.
+func (rd *RootDoc) RejectAllRevisions() {
+	rd.RejectRevisions(RevisionFilter{})
+}
+
+// AcceptRevisions accepts the tracked changes selected by the filter, see AcceptAllRevisions.
+//
+// Example:
+//
+//	// Keep what the supplier changed last week, review the rest in Word
+//	document.AcceptRevisions(docx.RevisionFilter{Author: "Supplier", Since: lastWeek})
+func (rd *RootDoc) AcceptRevisions(filter RevisionFilter) {
+	rd.resolveRevisions(&revisionResolver{accept: true, filter: filter})
+}
+
+// RejectRevisions rejects the tracked changes selected by the filter, see RejectAllRevisions.
+func (rd *RootDoc) RejectRevisions(filter RevisionFilter) {
+	rd.resolveRevisions(&revisionResolver{accept: false, filter: filter})
+}
+
+func (rd *RootDoc) resolveRevisions(rr *revisionResolver) {
+	body := rd.Document.Body
+	body.Children = rr.blocks(body.Children)
+
+	for _, header := range rd.Headers {
+		header.Children = rr.blocks(header.Children)
+	}
+	for _, footer := range rd.Footers {
+		footer.Children = rr.blocks(footer.Children)
+	}
+	for _, notes := range []*Notes{rd.Footnotes, rd.Endnotes} {
+		if notes == nil {
+			continue
+		}
+		for _, note := range notes.Notes {
+			note.Children = rr.blocks(note.Children)
+		}
+	}
+	if rd.Comments != nil {
+		for _, comment := range rd.Comments.Comments {
+			comment.Children = rr.blocks(comment.Children)
+		}
+	}
+}
+
+// revisionResolver accepts or rejects the tracked changes selected by its filter.
+type revisionResolver struct {
+	accept bool
+	filter RevisionFilter
+
+	moveRanges map[string]bool // IDs of the move range markers that go away
+}
+
+// keeps reports whether the content of a selected change survives: inserted content is kept when
+// changes are accepted, deleted content when they are rejected.
+func (rr *revisionResolver) keeps(inserted bool) bool {
+	return inserted == rr.accept
+}
+
+// blocks resolves the changes of block-level elements. A paragraph whose mark goes away is merged with
+// the paragraph that follows it; an empty one is removed.
+func (rr *revisionResolver) blocks(children []DocumentChild) []DocumentChild {
+	resolved := make([]DocumentChild, 0, len(children))
+	var pending *DocumentChild
+
+	for i := range children {
+		child := children[i]
+		if child.Para != nil {
+			p := child.Para.ct
+			removeMark := rr.paragraph(p)
+			if pending != nil {
+				p.Children = append(pending.Para.ct.Children, p.Children...)
+				pending = nil
+			}
+			if removeMark {
+				pending = &children[i]
+				continue
+			}
+			resolved = append(resolved, child)
+			continue
+		}
+
+		if pending != nil {
+			if len(pending.Para.ct.Children) > 0 {
+				resolved = append(resolved, *pending)
+			}
+			pending = nil
+		}
+		if child.Table != nil {
+			rr.table(child.Table.ct)
+		}
+		if child.Raw != nil && rr.dropsMarker(child.Raw) {
+			continue
+		}
+		resolved = append(resolved, child)
+	}
+
+	if pending != nil && len(pending.Para.ct.Children) > 0 {
+		resolved = append(resolved, *pending)
+	}
+
+	return resolved
+}
+
+// cellBlocks resolves the changes of the content of a table cell, like blocks. The last paragraph of a
+// cell is always kept.
+func (rr *revisionResolver) cellBlocks(contents []ctypes.TCBlockContent) []ctypes.TCBlockContent {
+	resolved := make([]ctypes.TCBlockContent, 0, len(contents))
+	var pending *ctypes.Paragraph
+
+	for i, content := range contents {
+		if p := content.Paragraph; p != nil {
+			removeMark := rr.paragraph(p)
+			if pending != nil {
+				p.Children = append(pending.Children, p.Children...)
+				pending = nil
+			}
+			if removeMark && i < len(contents)-1 {
+				pending = p
+				continue
+			}
+			resolved = append(resolved, content)
+			continue
+		}
+
+		if pending != nil {
+			if len(pending.Children) > 0 {
+				resolved = append(resolved, ctypes.TCBlockContent{Paragraph: pending})
+			}
+			pending = nil
+		}
+		if content.Table != nil {
+			rr.table(content.Table)
+		}
+		resolved = append(resolved, content)
+	}
+
+	return resolved
+}
+
+// paragraph resolves the changes of the paragraph and reports whether its mark goes away, in which case
+// its content belongs to the following paragraph.
+func (rr *revisionResolver) paragraph(p *ctypes.Paragraph) (removeMark bool) {
+	p.Children = rr.paragraphChildren(p.Children)
+
+	prop := p.Property
+	if prop == nil {
+		return false
+	}
+
+	if change := prop.PPrChange; change != nil && rr.filter.matches(change.Author, change.Date) {
+		prop.PPrChange = nil
+		if !rr.accept {
+			previous := ctypes.ParagraphProp{}
+			if change.ParaProp != nil {
+				previous = *change.ParaProp
+			}
+			previous.RunProperty = prop.RunProperty
+			previous.SectPr = prop.SectPr
+			*prop = previous
+		}
+	}
+
+	if prop.RunProperty == nil {
+		return false
+	}
+	mark := prop.RunProperty
+	rr.runProp(mark)
+
+	marks := []struct {
+		tc       **ctypes.TrackChange
+		inserted bool
+	}{
+		{&mark.Ins, true},
+		{&mark.Del, false},
+		{&mark.MoveTo, true},
+		{&mark.MoveFrom, false},
+	}
+	for _, m := range marks {
+		tc := *m.tc
+		if tc == nil || !rr.filter.matches(tc.Author, tc.Date) {
+			continue
+		}
+		*m.tc = nil
+		if !rr.keeps(m.inserted) && prop.SectPr == nil {
+			removeMark = true
+		}
+	}
+	if *mark == (ctypes.RunProperty{}) {
+		prop.RunProperty = nil
+	}
+
+	return removeMark
+}
+
+// paragraphChildren resolves the changes of paragraph content.
+func (rr *revisionResolver) paragraphChildren(children []ctypes.ParagraphChild) []ctypes.ParagraphChild {
+	resolved := make([]ctypes.ParagraphChild, 0, len(children))
+
+	for _, child := range children {
+		var change *ctypes.RunTrackChange
+		inserted := false
+		switch {
+		case child.Ins != nil:
+			change, inserted = child.Ins, true
+		case child.MoveTo != nil:
+			change, inserted = child.MoveTo, true
+		case child.Del != nil:
+			change = child.Del
+		case child.MoveFrom != nil:
+			change = child.MoveFrom
+		case child.Run != nil:
+			rr.runProp(child.Run.Property)
+		case child.Link != nil:
+			link := child.Link
+			if link.Run != nil {
+				rr.runProp(link.Run.Property)
+			}
+			if len(link.Children) > 0 {
+				link.Children = rr.paragraphChildren(link.Children)
+				if link.Run == nil && len(link.Children) == 0 {
+					// Nothing is left of the hyperlink
+					continue
+				}
+			}
+		case child.Raw != nil && rr.dropsMarker(child.Raw):
+			continue
+		}
+
+		if change == nil {
+			resolved = append(resolved, child)
+			continue
+		}
+
+		if !rr.filter.matches(change.Author, change.Date) {
+			change.Children = rr.paragraphChildren(change.Children)
+			resolved = append(resolved, child)
+			continue
+		}
+
+		if rr.keeps(inserted) {
+			content := rr.paragraphChildren(change.Children)
+			if !inserted {
+				for _, c := range content {
+					if c.Run != nil {
+						restoreDeleted(c.Run)
+					}
+				}
+			}
+			resolved = append(resolved, content...)
+		}
+	}
+
+	return resolved
+}
+
+// runProp resolves the formatting change of the run properties.
+func (rr *revisionResolver) runProp(prop *ctypes.RunProperty) {
+	if prop == nil || prop.Change == nil || !rr.filter.matches(prop.Change.Author, prop.Change.Date) {
+		return
+	}
+
+	change := prop.Change
+	prop.Change = nil
+	if rr.accept {
+		return
+	}
+
+	previous := ctypes.RunProperty{}
+	if change.RunProp != nil {
+		previous = *change.RunProp
+	}
+	previous.Ins, previous.Del = prop.Ins, prop.Del
+	previous.MoveFrom, previous.MoveTo = prop.MoveFrom, prop.MoveTo
+	*prop = previous
+}
+
+// table resolves the changes of the table: its properties, inserted and deleted rows and cells, and
+// the content of the cells.
+func (rr *revisionResolver) table(tbl *ctypes.Table) {
+	if change := tbl.TableProp.PrChange; change != nil && rr.filter.matches(change.Author, change.Date) {
+		tbl.TableProp.PrChange = nil
+		if !rr.accept {
+			tbl.TableProp = change.Prop
+			tbl.TableProp.PrChange = nil
+		}
+	}
+
+	rows := tbl.RowContents[:0]
+	for _, rowContent := range tbl.RowContents {
+		row := rowContent.Row
+		if row == nil {
+			rows = append(rows, rowContent)
+			continue
+		}
+		if !rr.row(row) {
+			continue
+		}
+		rows = append(rows, rowContent)
+	}
+	tbl.RowContents = rows
+}
+
+// row resolves the changes of the table row and reports whether the row is kept.
+func (rr *revisionResolver) row(row *ctypes.Row) bool {
+	if prop := row.Property; prop != nil {
+		if prop.Ins != nil && rr.filter.matches(prop.Ins.Author, prop.Ins.Date) {
+			prop.Ins = nil
+			if !rr.keeps(true) {
+				return false
+			}
+		}
+		if prop.Del != nil && rr.filter.matches(prop.Del.Author, prop.Del.Date) {
+			prop.Del = nil
+			if !rr.keeps(false) {
+				return false
+			}
+		}
+		if change := prop.Change; change != nil && rr.filter.matches(change.Author, change.Date) {
+			prop.Change = nil
+			if !rr.accept {
+				previous := change.Prop
+				previous.Ins, previous.Del = prop.Ins, prop.Del
+				*prop = previous
+			}
+		}
+	}
+
+	cells := row.Contents[:0]
+	for _, cellContent := range row.Contents {
+		cell := cellContent.Cell
+		if cell != nil {
+			if !rr.cell(cell) {
+				continue
+			}
+			cell.Contents = rr.cellBlocks(cell.Contents)
+		}
+		cells = append(cells, cellContent)
+	}
+	row.Contents = cells
+
+	return true
+}
+
+// cell resolves the changes of the table cell properties and reports whether the cell is kept.
+func (rr *revisionResolver) cell(cell *ctypes.Cell) bool {
+	prop := cell.Property
+	if prop == nil {
+		return true
+	}
+
+	if prop.CellInsertion != nil && rr.filter.matches(prop.CellInsertion.Author, prop.CellInsertion.Date) {
+		prop.CellInsertion = nil
+		if !rr.keeps(true) {
+			return false
+		}
+	}
+	if prop.CellDeletion != nil && rr.filter.matches(prop.CellDeletion.Author, prop.CellDeletion.Date) {
+		prop.CellDeletion = nil
+		if !rr.keeps(false) {
+			return false
+		}
+	}
+	if change := prop.PrChange; change != nil && rr.filter.matches(change.Author, change.Date) {
+		prop.PrChange = nil
+		if !rr.accept {
+			previous := change.Prop
+			previous.CellInsertion, previous.CellDeletion = prop.CellInsertion, prop.CellDeletion
+			*prop = previous
+		}
+	}
+
+	return true
+}
+
+// restoreDeleted turns the deleted text of a run back into text.
+func restoreDeleted(run *ctypes.Run) {
+	for i, child := range run.Children {
+		if child.DelText != nil {
+			run.Children[i] = ctypes.RunChild{Text: child.DelText}
+		}
+		if child.DelInstrText != nil {
+			run.Children[i] = ctypes.RunChild{InstrText: child.DelInstrText}
+		}
+	}
+}
+
+// dropsMarker reports whether the element marks the start or end of moved content whose move is
+// resolved. The markers go away with the move.
+func (rr *revisionResolver) dropsMarker(raw *ctypes.RawXML) bool {
+	id, _ := raw.Attr("w:id")
+
+	switch raw.Name() {
+	case "w:moveFromRangeStart", "w:moveToRangeStart":
+		author, _ := raw.Attr("w:author")
+		var date *string
+		if value, ok := raw.Attr("w:date"); ok {
+			date = &value
+		}
+		if !rr.filter.matches(author, date) {
+			return false
+		}
+		if rr.moveRanges == nil {
+			rr.moveRanges = map[string]bool{}
+		}
+		rr.moveRanges[id] = true
+		return true
+	case "w:moveFromRangeEnd", "w:moveToRangeEnd":
+		return rr.moveRanges[id]
+	}
+	return false
+}
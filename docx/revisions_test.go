@@ -0,0 +1,263 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_TrackChanges(t *testing.T) {
+	rd := NewRootDoc()
+	kept := rd.AddParagraph("Existing clause.")
+
+	rd.TrackChanges("Jane Doe")
+	assert.True(t, rd.IsTrackingChanges())
+	assert.True(t, rd.Settings.OnOff("w:trackRevisions"))
+
+	p := rd.AddParagraph("New clause.")
+	run := p.AddText(" Added later.")
+
+	assert.True(t, p.inserted())
+	assert.True(t, run.inserted())
+	assert.Equal(t, 1, p.ct.Property.RunProperty.Ins.ID)
+	assert.Equal(t, "Jane Doe", p.ct.Property.RunProperty.Ins.Author)
+
+	children := p.ct.Children
+	assert.Len(t, children, 2)
+	assert.Equal(t, 2, children[0].Ins.ID)
+	assert.Equal(t, 3, children[1].Ins.ID)
+	assert.Same(t, run.ct, children[1].Ins.Children[0].Run)
+
+	// Paragraphs added before tracking are left as they are
+	assert.False(t, kept.inserted())
+	assert.Nil(t, kept.ct.Children[0].Ins)
+
+	rd.StopTrackChanges()
+	assert.False(t, rd.IsTrackingChanges())
+	assert.False(t, rd.Settings.OnOff("w:trackRevisions"))
+	assert.NotNil(t, rd.AddParagraph("Untracked").ct.Children[0].Run)
+}
+
+func TestRun_Remove(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddParagraph("The seller ")
+	old := p.AddText("may")
+	p.AddText(" terminate.")
+
+	rd.TrackChanges("Jane Doe")
+	old.Remove()
+	added := p.AddText("shall")
+
+	output, err := xml.Marshal(p.ct)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^<w:p><w:r><w:t xml:space="preserve">The seller </w:t></w:r>`+
+		`<w:del w:id="1" w:author="Jane Doe" w:date="[^"]+"><w:r><w:delText>may</w:delText></w:r></w:del>`+
+		`<w:r><w:t xml:space="preserve"> terminate.</w:t></w:r>`+
+		`<w:ins w:id="2" w:author="Jane Doe" w:date="[^"]+"><w:r><w:t>shall</w:t></w:r></w:ins></w:p>$`, string(output))
+
+	// Removing inserted text undoes the insertion
+	added.Remove()
+	assert.Len(t, p.ct.Children, 3)
+	assert.Len(t, p.Runs(), 3)
+
+	// Without tracking the run is simply removed
+	rd.StopTrackChanges()
+	p.Runs()[2].Remove()
+	assert.Len(t, p.ct.Children, 2)
+}
+
+func TestParagraph_Remove(t *testing.T) {
+	rd := NewRootDoc()
+	removed := rd.AddParagraph("Obsolete clause.")
+	untracked := rd.AddParagraph("Draft note.")
+
+	untracked.Remove()
+	assert.Len(t, rd.Document.Body.Children, 1)
+
+	rd.TrackChanges("Jane Doe")
+	removed.Remove()
+	assert.Len(t, rd.Document.Body.Children, 1)
+	assert.NotNil(t, removed.ct.Property.RunProperty.Del)
+	assert.Nil(t, removed.ct.Property.PPrChange)
+	assert.NotNil(t, removed.ct.Children[0].Del)
+
+	// A paragraph inserted while tracking changes is removed altogether
+	inserted := rd.AddParagraph("Second thoughts.")
+	inserted.Remove()
+	assert.Len(t, rd.Document.Body.Children, 1)
+}
+
+func TestTrackChanges_Formatting(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddParagraph("")
+	run := p.AddText("Important")
+	run.Italic(true)
+
+	rd.TrackChanges("Jane Doe")
+	run.Bold(true).Color("FF0000")
+	p.Justification(stypes.JustificationCenter)
+
+	change := run.ct.Property.Change
+	assert.NotNil(t, change)
+	assert.Equal(t, "Jane Doe", change.Author)
+	assert.NotNil(t, change.RunProp.Italic)
+	assert.Nil(t, change.RunProp.Bold)
+	assert.Nil(t, change.RunProp.Color)
+	assert.NotNil(t, run.ct.Property.Bold)
+
+	pChange := p.ct.Property.PPrChange
+	assert.NotNil(t, pChange)
+	assert.NotEqual(t, change.ID, pChange.ID)
+	assert.Nil(t, pChange.ParaProp.Justification)
+
+	// Runs inserted while tracking changes do not record formatting changes
+	inserted := p.AddText(" and new").Bold(true)
+	assert.Nil(t, inserted.ct.Property.Change)
+}
+
+func TestRootDoc_NextRevisionID(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddParagraph("")
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Ins: ctypes.NewRunTrackChange(41, "John Smith", nil)})
+
+	rd.TrackChanges("Jane Doe")
+	assert.Equal(t, 42, rd.AddParagraph("").ct.Property.RunProperty.Ins.ID)
+}
+
+func TestRootDoc_NextRevisionID_AllStories(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault).AddEmptyParagraph()
+	header.ct.Children = append(header.ct.Children, ctypes.ParagraphChild{Ins: ctypes.NewRunTrackChange(7, "John Smith", nil)})
+	note := rd.AddParagraph("").AddFootnote("").AddEmptyParagraph()
+	note.ct.Children = append(note.ct.Children, ctypes.ParagraphChild{Del: ctypes.NewRunTrackChange(12, "John Smith", nil)})
+	comment := rd.AddParagraph("").AddComment("John Smith", "JS", "").AddParagraph("")
+	comment.ct.Children = append(comment.ct.Children, ctypes.ParagraphChild{Ins: ctypes.NewRunTrackChange(9, "John Smith", nil)})
+
+	rd.TrackChanges("Jane Doe")
+	assert.Equal(t, 13, rd.AddParagraph("").ct.Property.RunProperty.Ins.ID)
+}
+
+const redline = `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:p><w:r><w:t xml:space="preserve">The seller </w:t></w:r>` +
+	`<w:del w:id="1" w:author="Supplier" w:date="2024-05-01T10:00:00Z"><w:r><w:delText>may</w:delText></w:r></w:del>` +
+	`<w:ins w:id="2" w:author="Buyer" w:date="2024-06-01T10:00:00Z"><w:r><w:t>shall</w:t></w:r></w:ins>` +
+	`<w:r><w:rPr><w:b/><w:rPrChange w:id="3" w:author="Supplier" w:date="2024-05-01T10:00:00Z"><w:rPr><w:i/></w:rPr></w:rPrChange></w:rPr><w:t xml:space="preserve"> terminate.</w:t></w:r></w:p>` +
+	`<w:p><w:pPr><w:jc w:val="center"/><w:rPr><w:ins w:id="4" w:author="Supplier" w:date="2024-05-01T10:00:00Z"/></w:rPr>` +
+	`<w:pPrChange w:id="5" w:author="Supplier" w:date="2024-05-01T10:00:00Z"><w:pPr/></w:pPrChange></w:pPr>` +
+	`<w:ins w:id="6" w:author="Supplier" w:date="2024-05-01T10:00:00Z"><w:r><w:t>New clause.</w:t></w:r></w:ins></w:p>` +
+	`<w:p><w:moveFromRangeStart w:id="7" w:author="Supplier" w:name="move1"/>` +
+	`<w:moveFrom w:id="8" w:author="Supplier"><w:r><w:t>Moved.</w:t></w:r></w:moveFrom>` +
+	`<w:moveFromRangeEnd w:id="7"/><w:r><w:t>Kept.</w:t></w:r></w:p>` +
+	`<w:tbl><w:tr><w:trPr><w:del w:id="9" w:author="Supplier"/></w:trPr><w:tc><w:p><w:r><w:t>Deleted row</w:t></w:r></w:p></w:tc></w:tr>` +
+	`<w:tr><w:tc><w:p><w:r><w:t>Kept row</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`</w:body>`
+
+func loadRedline(t *testing.T) *RootDoc {
+	rd := NewRootDoc()
+	if err := xml.Unmarshal([]byte(redline), rd.Document.Body); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	return rd
+}
+
+func bodyXML(t *testing.T, body *Body) string {
+	var result strings.Builder
+	encoder := xml.NewEncoder(&result)
+	if err := body.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	encoder.Flush()
+	return result.String()
+}
+
+func TestRootDoc_AcceptAllRevisions(t *testing.T) {
+	rd := loadRedline(t)
+	rd.AcceptAllRevisions()
+
+	expected := `<w:body>` +
+		`<w:p><w:r><w:t xml:space="preserve">The seller </w:t></w:r><w:r><w:t>shall</w:t></w:r>` +
+		`<w:r><w:rPr><w:b></w:b></w:rPr><w:t xml:space="preserve"> terminate.</w:t></w:r></w:p>` +
+		`<w:p><w:pPr><w:jc w:val="center"></w:jc></w:pPr><w:r><w:t>New clause.</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Kept.</w:t></w:r></w:p>` +
+		`<w:tbl><w:tblPr></w:tblPr><w:tblGrid></w:tblGrid><w:tr><w:tc><w:p><w:r><w:t>Kept row</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`</w:body>`
+	assert.Equal(t, expected, bodyXML(t, rd.Document.Body))
+}
+
+func TestRootDoc_RejectAllRevisions(t *testing.T) {
+	rd := loadRedline(t)
+	rd.RejectAllRevisions()
+
+	expected := `<w:body>` +
+		`<w:p><w:r><w:t xml:space="preserve">The seller </w:t></w:r><w:r><w:t>may</w:t></w:r>` +
+		`<w:r><w:rPr><w:i></w:i></w:rPr><w:t xml:space="preserve"> terminate.</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Moved.</w:t></w:r><w:r><w:t>Kept.</w:t></w:r></w:p>` +
+		`<w:tbl><w:tblPr></w:tblPr><w:tblGrid></w:tblGrid>` +
+		`<w:tr><w:trPr></w:trPr><w:tc><w:p><w:r><w:t>Deleted row</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>Kept row</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`</w:body>`
+	assert.Equal(t, expected, bodyXML(t, rd.Document.Body))
+}
+
+func TestRootDoc_AcceptRevisions(t *testing.T) {
+	rd := loadRedline(t)
+	rd.AcceptRevisions(RevisionFilter{Author: "Buyer"})
+
+	output := bodyXML(t, rd.Document.Body)
+	assert.Contains(t, output, `<w:r><w:t>shall</w:t></w:r>`)
+	assert.NotContains(t, output, `w:author="Buyer"`)
+	assert.Contains(t, output, `<w:del w:id="1" w:author="Supplier"`)
+
+	rd = loadRedline(t)
+	rd.RejectRevisions(RevisionFilter{Since: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)})
+
+	output = bodyXML(t, rd.Document.Body)
+	assert.NotContains(t, output, `shall`)
+	assert.Contains(t, output, `<w:del w:id="1" w:author="Supplier"`)
+	// Changes without a date are left to review
+	assert.Contains(t, output, `<w:moveFrom w:id="8" w:author="Supplier">`)
+	assert.Contains(t, output, `<w:del w:id="9" w:author="Supplier">`)
+}
+
+func TestRootDoc_Revisions_Hyperlinks(t *testing.T) {
+	input := `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:p><w:hyperlink r:id="rId5"><w:r><w:t xml:space="preserve">the </w:t></w:r>` +
+		`<w:ins w:id="1" w:author="Buyer"><w:r><w:t xml:space="preserve">new </w:t></w:r></w:ins>` +
+		`<w:del w:id="2" w:author="Buyer"><w:r><w:delText xml:space="preserve">old </w:delText></w:r></w:del>` +
+		`<w:r><w:t>terms</w:t></w:r></w:hyperlink>` +
+		`<w:ins w:id="3" w:author="Buyer"><w:hyperlink w:anchor="annex"><w:ins w:id="4" w:author="Buyer"><w:r><w:t>annex</w:t></w:r></w:ins></w:hyperlink></w:ins></w:p>` +
+		`</w:body>`
+
+	rd := NewRootDoc()
+	assert.NoError(t, xml.Unmarshal([]byte(input), rd.Document.Body))
+	rd.AcceptAllRevisions()
+	assert.Equal(t, `<w:body><w:p><w:hyperlink r:id="rId5"><w:r><w:t xml:space="preserve">the </w:t></w:r>`+
+		`<w:r><w:t xml:space="preserve">new </w:t></w:r><w:r><w:t>terms</w:t></w:r></w:hyperlink>`+
+		`<w:hyperlink w:anchor="annex"><w:r><w:t>annex</w:t></w:r></w:hyperlink></w:p></w:body>`, bodyXML(t, rd.Document.Body))
+
+	rd = NewRootDoc()
+	assert.NoError(t, xml.Unmarshal([]byte(input), rd.Document.Body))
+	rd.RejectAllRevisions()
+	assert.Equal(t, `<w:body><w:p><w:hyperlink r:id="rId5"><w:r><w:t xml:space="preserve">the </w:t></w:r>`+
+		`<w:r><w:t xml:space="preserve">old </w:t></w:r><w:r><w:t>terms</w:t></w:r></w:hyperlink></w:p></w:body>`, bodyXML(t, rd.Document.Body))
+}
+
+func TestRootDoc_RejectAllRevisions_Headers(t *testing.T) {
+	rd := NewRootDoc()
+	rd.TrackChanges("Jane Doe")
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	header.AddParagraph("Draft")
+	note := rd.AddParagraph("Text").AddFootnote("Note")
+
+	rd.RejectAllRevisions()
+
+	assert.Empty(t, rd.Document.Body.Children)
+	assert.Empty(t, header.Children)
+	assert.Empty(t, note.Children)
+}
@@ -15,8 +15,10 @@ type RootDoc struct {
 	FileMap     sync.Map      // FileMap is a synchronized map for managing files related to the document.
 	RootRels    Relationships // RootRels represents relationships at the root level.
 	ContentType ContentTypes
-	Document    *Document      // Document is the main document structure.
-	DocStyles   *ctypes.Styles // Document styles
+	Document    *Document         // Document is the main document structure.
+	DocStyles   *ctypes.Styles    // Document styles
+	Settings    *ctypes.Settings  // Document settings (word/settings.xml), nil if the document has none
+	Numbering   *ctypes.Numbering // Numbering definitions (word/numbering.xml), nil if the document has none
 
 	rID        int // rId is used to generate unique relationship IDs.
 	ImageCount uint
@@ -24,6 +26,19 @@ type RootDoc struct {
 	// Headers and footers storage
 	Headers []*Header // Headers stores all headers for automatic serialization
 	Footers []*Footer // Footers stores all footers for automatic serialization
+
+	// Footnotes and endnotes, nil if the document has none
+	Footnotes *Notes // Footnotes holds the footnotes part (word/footnotes.xml)
+	Endnotes  *Notes // Endnotes holds the endnotes part (word/endnotes.xml)
+
+	// Comments, nil if the document has none
+	Comments         *Comments          // Comments holds the comments part (word/comments.xml)
+	CommentsExtended *ctypes.CommentsEx // Replies and resolved state of the comments (word/commentsExtended.xml)
+
+	// Change tracking, see TrackChanges
+	trackChanges   bool
+	revisionAuthor string
+	revisionID     int // last revision ID used
 }
 
 // NewRootDoc creates a new instance of the RootDoc structure.
@@ -74,3 +89,25 @@ func LoadStyles(fileName string, fileBytes []byte) (*ctypes.Styles, error) {
 	styles.RelativePath = fileName
 	return &styles, nil
 }
+
+// Load settings.xml into Settings struct
+func LoadSettings(fileName string, fileBytes []byte) (*ctypes.Settings, error) {
+	settings := ctypes.NewSettings(fileName)
+	err := xml.Unmarshal(fileBytes, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// Load numbering.xml into Numbering struct
+func LoadNumbering(fileName string, fileBytes []byte) (*ctypes.Numbering, error) {
+	numbering := ctypes.NewNumbering(fileName)
+	err := xml.Unmarshal(fileBytes, numbering)
+	if err != nil {
+		return nil, err
+	}
+
+	return numbering, nil
+}
@@ -6,8 +6,9 @@ import (
 )
 
 type Run struct {
-	root *RootDoc    // root is the root document to which this run belongs.
-	ct   *ctypes.Run // ct is the underlying run element from the wml/ctypes package.
+	root   *RootDoc    // root is the root document to which this run belongs.
+	ct     *ctypes.Run // ct is the underlying run element from the wml/ctypes package.
+	parent *Paragraph  // parent is the paragraph that holds the run, nil if unknown.
 }
 
 func newRun(root *RootDoc, ct *ctypes.Run) *Run {
@@ -19,6 +20,7 @@ func (r *Run) getProp() *ctypes.RunProperty {
 	if r.ct.Property == nil {
 		r.ct.Property = &ctypes.RunProperty{}
 	}
+	r.trackPropChange()
 	return r.ct.Property
 }
 
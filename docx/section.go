@@ -0,0 +1,305 @@
+package docx
+
+import (
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// Section is a range of the document body that shares page setup, page numbering and headers and footers.
+//
+// The properties of a section are stored where it ends: in the paragraph that ends it, or in the body
+// for the last section of the document.
+type Section struct {
+	root *RootDoc
+	ct   *ctypes.SectionProp
+}
+
+func newSection(root *RootDoc, ct *ctypes.SectionProp) *Section {
+	return &Section{root: root, ct: ct}
+}
+
+// GetCT returns a pointer to the underlying SectionProp Complex Type.
+func (s *Section) GetCT() *ctypes.SectionProp {
+	return s.ct
+}
+
+// AddSection ends the current section of the document and starts a new one, which initially has the same
+// page setup and headers and footers as the section before it. See Paragraph.SectionBreak.
+//
+// Parameters:
+//   - mark: How the new section starts, e.g. stypes.SectionMarkNextPage.
+//
+// Returns:
+//   - *Section: The new section, which holds all content added to the document from now on.
+//
+// Example:
+//
+//	doc.AddParagraph("Portrait content")
+//	doc.AddSection(stypes.SectionMarkNextPage).Orientation(stypes.PageOrientLandscape)
+//	doc.AddParagraph("Landscape content")
+func (rd *RootDoc) AddSection(mark stypes.SectionMark) *Section {
+	return rd.AddEmptyParagraph().SectionBreak(mark)
+}
+
+// LastSection returns the last section of the document, whose properties are stored in the body.
+// The section properties are created if the document has none.
+func (rd *RootDoc) LastSection() *Section {
+	rd.ensureSectionProperties()
+	return newSection(rd, rd.Document.Body.SectPr)
+}
+
+// Sections returns the sections of the document in document order. The last element is always the
+// section returned by LastSection.
+func (rd *RootDoc) Sections() []*Section {
+	var sections []*Section
+	for _, child := range rd.Document.Body.Children {
+		if sectPr := paragraphSectPr(child.Para); sectPr != nil {
+			sections = append(sections, newSection(rd, sectPr))
+		}
+	}
+	return append(sections, rd.LastSection())
+}
+
+// SectionBreak makes the paragraph the last paragraph of its section and starts a new section after it.
+// The paragraph must be a paragraph of the document body.
+//
+// The new section starts with the page setup, headers and footers of the section that ends here.
+// Section values of the ending section stay valid. Page numbers of the new section continue from it.
+//
+// Parameters:
+//   - mark: How the new section starts, e.g. stypes.SectionMarkNextPage.
+//
+// Returns:
+//   - *Section: The section that starts after the paragraph.
+func (p *Paragraph) SectionBreak(mark stypes.SectionMark) *Section {
+	rd := p.root
+	end := rd.sectionEndAfter(p)
+
+	if sectPr := paragraphSectPr(p); sectPr != nil {
+		following := rd.sectionPropAt(end)
+		following.Type = ctypes.NewGenSingleStrVal(mark)
+		return newSection(rd, following)
+	}
+
+	// The properties stored at the end of the section now describe the part up to p only
+	ended := rd.sectionPropAt(end)
+	following := ended.Clone()
+	following.Type = ctypes.NewGenSingleStrVal(mark)
+	if following.PageNum != nil {
+		// Page numbers continue from the section that ends here
+		following.PageNum.Start = nil
+	}
+
+	p.ensureProp()
+	p.ct.Property.SectPr = ended
+	if end == nil {
+		rd.Document.Body.SectPr = following
+	} else {
+		end.ct.Property.SectPr = following
+	}
+
+	return newSection(rd, following)
+}
+
+// sectionEndAfter returns the first paragraph after p that ends a section, or nil if the section
+// following p is the last section of the document.
+func (rd *RootDoc) sectionEndAfter(p *Paragraph) *Paragraph {
+	found := false
+	for _, child := range rd.Document.Body.Children {
+		if child.Para == p {
+			found = true
+			continue
+		}
+		if found && paragraphSectPr(child.Para) != nil {
+			return child.Para
+		}
+	}
+	return nil
+}
+
+// sectionPropAt returns the properties of the section ended by the paragraph, or of the last section if end is nil.
+func (rd *RootDoc) sectionPropAt(end *Paragraph) *ctypes.SectionProp {
+	if end == nil {
+		rd.ensureSectionProperties()
+		return rd.Document.Body.SectPr
+	}
+	return paragraphSectPr(end)
+}
+
+// paragraphSectPr returns the properties of the section ended by p, or nil if p does not end a section.
+func paragraphSectPr(p *Paragraph) *ctypes.SectionProp {
+	if p == nil || p.ct.Property == nil {
+		return nil
+	}
+	return p.ct.Property.SectPr
+}
+
+// Type sets how the section starts, e.g. on the next page or continuously.
+func (s *Section) Type(mark stypes.SectionMark) *Section {
+	s.ct.Type = ctypes.NewGenSingleStrVal(mark)
+	return s
+}
+
+// PageSize sets the page size of the section, e.g. ctypes.A4. The value is copied.
+func (s *Section) PageSize(size *ctypes.PageSize) *Section {
+	if size == nil {
+		s.ct.PageSize = nil
+		return s
+	}
+
+	pgSz := *size
+	s.ct.PageSize = &pgSz
+	return s
+}
+
+// Orientation sets the page orientation of the section, swapping the page width and height if needed.
+// Sections without a page size get a US Letter page.
+func (s *Section) Orientation(orient stypes.PageOrient) *Section {
+	pgSz := ctypes.PageSize{}
+	if s.ct.PageSize != nil {
+		pgSz = *s.ct.PageSize
+	}
+
+	if pgSz.Width == nil || pgSz.Height == nil {
+		width, height := uint64(12240), uint64(15840)
+		pgSz.Width, pgSz.Height = &width, &height
+	}
+
+	landscape := orient == stypes.PageOrientLandscape
+	if (landscape && *pgSz.Width < *pgSz.Height) || (!landscape && *pgSz.Width > *pgSz.Height) {
+		pgSz.Width, pgSz.Height = pgSz.Height, pgSz.Width
+	}
+
+	pgSz.Orient = orient
+	s.ct.PageSize = &pgSz
+	return s
+}
+
+// Margins sets the page margins of the section, in twips.
+func (s *Section) Margins(margin ctypes.PageMargin) *Section {
+	s.ct.PageMargin = &margin
+	return s
+}
+
+// Columns lays out the text of the section in equal width columns.
+//
+// Parameters:
+//   - num: The number of columns.
+//   - space: The space between columns, in twips.
+func (s *Section) Columns(num int, space int) *Section {
+	s.ct.Cols = &ctypes.Columns{Num: &num, Space: &space}
+	return s
+}
+
+// RestartPageNumbering makes the page numbers of the section start at the given number instead of
+// continuing from the previous section.
+func (s *Section) RestartPageNumbering(start int) *Section {
+	pgNum := ctypes.PageNumbering{}
+	if s.ct.PageNum != nil {
+		pgNum = *s.ct.PageNum
+	}
+	pgNum.Start = &start
+	s.ct.PageNum = &pgNum
+	return s
+}
+
+// PageNumberFormat sets the number format of the page numbers of the section, e.g. lower case roman numerals.
+func (s *Section) PageNumberFormat(format stypes.NumFmt) *Section {
+	pgNum := ctypes.PageNumbering{}
+	if s.ct.PageNum != nil {
+		pgNum = *s.ct.PageNum
+	}
+	pgNum.Format = format
+	s.ct.PageNum = &pgNum
+	return s
+}
+
+// AddHeader adds a header of the given type to the section, replacing the reference to an existing header of that type.
+// The replaced header is removed from the document unless another section still shows it.
+//
+// A first page header also turns on a different first page for the section (see SetDifferentFirstPage), and an
+// even page header turns on different odd and even headers and footers (see RootDoc.SetEvenAndOddHeaders).
+func (s *Section) AddHeader(hdrType stypes.HdrFtrType) *Header {
+	header := s.root.newHeaderPart()
+
+	replaced := ""
+	if ref := s.ct.GetHeaderReference(hdrType); ref != nil {
+		replaced = ref.ID
+	}
+	s.ct.SetHeaderReference(ctypes.HeaderReference{
+		Type: hdrType,
+		ID:   header.rID,
+	})
+	s.root.releaseHdrFtrPart(replaced)
+	s.onHdrFtrAdded(hdrType)
+
+	return header
+}
+
+// AddFooter adds a footer of the given type to the section, replacing the reference to an existing footer of that type.
+// The replaced footer is removed from the document unless another section still shows it.
+//
+// A first page footer also turns on a different first page for the section (see SetDifferentFirstPage), and an
+// even page footer turns on different odd and even headers and footers (see RootDoc.SetEvenAndOddHeaders).
+func (s *Section) AddFooter(ftrType stypes.HdrFtrType) *Footer {
+	footer := s.root.newFooterPart()
+
+	replaced := ""
+	if ref := s.ct.GetFooterReference(ftrType); ref != nil {
+		replaced = ref.ID
+	}
+	s.ct.SetFooterReference(ctypes.FooterReference{
+		Type: ftrType,
+		ID:   footer.rID,
+	})
+	s.root.releaseHdrFtrPart(replaced)
+	s.onHdrFtrAdded(ftrType)
+
+	return footer
+}
+
+func (s *Section) onHdrFtrAdded(hdrFtrType stypes.HdrFtrType) {
+	switch hdrFtrType {
+	case stypes.HdrFtrFirst:
+		s.SetDifferentFirstPage(true)
+	case stypes.HdrFtrEven:
+		s.root.SetEvenAndOddHeaders(true)
+	}
+}
+
+// Header returns the header of the given type referenced by the section, or nil if there is none.
+// Sections without a reference of their own show the header of the previous section.
+func (s *Section) Header(hdrType stypes.HdrFtrType) *Header {
+	ref := s.ct.GetHeaderReference(hdrType)
+	if ref == nil {
+		return nil
+	}
+	return s.root.headerByID(ref.ID)
+}
+
+// Footer returns the footer of the given type referenced by the section, or nil if there is none.
+// Sections without a reference of their own show the footer of the previous section.
+func (s *Section) Footer(ftrType stypes.HdrFtrType) *Footer {
+	ref := s.ct.GetFooterReference(ftrType)
+	if ref == nil {
+		return nil
+	}
+	return s.root.footerByID(ref.ID)
+}
+
+// SetDifferentFirstPage sets whether the first page of the section uses its own header and footer
+// (w:titlePg). When turned on without a first page header, the first page has an empty header,
+// as is common for cover pages.
+func (s *Section) SetDifferentFirstPage(enable bool) *Section {
+	if enable {
+		s.ct.TitlePg = ctypes.OnOffFromBool(true)
+	} else {
+		s.ct.TitlePg = nil
+	}
+	return s
+}
+
+// HasDifferentFirstPage reports whether the first page of the section uses its own header and footer.
+func (s *Section) HasDifferentFirstPage() bool {
+	return s.ct.TitlePg != nil && s.ct.TitlePg.Enabled()
+}
@@ -0,0 +1,183 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_AddSection(t *testing.T) {
+	rd := NewRootDoc()
+	rd.LastSection().PageSize(ctypes.A4)
+
+	rd.AddParagraph("Portrait report")
+	landscape := rd.AddSection(stypes.SectionMarkNextPage).Orientation(stypes.PageOrientLandscape)
+	rd.AddParagraph("Wide table")
+	portrait := rd.AddSection(stypes.SectionMarkNextPage).Orientation(stypes.PageOrientPortrait)
+	rd.AddParagraph("Conclusion")
+
+	sections := rd.Sections()
+	assert.Len(t, sections, 3)
+	assert.Same(t, landscape.GetCT(), sections[1].GetCT())
+	assert.Same(t, portrait.GetCT(), sections[2].GetCT())
+	assert.Same(t, rd.Document.Body.SectPr, sections[2].GetCT())
+
+	first := sections[0].GetCT().PageSize
+	assert.Equal(t, ctypes.A4Width, *first.Width)
+	assert.Equal(t, ctypes.A4Height, *first.Height)
+
+	second := sections[1].GetCT().PageSize
+	assert.Equal(t, stypes.PageOrientLandscape, second.Orient)
+	assert.Equal(t, ctypes.A4Height, *second.Width)
+	assert.Equal(t, ctypes.A4Width, *second.Height)
+	assert.Equal(t, stypes.SectionMarkNextPage, sections[1].GetCT().Type.Val)
+
+	third := sections[2].GetCT().PageSize
+	assert.Equal(t, stypes.PageOrientPortrait, third.Orient)
+	assert.Equal(t, ctypes.A4Width, *third.Width)
+
+	// Only the section that restarts page numbering does so
+	portrait.PageNumberFormat(stypes.NumFmtDecimal).RestartPageNumbering(1)
+	rd.AddSection(stypes.SectionMarkNextPage)
+	assert.Equal(t, 1, *rd.Sections()[2].GetCT().PageNum.Start)
+	assert.Equal(t, stypes.NumFmtDecimal, rd.LastSection().GetCT().PageNum.Format)
+	assert.Nil(t, rd.LastSection().GetCT().PageNum.Start)
+
+	// The page size presets are not modified
+	assert.Equal(t, stypes.PageOrientPortrait, ctypes.A4.Orient)
+	assert.Equal(t, ctypes.A4Width, *ctypes.A4.Width)
+}
+
+func TestParagraph_SectionBreak(t *testing.T) {
+	rd := NewRootDoc()
+	last := rd.LastSection().Columns(2, 720)
+
+	first := rd.AddParagraph("First")
+	rd.AddParagraph("Second")
+
+	next := first.SectionBreak(stypes.SectionMarkNextContinuous)
+	assert.Same(t, rd.Document.Body.SectPr, next.GetCT())
+	assert.Equal(t, stypes.SectionMarkNextContinuous, next.GetCT().Type.Val)
+
+	// The section that ends at the paragraph keeps its properties
+	ended := first.GetCT().Property.SectPr
+	assert.Same(t, last.GetCT(), ended)
+	assert.Nil(t, ended.Type)
+	assert.Equal(t, 2, *ended.Cols.Num)
+
+	// Changing the new section does not affect the one that ended
+	next.Columns(1, 0)
+	assert.Equal(t, 2, *ended.Cols.Num)
+
+	// A second break at the same paragraph changes the type of the following section only
+	again := first.SectionBreak(stypes.SectionMarkNextPage)
+	assert.Same(t, next.GetCT(), again.GetCT())
+	assert.Equal(t, stypes.SectionMarkNextPage, again.GetCT().Type.Val)
+	assert.Len(t, rd.Sections(), 2)
+}
+
+func TestParagraph_SectionBreak_MiddleSection(t *testing.T) {
+	rd := NewRootDoc()
+
+	a := rd.AddParagraph("A")
+	b := rd.AddParagraph("B")
+	rd.AddSection(stypes.SectionMarkNextPage)
+	rd.AddParagraph("C")
+
+	middle := rd.Sections()[0]
+	middle.Columns(2, 0)
+
+	// Splitting the first section at A: A ends the original section, B belongs to the new one
+	split := a.SectionBreak(stypes.SectionMarkNextColumn)
+
+	sections := rd.Sections()
+	assert.Len(t, sections, 3)
+	assert.Same(t, middle.GetCT(), sections[0].GetCT())
+	assert.Same(t, split.GetCT(), sections[1].GetCT())
+	assert.Equal(t, 2, *split.GetCT().Cols.Num)
+	assert.Equal(t, stypes.SectionMarkNextColumn, split.GetCT().Type.Val)
+	assert.Nil(t, paragraphSectPr(b))
+}
+
+func TestSection_PageSetup(t *testing.T) {
+	rd := NewRootDoc()
+	sec := rd.AddSection(stypes.SectionMarkOddPage).
+		Margins(ctypes.PageMargin{Top: internal.ToPtr(720), Bottom: internal.ToPtr(720)}).
+		Columns(3, 360).
+		PageNumberFormat(stypes.NumFmtLowerRoman).
+		RestartPageNumbering(1)
+
+	output, err := xml.Marshal(sec.GetCT())
+	assert.NoError(t, err)
+
+	expected := `<w:sectPr><w:type w:val="oddPage"></w:type><w:pgMar w:top="720" w:bottom="720"></w:pgMar>` +
+		`<w:pgNumType w:fmt="lowerRoman" w:start="1"></w:pgNumType><w:cols w:num="3" w:space="360"></w:cols></w:sectPr>`
+	assert.Equal(t, expected, string(output))
+}
+
+func TestSection_HeadersAndFooters(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("Report")
+
+	cover := rd.LastSection()
+	body := rd.AddSection(stypes.SectionMarkNextPage)
+	assert.Same(t, cover.GetCT(), rd.Sections()[0].GetCT())
+
+	// The new section starts with the headers of the previous one
+	assert.Same(t, rd.Header(stypes.HdrFtrDefault), cover.Header(stypes.HdrFtrDefault))
+	assert.Same(t, cover.Header(stypes.HdrFtrDefault), body.Header(stypes.HdrFtrDefault))
+
+	cover.SetDifferentFirstPage(true)
+	chapter := body.AddHeader(stypes.HdrFtrDefault)
+	footer := body.AddFooter(stypes.HdrFtrFirst)
+
+	assert.Same(t, chapter, body.Header(stypes.HdrFtrDefault))
+	assert.NotSame(t, chapter, cover.Header(stypes.HdrFtrDefault))
+	assert.Same(t, footer, body.Footer(stypes.HdrFtrFirst))
+	assert.Nil(t, cover.Footer(stypes.HdrFtrFirst))
+	assert.True(t, cover.HasDifferentFirstPage())
+	assert.True(t, body.HasDifferentFirstPage())
+	assert.Same(t, body.GetCT(), rd.LastSection().GetCT())
+	assert.Len(t, rd.Headers, 2)
+
+	// A header another section still shows stays when it is replaced
+	shared := cover.Header(stypes.HdrFtrDefault)
+	rd.AddHeader(stypes.HdrFtrDefault)
+	assert.Len(t, rd.Headers, 2)
+	assert.Contains(t, rd.Headers, shared)
+	assert.NotContains(t, rd.Headers, chapter)
+}
+
+func TestSections_OpenedDocument(t *testing.T) {
+	input := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>` +
+		`<w:p><w:r><w:t>Portrait</w:t></w:r></w:p>` +
+		`<w:p><w:pPr><w:sectPr><w:headerReference w:type="default" r:id="rId7"/><w:pgSz w:w="12240" w:h="15840"/></w:sectPr></w:pPr></w:p>` +
+		`<w:p><w:r><w:t>Landscape</w:t></w:r></w:p>` +
+		`<w:sectPr><w:type w:val="nextPage"/><w:pgSz w:w="15840" w:h="12240" w:orient="landscape"/><w:pgNumType w:start="3"/></w:sectPr>` +
+		`</w:body></w:document>`
+
+	rd := NewRootDoc()
+	doc, err := LoadDocXml(rd, "word/document.xml", []byte(input))
+	assert.NoError(t, err)
+	rd.Document = doc
+
+	header, err := LoadHeaderXml(rd, "rId7", "word/header1.xml", []byte(`<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"></w:hdr>`))
+	assert.NoError(t, err)
+	rd.Headers = append(rd.Headers, header)
+
+	sections := rd.Sections()
+	assert.Len(t, sections, 2)
+	assert.Same(t, header, sections[0].Header(stypes.HdrFtrDefault))
+	assert.Equal(t, stypes.PageOrientLandscape, sections[1].GetCT().PageSize.Orient)
+	assert.Equal(t, 3, *sections[1].GetCT().PageNum.Start)
+
+	var result strings.Builder
+	assert.NoError(t, xml.NewEncoder(&result).Encode(rd.Document.Body))
+	assert.Contains(t, result.String(), `<w:pPr><w:sectPr><w:headerReference w:type="default" r:id="rId7"></w:headerReference>`)
+}
@@ -0,0 +1,33 @@
+package docx
+
+import (
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+const settingsFileName = "word/settings.xml"
+
+// settings returns the document settings. Documents without a settings part get a new one,
+// which is registered in the document relationships and content types.
+func (rd *RootDoc) settings() *ctypes.Settings {
+	if rd.Settings != nil {
+		return rd.Settings
+	}
+
+	if content, ok := rd.FileMap.Load(settingsFileName); ok {
+		if settings, err := LoadSettings(settingsFileName, content.([]byte)); err == nil {
+			rd.Settings = settings
+			return rd.Settings
+		}
+	}
+
+	rd.Settings = ctypes.NewSettings(settingsFileName)
+	if rd.Document.relationByType(constants.SourceRelationshipSettings) == nil {
+		rd.Document.addRelation(constants.SourceRelationshipSettings, "settings.xml")
+	}
+	if !rd.ContentType.hasOverride("/" + settingsFileName) {
+		rd.ContentType.AddOverride("/"+settingsFileName, "application/vnd.openxmlformats-officedocument.wordprocessingml.settings+xml")
+	}
+
+	return rd.Settings
+}
@@ -0,0 +1,557 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// SplitBy is where Split cuts a document.
+type SplitBy int
+
+const (
+	SplitByHeading SplitBy = iota // Before each heading of the level of the options or a higher one
+	SplitBySection                // After the end of each section
+)
+
+// SplitOptions are the options of Split.
+type SplitOptions struct {
+	By SplitBy
+
+	// Level is the deepest heading level SplitByHeading cuts at, from 1 for Heading1; 0 stands for 1.
+	// Higher headings start a new document as well: at level 2, both Heading1 and Heading2 do.
+	Level int
+}
+
+// Split cuts the body of the document into parts and returns a document for each of them, in order.
+// When splitting by heading, the content before the first heading becomes the first document.
+//
+// Every document keeps the styles, numbering definitions and settings of this one, and the headers
+// and footers of its sections. Of the pictures, hyperlinks, footnotes, endnotes and comments, it only
+// keeps those its content refers to. The document itself is not changed.
+//
+// Example:
+//
+//	chapters, err := document.Split(docx.SplitOptions{By: docx.SplitByHeading, Level: 1})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for i, chapter := range chapters {
+//		err = chapter.SaveTo(fmt.Sprintf("chapter%d.docx", i+1))
+//	}
+func (rd *RootDoc) Split(opts SplitOptions) ([]*RootDoc, error) {
+	var docs []*RootDoc
+	for _, part := range rd.splitRanges(opts) {
+		doc, err := rd.splitPart(part[0], part[1])
+		if err != nil {
+			return nil, fmt.Errorf("splitting document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// splitRanges returns the start and end of the body children of each part of the document. Parts
+// without paragraphs or tables are left out.
+func (rd *RootDoc) splitRanges(opts SplitOptions) [][2]int {
+	children := rd.Document.Body.Children
+	level := opts.Level
+	if level < 1 {
+		level = 1
+	}
+
+	var cuts []int
+	for i, child := range children {
+		switch opts.By {
+		case SplitByHeading:
+			if child.Para == nil {
+				continue
+			}
+			if heading := rd.outlineLevel(child.Para.ct); heading > 0 && heading <= level {
+				// Bookmarks and other elements just before the heading go along with it
+				cut := i
+				for cut > 0 && childBlock(children[cut-1]) == nil {
+					cut--
+				}
+				cuts = append(cuts, cut)
+			}
+		case SplitBySection:
+			if paragraphSectPr(child.Para) != nil {
+				cuts = append(cuts, i+1)
+			}
+		}
+	}
+
+	var ranges [][2]int
+	start := 0
+	for _, end := range append(cuts, len(children)) {
+		if end <= start {
+			continue
+		}
+		for _, child := range children[start:end] {
+			if childBlock(child) != nil {
+				ranges = append(ranges, [2]int{start, end})
+				break
+			}
+		}
+		start = end
+	}
+	return ranges
+}
+
+// outlineLevel returns the heading level of the paragraph, from 1 for a top level heading, or 0 if the
+// paragraph is not a heading. The outline level of the paragraph comes first, then the one of its style
+// or the styles that style is based on, then the ID of a HeadingN style.
+func (rd *RootDoc) outlineLevel(p *ctypes.Paragraph) int {
+	if p.Property == nil {
+		return 0
+	}
+	if p.Property.OutlineLvl != nil {
+		return outlineHeading(p.Property.OutlineLvl.Val)
+	}
+	if p.Property.Style == nil {
+		return 0
+	}
+
+	seen := map[string]bool{}
+	for id := p.Property.Style.Val; id != "" && !seen[id]; {
+		seen[id] = true
+		style := rd.GetStyleByID(id, stypes.StyleTypeParagraph)
+		if style == nil {
+			break
+		}
+		if style.ParaProp != nil && style.ParaProp.OutlineLvl != nil {
+			return outlineHeading(style.ParaProp.OutlineLvl.Val)
+		}
+		id = ""
+		if style.BasedOn != nil {
+			id = style.BasedOn.Val
+		}
+	}
+
+	if entry := rd.extractHeadingFromParagraph(&Paragraph{root: rd, ct: p}); entry != nil {
+		return entry.Level
+	}
+	return 0
+}
+
+// outlineHeading returns the heading level of an outline level, which counts from 0. Outline level 9 is
+// body text.
+func outlineHeading(outlineLvl int) int {
+	if outlineLvl < 0 || outlineLvl > 8 {
+		return 0
+	}
+	return outlineLvl + 1
+}
+
+// emptyCopy returns a copy of the document with an empty body. The parts are decoded from the XML
+// data the library writes for them.
+func (rd *RootDoc) emptyCopy() (*RootDoc, error) {
+	c := NewRootDoc()
+	c.Path = rd.Path
+	c.ImageCount = rd.ImageCount
+	c.trackChanges = rd.trackChanges
+	c.revisionAuthor = rd.revisionAuthor
+	c.revisionID = rd.revisionID
+	rd.FileMap.Range(func(key, value any) bool {
+		c.FileMap.Store(key, value)
+		return true
+	})
+
+	c.ContentType = ContentTypes{
+		XMLName:  rd.ContentType.XMLName,
+		Default:  append([]Default(nil), rd.ContentType.Default...),
+		Override: append([]Override(nil), rd.ContentType.Override...),
+	}
+	c.RootRels = cloneRelationships(rd.RootRels)
+
+	document := *rd.Document
+	document.Body = NewBody(rd)
+	data, err := marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	if c.Document, err = LoadDocXml(c, rd.Document.relativePath, data); err != nil {
+		return nil, err
+	}
+	c.Document.DocRels = cloneRelationships(rd.Document.DocRels)
+	c.Document.RID = rd.Document.RID
+
+	if data, err = marshal(rd.DocStyles); err != nil {
+		return nil, err
+	}
+	if c.DocStyles, err = LoadStyles(rd.DocStyles.RelativePath, data); err != nil {
+		return nil, err
+	}
+
+	if rd.Settings != nil {
+		if data, err = marshal(rd.Settings); err != nil {
+			return nil, err
+		}
+		if c.Settings, err = LoadSettings(rd.Settings.RelativePath, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if rd.Numbering != nil {
+		if data, err = marshal(rd.Numbering); err != nil {
+			return nil, err
+		}
+		if c.Numbering, err = LoadNumbering(rd.Numbering.RelativePath, data); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, header := range rd.Headers {
+		if data, err = marshal(header); err != nil {
+			return nil, err
+		}
+		copied, err := LoadHeaderXml(c, header.rID, header.filename, data)
+		if err != nil {
+			return nil, err
+		}
+		copied.Rels = cloneRelationships(header.Rels)
+		c.Headers = append(c.Headers, copied)
+	}
+	for _, footer := range rd.Footers {
+		if data, err = marshal(footer); err != nil {
+			return nil, err
+		}
+		copied, err := LoadFooterXml(c, footer.rID, footer.filename, data)
+		if err != nil {
+			return nil, err
+		}
+		copied.Rels = cloneRelationships(footer.Rels)
+		c.Footers = append(c.Footers, copied)
+	}
+
+	if rd.Footnotes != nil {
+		if data, err = marshal(rd.Footnotes); err != nil {
+			return nil, err
+		}
+		if c.Footnotes, err = LoadFootnotesXml(c, rd.Footnotes.filename, data); err != nil {
+			return nil, err
+		}
+		c.Footnotes.Rels = cloneRelationships(rd.Footnotes.Rels)
+	}
+	if rd.Endnotes != nil {
+		if data, err = marshal(rd.Endnotes); err != nil {
+			return nil, err
+		}
+		if c.Endnotes, err = LoadEndnotesXml(c, rd.Endnotes.filename, data); err != nil {
+			return nil, err
+		}
+		c.Endnotes.Rels = cloneRelationships(rd.Endnotes.Rels)
+	}
+
+	if rd.Comments != nil {
+		if data, err = marshal(rd.Comments); err != nil {
+			return nil, err
+		}
+		if c.Comments, err = LoadCommentsXml(c, rd.Comments.filename, data); err != nil {
+			return nil, err
+		}
+		c.Comments.Rels = cloneRelationships(rd.Comments.Rels)
+	}
+	if rd.CommentsExtended != nil {
+		if data, err = marshal(rd.CommentsExtended); err != nil {
+			return nil, err
+		}
+		if c.CommentsExtended, err = LoadCommentsExtended(rd.CommentsExtended.RelativePath, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// cloneRelationships returns a copy of the relationships of a part.
+func cloneRelationships(rels Relationships) Relationships {
+	copied := rels
+	copied.Relationships = make([]*Relationship, 0, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		relCopy := *rel
+		copied.Relationships = append(copied.Relationships, &relCopy)
+	}
+	return copied
+}
+
+// splitPart returns a document with a copy of the body children from start up to end. The copy ends
+// with the section properties in effect at the last of them, and keeps the headers and footers in
+// effect at the first. The notes, comments, headers, footers and relationships that it does not use
+// are left out.
+func (rd *RootDoc) splitPart(start, end int) (*RootDoc, error) {
+	doc, err := rd.emptyCopy()
+	if err != nil {
+		return nil, err
+	}
+
+	children := rd.Document.Body.Children
+	data, err := encodeChildren(children[start:end])
+	if err != nil {
+		return nil, err
+	}
+	body := doc.Document.Body
+	if body.Children, err = decodeChildren(doc, nil, data); err != nil {
+		return nil, err
+	}
+
+	// The section in effect at the last child is the last section of the copy, whose properties are
+	// stored in the body
+	sectPr := rd.Document.Body.SectPr
+	for _, child := range children[end-1:] {
+		if paragraphSectPr(child.Para) != nil {
+			sectPr = paragraphSectPr(child.Para)
+			break
+		}
+	}
+	if sectPr != nil {
+		body.SectPr = sectPr.Clone()
+	}
+	if paragraphSectPr(children[end-1].Para) != nil {
+		for i := len(body.Children) - 1; i >= 0; i-- {
+			if p := body.Children[i].Para; paragraphSectPr(p) != nil {
+				p.ct.Property.SectPr = nil
+				break
+			}
+		}
+	}
+
+	// Sections take the headers and footers they have no reference to from the section before them
+	var before []*ctypes.SectionProp
+	for _, child := range children[:start] {
+		if sectPr := paragraphSectPr(child.Para); sectPr != nil {
+			before = append(before, sectPr)
+		}
+	}
+	first := body.SectPr
+	for _, child := range body.Children {
+		if sectPr := paragraphSectPr(child.Para); sectPr != nil {
+			first = sectPr
+			break
+		}
+	}
+	if first != nil {
+		inheritReferences(first, before)
+	}
+
+	if err := doc.dropUnused(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// dropUnused removes the notes, comments, headers, footers and picture and hyperlink relationships that
+// the body does not refer to.
+func (rd *RootDoc) dropUnused() error {
+	body := rd.Document.Body
+	data, err := encodeChildren(body.Children)
+	if err != nil {
+		return err
+	}
+	if body.SectPr != nil {
+		sectPr, err := xml.Marshal(body.SectPr)
+		if err != nil {
+			return err
+		}
+		data = append(data, sectPr...)
+	}
+
+	rd.keepNotes(rd.Footnotes, referencedIDs(data, "footnoteReference"))
+	rd.keepNotes(rd.Endnotes, referencedIDs(data, "endnoteReference"))
+	rd.keepComments(referencedIDs(data, "commentRangeStart", "commentReference"))
+	if err := rd.keepHeadersAndFooters(scanRelationIDs(data)); err != nil {
+		return err
+	}
+
+	// The relationships of the notes and comments parts that the remaining ones do not use
+	for _, notes := range []*Notes{rd.Footnotes, rd.Endnotes} {
+		if notes == nil {
+			continue
+		}
+		var stories [][]DocumentChild
+		for _, note := range notes.Notes {
+			stories = append(stories, note.Children)
+		}
+		if err := rd.releasePartRelations(&notes.Rels, stories...); err != nil {
+			return err
+		}
+	}
+	if rd.Comments != nil {
+		var stories [][]DocumentChild
+		for _, comment := range rd.Comments.Comments {
+			stories = append(stories, comment.Children)
+		}
+		if err := rd.releasePartRelations(&rd.Comments.Rels, stories...); err != nil {
+			return err
+		}
+	}
+
+	ids := map[string]bool{}
+	for _, rel := range rd.Document.DocRels.Relationships {
+		ids[rel.ID] = true
+	}
+	return rd.releaseRelationIDs(ids)
+}
+
+// releasePartRelations removes the picture and hyperlink relationships of a part that the given content
+// of the part does not refer to, along with the images that no relationship targets anymore.
+func (rd *RootDoc) releasePartRelations(rels *Relationships, stories ...[]DocumentChild) error {
+	var data []byte
+	for _, children := range stories {
+		storyData, err := encodeChildren(children)
+		if err != nil {
+			return err
+		}
+		data = append(data, storyData...)
+	}
+	inUse := scanRelationIDs(data)
+
+	var released []*Relationship
+	kept := rels.Relationships[:0]
+	for _, rel := range rels.Relationships {
+		releasable := rel.Type == constants.SourceRelationshipImage || rel.Type == constants.SourceRelationshipHyperLink
+		if releasable && !inUse[rel.ID] {
+			released = append(released, rel)
+			continue
+		}
+		kept = append(kept, rel)
+	}
+	rels.Relationships = kept
+
+	for _, rel := range released {
+		if rel.Type == constants.SourceRelationshipImage && rel.TargetMode == "" {
+			rd.removeMedia(rel.Target)
+		}
+	}
+	return nil
+}
+
+// inheritReferences adds the header and footer references of the types the section properties lack
+// from the closest of the sections before them that has one.
+func inheritReferences(sectPr *ctypes.SectionProp, before []*ctypes.SectionProp) {
+	headers := map[stypes.HdrFtrType]bool{}
+	for _, ref := range sectPr.HeaderReference {
+		headers[ref.Type] = true
+	}
+	footers := map[stypes.HdrFtrType]bool{}
+	for _, ref := range sectPr.FooterReference {
+		footers[ref.Type] = true
+	}
+
+	for i := len(before) - 1; i >= 0; i-- {
+		for _, ref := range before[i].HeaderReference {
+			if !headers[ref.Type] {
+				headers[ref.Type] = true
+				sectPr.HeaderReference = append(sectPr.HeaderReference, ref)
+			}
+		}
+		for _, ref := range before[i].FooterReference {
+			if !footers[ref.Type] {
+				footers[ref.Type] = true
+				sectPr.FooterReference = append(sectPr.FooterReference, ref)
+			}
+		}
+	}
+}
+
+// keepNotes removes the notes whose IDs are not among those given. Separators are kept.
+func (rd *RootDoc) keepNotes(notes *Notes, ids map[int]bool) {
+	if notes == nil {
+		return
+	}
+	kept := notes.Notes[:0]
+	for _, note := range notes.Notes {
+		if note.Type != nil || ids[note.ID] {
+			kept = append(kept, note)
+		}
+	}
+	notes.Notes = kept
+}
+
+// keepComments removes the comments whose IDs are not among those given, with their replies and
+// resolved state.
+func (rd *RootDoc) keepComments(ids map[int]bool) {
+	if rd.Comments == nil {
+		return
+	}
+	kept := rd.Comments.Comments[:0]
+	for _, comment := range rd.Comments.Comments {
+		if ids[comment.ID] {
+			kept = append(kept, comment)
+			continue
+		}
+		if rd.CommentsExtended != nil {
+			rd.CommentsExtended.Remove(comment.lastParaID())
+		}
+	}
+	rd.Comments.Comments = kept
+}
+
+// keepHeadersAndFooters removes the header and footer parts whose relationship IDs are not among those
+// given, along with the images only they used.
+func (rd *RootDoc) keepHeadersAndFooters(ids map[string]bool) error {
+	var removed []*Relationships
+	headers := rd.Headers[:0]
+	for _, header := range rd.Headers {
+		if ids[header.rID] {
+			headers = append(headers, header)
+			continue
+		}
+		rd.removePart(header.rID, header.filename)
+		removed = append(removed, &header.Rels)
+	}
+	rd.Headers = headers
+
+	footers := rd.Footers[:0]
+	for _, footer := range rd.Footers {
+		if ids[footer.rID] {
+			footers = append(footers, footer)
+			continue
+		}
+		rd.removePart(footer.rID, footer.filename)
+		removed = append(removed, &footer.Rels)
+	}
+	rd.Footers = footers
+
+	for _, rels := range removed {
+		if err := rd.releasePartRelations(rels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referencedIDs returns the values of the w:id attributes of the WordprocessingML elements with the
+// given names in the XML data written by the library.
+func referencedIDs(data []byte, names ...string) map[int]bool {
+	ids := map[int]bool{}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.RawToken()
+		if err != nil {
+			return ids
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Space != "w" {
+			continue
+		}
+		for _, name := range names {
+			if start.Name.Local != name {
+				continue
+			}
+			for _, attr := range start.Attr {
+				if attr.Name.Space == "w" && attr.Name.Local == "id" {
+					if id, err := strconv.Atoi(attr.Value); err == nil {
+						ids[id] = true
+					}
+				}
+			}
+		}
+	}
+}
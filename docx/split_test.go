@@ -0,0 +1,180 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_Split(t *testing.T) {
+	img := testPNG(t, 10, 10)
+
+	rd := NewRootDoc()
+	addTestStyle(rd, stypes.StyleTypeParagraph, "Heading1", "", nil)
+	addTestStyle(rd, stypes.StyleTypeParagraph, "Heading2", "Heading1", nil)
+	rd.AddParagraph("Preface")
+	_, err := rd.AddParagraph("Scope").AddBookmark("scope")
+	assert.NoError(t, err)
+
+	// The bookmark before the heading goes along with it
+	rd.Document.Body.Children = append(rd.Document.Body.Children, DocumentChild{RngMarkup: &ctypes.RngMarkupElem{
+		BookmarkStart: &ctypes.BookmarkStart{ID: 9, Name: "install"},
+	}})
+	first := rd.AddParagraph("Installation")
+	first.Style("Heading1")
+	first.AddFootnote("Note")
+	first.AddComment("Jane Doe", "JD", "Comment").AddReply("John Doe", "JD", "Reply")
+	assert.NoError(t, rd.AddEmptyParagraph().addImageData(img, ".png", "Screen", 0, 0))
+	rd.AddParagraph("Requirements").Style("Heading2")
+
+	second := rd.AddParagraph("Usage")
+	second.Style("Heading1")
+	rd.AddParagraph("Also see ").AddLink("the site", "https://example.com")
+	appendix := rd.AddParagraph("Appendix")
+	appendix.ensureProp()
+	appendix.ct.Property.OutlineLvl = &ctypes.DecimalNum{Val: 0}
+
+	docs, err := rd.Split(SplitOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 4)
+	assert.Equal(t, []string{"Preface", "Scope"}, blockTexts(docs[0]))
+	assert.Equal(t, []string{"Installation[1]", "", "Requirements"}, blockTexts(docs[1]))
+	assert.Equal(t, []string{"Usage", "Also see the site"}, blockTexts(docs[2]))
+	assert.Equal(t, []string{"Appendix"}, blockTexts(docs[3]))
+	assert.Equal(t, "install", docs[1].Document.Body.Children[0].RngMarkup.BookmarkStart.Name)
+
+	// Notes, comments, pictures and hyperlinks stay with the content that refers to them
+	isImage := func(rel *Relationship) bool { return rel.Type == constants.SourceRelationshipImage }
+	hasRelation := func(rd *RootDoc, match func(*Relationship) bool) bool {
+		for _, rel := range rd.Document.DocRels.Relationships {
+			if match(rel) {
+				return true
+			}
+		}
+		return false
+	}
+	isLink := func(rel *Relationship) bool { return rel.Target == "https://example.com" }
+
+	assert.Equal(t, "Note", docs[1].Footnotes.Note(1).Children[0].Para.Text()[1:])
+	assert.Len(t, docs[1].Comments.Comments, 2)
+	assert.Len(t, docs[1].CommentsExtended.Comments, 2)
+	assert.True(t, hasRelation(docs[1], isImage))
+	assert.False(t, hasRelation(docs[1], isLink))
+	_, stored := docs[1].FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+
+	assert.Nil(t, docs[2].Footnotes.Note(1))
+	assert.Empty(t, docs[2].Comments.Comments)
+	assert.Empty(t, docs[2].CommentsExtended.Comments)
+	assert.False(t, hasRelation(docs[2], isImage))
+	assert.True(t, hasRelation(docs[2], isLink))
+	_, stored = docs[2].FileMap.Load("word/media/image1.png")
+	assert.False(t, stored)
+	assert.False(t, docs[2].ContentType.hasOverride("/word/media/image1.png"))
+
+	// Every document keeps the styles
+	for _, doc := range docs {
+		assert.NotNil(t, doc.GetStyleByID("Heading2", stypes.StyleTypeParagraph))
+	}
+
+	// Deeper levels cut at the higher headings as well
+	docs, err = rd.Split(SplitOptions{Level: 2})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 5)
+	assert.Equal(t, []string{"Requirements"}, blockTexts(docs[2]))
+
+	// The document itself is not changed
+	assert.Len(t, rd.Blocks(), 8)
+	assert.Len(t, rd.Comments.Comments, 2)
+	_, stored = rd.FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+}
+
+func TestRootDoc_Split_Section(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("Manual")
+	rd.AddParagraph("Part 1")
+	rd.AddSection(stypes.SectionMarkNextPage)
+	rd.AddParagraph("Part 2")
+	rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("Appendix")
+	rd.AddSection(stypes.SectionMarkNextPage)
+	rd.AddParagraph("Part 3")
+	// The last section takes the header of the one before
+	rd.Document.Body.SectPr.HeaderReference = nil
+
+	docs, err := rd.Split(SplitOptions{By: SplitBySection})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+	assert.Equal(t, []string{"Part 1", ""}, blockTexts(docs[0]))
+	assert.Equal(t, []string{"Part 2", ""}, blockTexts(docs[1]))
+	assert.Equal(t, []string{"Part 3"}, blockTexts(docs[2]))
+
+	for i, header := range []string{"Manual", "Appendix", "Appendix"} {
+		doc := docs[i]
+		assert.Len(t, doc.Sections(), 1)
+		assert.Len(t, doc.Headers, 1)
+		assert.Equal(t, header, doc.Headers[0].Children[0].Para.Text())
+		refs := doc.Document.Body.SectPr.HeaderReference
+		assert.Len(t, refs, 1)
+		assert.Equal(t, doc.Headers[0].rID, refs[0].ID)
+		assert.Len(t, doc.Document.DocRels.Relationships, len(rd.Document.DocRels.Relationships)-1)
+	}
+	assert.Equal(t, stypes.SectionMarkNextPage, docs[1].Document.Body.SectPr.Type.Val)
+}
+
+func TestRootDoc_Split_PartRelationships(t *testing.T) {
+	img := testPNG(t, 10, 10)
+	imageTarget := func(rels *Relationships) string {
+		for _, rel := range rels.Relationships {
+			if rel.Type == constants.SourceRelationshipImage {
+				return rel.Target
+			}
+		}
+		return ""
+	}
+
+	rd := NewRootDoc()
+	manual := rd.AddHeader(stypes.HdrFtrDefault)
+	assert.NoError(t, manual.AddEmptyParagraph().addImageData(img, ".png", "Manual", 0, 0))
+	rd.AddParagraph("Part 1").AddFootnote("See ").AddEmptyParagraph().AddLink("the site", "https://example.com")
+	rd.AddSection(stypes.SectionMarkNextPage)
+	appendix := rd.AddHeader(stypes.HdrFtrDefault)
+	assert.NoError(t, appendix.AddEmptyParagraph().addImageData(img, ".png", "Appendix", 0, 0))
+	note := rd.AddParagraph("Part 2").AddFootnote("Chart")
+	assert.NoError(t, note.AddEmptyParagraph().addImageData(img, ".png", "Chart", 0, 0))
+	manualImage, appendixImage := imageTarget(&manual.Rels), imageTarget(&appendix.Rels)
+	noteImage := imageTarget(&rd.Footnotes.Rels)
+
+	docs, err := rd.Split(SplitOptions{By: SplitBySection})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+
+	// Each document keeps the relationships of its parts and the images they target
+	for i, want := range [][2]string{{manualImage, appendixImage}, {appendixImage, manualImage}} {
+		doc := docs[i]
+		assert.Len(t, doc.Headers, 1)
+		assert.Equal(t, want[0], imageTarget(&doc.Headers[0].Rels))
+		_, stored := doc.FileMap.Load("word/" + want[0])
+		assert.True(t, stored)
+		_, stored = doc.FileMap.Load("word/" + want[1])
+		assert.False(t, stored)
+	}
+
+	assert.Len(t, docs[0].Footnotes.Rels.Relationships, 1)
+	assert.Equal(t, "https://example.com", docs[0].Footnotes.Rels.Relationships[0].Target)
+	_, stored := docs[0].FileMap.Load("word/" + noteImage)
+	assert.False(t, stored)
+
+	assert.Len(t, docs[1].Footnotes.Rels.Relationships, 1)
+	assert.Equal(t, noteImage, imageTarget(&docs[1].Footnotes.Rels))
+	_, stored = docs[1].FileMap.Load("word/" + noteImage)
+	assert.True(t, stored)
+
+	// The document itself is not changed
+	assert.Len(t, rd.Footnotes.Rels.Relationships, 2)
+	_, stored = rd.FileMap.Load("word/" + noteImage)
+	assert.True(t, stored)
+}
@@ -12,7 +12,10 @@ type Table struct {
 	root *RootDoc
 
 	// Table Complex Type
-	ct ctypes.Table
+	ct *ctypes.Table
+
+	// Relationships of the part holding the table; nil for the document
+	rels *Relationships
 }
 
 func (t *Table) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -62,12 +65,13 @@ func (t *Table) Layout(layout stypes.TableLayout) *Table {
 
 // GetCT returns a pointer to the underlying Table Complex Type.
 func (t *Table) GetCT() *ctypes.Table {
-	return &t.ct
+	return t.ct
 }
 
 func NewTable(root *RootDoc) *Table {
 	return &Table{
 		root: root,
+		ct:   ctypes.DefaultTable(),
 	}
 }
 
@@ -96,7 +100,7 @@ func NewTable(root *RootDoc) *Table {
 func (rd *RootDoc) AddTable() *Table {
 	tbl := Table{
 		root: rd,
-		ct:   *ctypes.DefaultTable(),
+		ct:   ctypes.DefaultTable(),
 	}
 
 	rd.Document.Body.Children = append(rd.Document.Body.Children, DocumentChild{
@@ -117,11 +121,12 @@ func (rd *RootDoc) AddTable() *Table {
 func (t *Table) AddRow() *Row {
 	row := Row{
 		root: t.root,
-		ct:   *ctypes.DefaultRow(),
+		ct:   ctypes.DefaultRow(),
+		rels: t.rels,
 	}
 
 	t.ct.RowContents = append(t.ct.RowContents, ctypes.RowContent{
-		Row: &row.ct,
+		Row: row.ct,
 	})
 
 	return &row
@@ -193,18 +198,22 @@ type Row struct {
 	root *RootDoc
 
 	// Row Complex Type
-	ct ctypes.Row
+	ct *ctypes.Row
+
+	// Relationships of the part holding the row; nil for the document
+	rels *Relationships
 }
 
 // Add Cell to row and returns Cell
 func (r *Row) AddCell() *Cell {
 	cell := Cell{
 		root: r.root,
-		ct:   *ctypes.DefaultCell(),
+		ct:   ctypes.DefaultCell(),
+		rels: r.rels,
 	}
 
 	r.ct.Contents = append(r.ct.Contents, ctypes.TRCellContent{
-		Cell: &cell.ct,
+		Cell: cell.ct,
 	})
 
 	return &cell
@@ -216,14 +225,17 @@ type Cell struct {
 	root *RootDoc
 
 	// Cell Complex Type
-	ct ctypes.Cell
+	ct *ctypes.Cell
+
+	// Relationships of the part holding the cell; nil for the document
+	rels *Relationships
 }
 
 // Adds paragraph with text and returns Paragraph
 func (c *Cell) AddParagraph(text string) *Paragraph {
-	p := newParagraph(c.root, paraWithText(text))
+	p := newParagraph(c.root, paraInPart(c.rels), paraWithText(text))
 	tblContent := ctypes.TCBlockContent{
-		Paragraph: &p.ct,
+		Paragraph: p.ct,
 	}
 
 	c.ct.Contents = append(c.ct.Contents, tblContent)
@@ -233,9 +245,9 @@ func (c *Cell) AddParagraph(text string) *Paragraph {
 
 // Add empty paragraph without any text and returns Paragraph
 func (c *Cell) AddEmptyPara() *Paragraph {
-	p := newParagraph(c.root)
+	p := newParagraph(c.root, paraInPart(c.rels))
 	tblContent := ctypes.TCBlockContent{
-		Paragraph: &p.ct,
+		Paragraph: p.ct,
 	}
 
 	c.ct.Contents = append(c.ct.Contents, tblContent)
@@ -243,52 +255,62 @@ func (c *Cell) AddEmptyPara() *Paragraph {
 	return p
 }
 
+// ensureProp makes sure the cell has properties to set, as cells read from a document may have none.
+func (c *Cell) ensureProp() {
+	if c.ct.Property == nil {
+		c.ct.Property = &ctypes.CellProperty{}
+	}
+}
+
 // ColSpan sets the number of columns a cell should span across in a table.
 func (c *Cell) ColSpan(cols int) *Cell {
-	if c.ct.Property != nil {
-		c.ct.Property.GridSpan = &ctypes.DecimalNum{Val: cols}
-	}
+	c.ensureProp()
+	c.ct.Property.GridSpan = &ctypes.DecimalNum{Val: cols}
 	return c
 }
 
 // RowSpan sets the cell to span vertically in a table, indicating it is part of a vertically merged group of cells.
 func (c *Cell) RowSpan() *Cell {
-	if c.ct.Property != nil {
-		vMerge := ctypes.AnnotationVMergeRest
-		c.ct.Property.CellMerge = &ctypes.CellMerge{
-			VMerge: &vMerge,
-		}
+	c.ensureProp()
+	vMerge := ctypes.AnnotationVMergeRest
+	c.ct.Property.CellMerge = &ctypes.CellMerge{
+		VMerge: &vMerge,
 	}
 	return c
 }
 
 // VerticalAlign sets the vertical alignment of a cell based on the provided string: "top", "center", "middle", or "bottom".
 func (c *Cell) VerticalAlign(valign string) *Cell {
-	if c.ct.Property != nil {
-		switch valign {
-		case "top":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcTop)
-		case "center", "middle":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcCenter)
-		case "bottom":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcBottom)
-		}
+	c.ensureProp()
+	switch valign {
+	case "top":
+		c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcTop)
+	case "center", "middle":
+		c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcCenter)
+	case "bottom":
+		c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcBottom)
 	}
 	return c
 }
 
 func (c *Cell) BackgroundColor(color string) *Cell {
+	c.ensureProp()
+	if c.ct.Property.Shading == nil {
+		c.ct.Property.Shading = ctypes.DefaultShading()
+	}
 	c.ct.Property.Shading.Fill = &color
 	return c
 }
 
 func (c *Cell) Width(width int, widthType stypes.TableWidth) *Cell {
+	c.ensureProp()
 	c.ct.Property.Width = ctypes.NewTableWidth(width, widthType)
 	return c
 }
 
 func (c *Cell) Borders(top *ctypes.Border, left *ctypes.Border, bottom *ctypes.Border, right *ctypes.Border,
 	insideH *ctypes.Border, insideV *ctypes.Border, tl2br *ctypes.Border, tr2bl *ctypes.Border) *Cell {
+	c.ensureProp()
 	c.ct.Property.Borders = &ctypes.CellBorders{
 		Top:     top,
 		Left:    left,
@@ -0,0 +1,824 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/common/units"
+	"github.com/mrlijnden/godocx/dml"
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// Template runs the text/template actions written in the text of a document, such as {{.Name}},
+// {{if .Signed}}...{{end}} or {{range .Items}}...{{end}}, against a data value.
+//
+// Actions that print a value take the formatting of the text they are written in. Line feeds and tabs in
+// a printed value become breaks and tabs. Control actions select or repeat content depending on where
+// they are written:
+//   - Within a paragraph, they apply to the text between them.
+//   - In paragraphs of their own, they apply to the paragraphs and tables between them.
+//   - Opening in one paragraph and closing in a later one, they apply to the paragraphs from the first to
+//     the last.
+//   - Opening in one cell of a table row and closing in another cell or a later row, they apply to the
+//     rows from the first to the last. A {{range}} in the first cell with its {{end}} in the last cell
+//     repeats the row for each element.
+//
+// Besides the functions of text/template, a template can call:
+//   - image: {{image .Logo 2 1.5}} inserts a picture, given the path of an image file or the bytes of a
+//     PNG, JPEG or GIF image, and its width and height in inches.
+//   - link: {{link .URL "our website"}} inserts a hyperlink with the given text.
+//
+// Images and hyperlinks can only be inserted in the document body. The template runs over the body,
+// headers and footers.
+type Template struct {
+	root  *RootDoc
+	funcs template.FuncMap
+
+	inserts []templateInsert // images and hyperlinks inserted by template functions
+	inBody  bool             // whether the body is being executed
+}
+
+// templateInsert is an image or a hyperlink inserted by a template function. The function prints a
+// marker that is replaced by the insert once the output is parsed.
+type templateInsert struct {
+	drawing *dml.Drawing
+	link    *ctypes.Hyperlink
+}
+
+const (
+	insertMarkerStart = '\uE000'
+	insertMarkerEnd   = '\uE001'
+)
+
+// templateEscapeFunc is the function that escapes the values printed by a template. Its name cannot
+// clash with those of the functions given to Funcs, which must start with a letter.
+const templateEscapeFunc = "_docxText"
+
+// NewTemplate returns a template that runs the actions written in the document.
+//
+// Example:
+//
+//	tmpl := docx.NewTemplate(document).Funcs(template.FuncMap{"upper": strings.ToUpper})
+//	if err := tmpl.Execute(invoice); err != nil {
+//	    log.Fatal(err)
+//	}
+func NewTemplate(rd *RootDoc) *Template {
+	return &Template{
+		root:  rd,
+		funcs: template.FuncMap{},
+	}
+}
+
+// ExecuteTemplate runs the actions written in the document against data, see Template.
+func (rd *RootDoc) ExecuteTemplate(data any) error {
+	return NewTemplate(rd).Execute(data)
+}
+
+// Funcs adds functions that the actions of the template can call, as text/template.Template.Funcs does.
+func (t *Template) Funcs(funcs template.FuncMap) *Template {
+	for name, fn := range funcs {
+		t.funcs[name] = fn
+	}
+	return t
+}
+
+// Execute runs the actions of the document body, headers and footers against data and replaces their
+// content with the output.
+//
+// Returns:
+//   - error: An error if an action cannot be parsed or executed. The document may be partly executed.
+func (t *Template) Execute(data any) error {
+	body := t.root.Document.Body
+
+	t.inBody = true
+	children, err := t.execute("document", nil, body.Children, data)
+	t.inBody = false
+	if err != nil {
+		return err
+	}
+	body.Children = children
+
+	for _, header := range t.root.Headers {
+		if header.Children, err = t.execute(header.filename, &header.Rels, header.Children, data); err != nil {
+			return err
+		}
+	}
+	for _, footer := range t.root.Footers {
+		if footer.Children, err = t.execute(footer.filename, &footer.Rels, footer.Children, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute turns the block-level elements into the source of a text/template, runs it and parses the
+// output back into block-level elements of the part with the given relationships, nil for the body.
+func (t *Template) execute(name string, rels *Relationships, children []DocumentChild, data any) ([]DocumentChild, error) {
+	forEachParagraph(children, mergeActions)
+
+	w := &templateWriter{}
+	if err := w.blocks(documentBlocks(children)); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(t.builtinFuncs()).Funcs(t.funcs).Parse(rewriteActions(w.src.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	var out strings.Builder
+	out.WriteString(t.bodyStart())
+	if err = tmpl.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	out.WriteString("</w:body>")
+
+	result := NewBody(t.root)
+	if err = xml.Unmarshal([]byte(out.String()), result); err != nil {
+		return nil, fmt.Errorf("template %s: output is not valid: %w", name, err)
+	}
+
+	forEachParagraph(result.Children, func(p *ctypes.Paragraph) {
+		p.Children = t.expandChildren(p.Children, true)
+	})
+	for _, child := range result.Children {
+		switch {
+		case child.Para != nil:
+			child.Para.rels = rels
+		case child.Table != nil:
+			child.Table.rels = rels
+			ensureCellParagraphs(child.Table.ct)
+		}
+	}
+	return result.Children, nil
+}
+
+// bodyStart returns the start of the element that wraps the output, with the namespace declarations of
+// the document.
+func (t *Template) bodyStart() string {
+	namespaces := map[string]string{}
+	for key, value := range docAttrs {
+		if strings.HasPrefix(key, "xmlns:") {
+			namespaces[key] = value
+		}
+	}
+	for _, attr := range t.root.Document.Attr {
+		if strings.HasPrefix(attr.Name.Local, "xmlns:") {
+			namespaces[attr.Name.Local] = attr.Value
+		}
+	}
+
+	keys := make([]string, 0, len(namespaces))
+	for key := range namespaces {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var start strings.Builder
+	start.WriteString("<w:body")
+	for _, key := range keys {
+		fmt.Fprintf(&start, ` %s="%s"`, key, html.EscapeString(namespaces[key]))
+	}
+	start.WriteString(">")
+	return start.String()
+}
+
+func (t *Template) builtinFuncs() template.FuncMap {
+	return template.FuncMap{
+		templateEscapeFunc: escapeTemplateValue,
+		"image":            t.image,
+		"link":             t.link,
+	}
+}
+
+// escapeTemplateValue prints a value as XML text. Missing values print nothing.
+func escapeTemplateValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	var text strings.Builder
+	_ = xml.EscapeText(&text, []byte(fmt.Sprint(value)))
+	return text.String()
+}
+
+func (t *Template) image(src any, width float64, height float64) (string, error) {
+	if !t.inBody {
+		return "", errors.New("images can only be inserted in the document body")
+	}
+
+	var imgBytes []byte
+	var imgExt string
+	switch src := src.(type) {
+	case string:
+		var err error
+		if imgBytes, err = internal.FileToByte(src); err != nil {
+			return "", err
+		}
+		imgExt = filepath.Ext(src)
+	case []byte:
+		imgBytes = src
+		switch http.DetectContentType(src) {
+		case "image/png":
+			imgExt = ".png"
+		case "image/jpeg":
+			imgExt = ".jpeg"
+		case "image/gif":
+			imgExt = ".gif"
+		default:
+			return "", errors.New("image data is not a PNG, JPEG or GIF image")
+		}
+	default:
+		return "", fmt.Errorf("image needs a file path or image data, got %T", src)
+	}
+
+	rID, err := t.root.addImage(nil, imgBytes, imgExt)
+	if err != nil {
+		return "", err
+	}
+
+	inline := newPicInline(rID, t.root.ImageCount, units.Inch(width), units.Inch(height))
+	return t.insert(templateInsert{drawing: &dml.Drawing{Inline: []dml.Inline{inline}}}), nil
+}
+
+func (t *Template) link(url string, text string) (string, error) {
+	if !t.inBody {
+		return "", errors.New("hyperlinks can only be inserted in the document body")
+	}
+
+	link := &ctypes.Hyperlink{
+		ID:  t.root.Document.addLinkRelation(url),
+		Run: &ctypes.Run{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(text)}}},
+	}
+	return t.insert(templateInsert{link: link}), nil
+}
+
+// insert records the insert and returns the marker that stands for it in the output.
+func (t *Template) insert(insert templateInsert) string {
+	t.inserts = append(t.inserts, insert)
+	return string(insertMarkerStart) + strconv.Itoa(len(t.inserts)-1) + string(insertMarkerEnd)
+}
+
+// outputSpecialRe matches the parts of the output text that are not plain text: insert markers, line
+// feeds and tabs.
+var outputSpecialRe = regexp.MustCompile(`\x{E000}(\d+)\x{E001}|\n|\t`)
+
+// expandChildren turns the insert markers, line feeds and tabs of the text of the paragraph content into
+// drawings, hyperlinks, breaks and tabs. Hyperlinks cannot be nested: within a hyperlink, only the text
+// of an inserted hyperlink is kept.
+func (t *Template) expandChildren(children []ctypes.ParagraphChild, allowLinks bool) []ctypes.ParagraphChild {
+	expanded := make([]ctypes.ParagraphChild, 0, len(children))
+	for _, child := range children {
+		switch {
+		case child.Run != nil:
+			expanded = append(expanded, t.expandRun(child.Run, allowLinks)...)
+			continue
+		case child.Link != nil:
+			if child.Link.Run != nil {
+				t.expandRun(child.Link.Run, false)
+			}
+			child.Link.Children = t.expandChildren(child.Link.Children, false)
+		case child.Ins != nil:
+			child.Ins.Children = t.expandChildren(child.Ins.Children, allowLinks)
+		case child.MoveTo != nil:
+			child.MoveTo.Children = t.expandChildren(child.MoveTo.Children, allowLinks)
+		case child.Sdt != nil:
+			child.Sdt.Content = t.expandChildren(child.Sdt.Content, allowLinks)
+		}
+		expanded = append(expanded, child)
+	}
+	return expanded
+}
+
+// expandRun expands the text of the run, see expandChildren. An inserted hyperlink splits the run in
+// two; the hyperlink takes the formatting of the run.
+func (t *Template) expandRun(run *ctypes.Run, allowLinks bool) []ctypes.ParagraphChild {
+	var expanded []ctypes.ParagraphChild
+	children := run.Children
+	current := run
+	current.Children = nil
+
+	for _, child := range children {
+		if child.Text == nil {
+			current.Children = append(current.Children, child)
+			continue
+		}
+
+		text := child.Text.Text
+		last := 0
+		for _, m := range outputSpecialRe.FindAllStringSubmatchIndex(text, -1) {
+			current.Children = appendText(current.Children, text[last:m[0]])
+			last = m[1]
+
+			switch {
+			case text[m[0]] == '\n':
+				current.Children = append(current.Children, ctypes.RunChild{Break: &ctypes.Break{}})
+			case text[m[0]] == '\t':
+				current.Children = append(current.Children, ctypes.RunChild{Tab: &ctypes.Empty{}})
+			default:
+				index, _ := strconv.Atoi(text[m[2]:m[3]])
+				if index >= len(t.inserts) {
+					continue
+				}
+				insert := t.inserts[index]
+				switch {
+				case insert.drawing != nil:
+					current.Children = append(current.Children, ctypes.RunChild{Drawing: insert.drawing})
+				case !allowLinks:
+					current.Children = append(current.Children, insert.link.Run.Children...)
+				default:
+					if len(current.Children) > 0 {
+						expanded = append(expanded, ctypes.ParagraphChild{Run: current})
+					}
+					insert.link.Run.Property = hyperlinkProperty(run.Property)
+					expanded = append(expanded, ctypes.ParagraphChild{Link: insert.link})
+					current = &ctypes.Run{Property: cloneRunProperty(run.Property)}
+				}
+			}
+		}
+		current.Children = appendText(current.Children, text[last:])
+	}
+
+	if len(current.Children) > 0 || len(expanded) == 0 {
+		expanded = append(expanded, ctypes.ParagraphChild{Run: current})
+	}
+	return expanded
+}
+
+// appendText appends a text element unless the text is empty.
+func appendText(children []ctypes.RunChild, text string) []ctypes.RunChild {
+	if text == "" {
+		return children
+	}
+	return append(children, ctypes.RunChild{Text: ctypes.TextFromString(text)})
+}
+
+func cloneRunProperty(prop *ctypes.RunProperty) *ctypes.RunProperty {
+	if prop == nil {
+		return nil
+	}
+	clone := *prop
+	return &clone
+}
+
+// hyperlinkProperty returns the formatting of a hyperlink inserted in a run with the given formatting.
+func hyperlinkProperty(prop *ctypes.RunProperty) *ctypes.RunProperty {
+	linkProp := cloneRunProperty(prop)
+	if linkProp == nil {
+		linkProp = &ctypes.RunProperty{}
+	}
+	linkProp.Style = &ctypes.CTString{Val: constants.HyperLinkStyle}
+	return linkProp
+}
+
+// ensureCellParagraphs adds an empty paragraph to the cells of the table that no longer end with one,
+// as Word requires.
+func ensureCellParagraphs(tbl *ctypes.Table) {
+	for _, rowContent := range tbl.RowContents {
+		if rowContent.Row == nil {
+			continue
+		}
+		for _, cellContent := range rowContent.Row.Contents {
+			cell := cellContent.Cell
+			if cell == nil {
+				continue
+			}
+			for _, block := range cell.Contents {
+				if block.Table != nil {
+					ensureCellParagraphs(block.Table)
+				}
+			}
+			if n := len(cell.Contents); n == 0 || cell.Contents[n-1].Paragraph == nil {
+				cell.Contents = append(cell.Contents, ctypes.TCBlockContent{Paragraph: &ctypes.Paragraph{}})
+			}
+		}
+	}
+}
+
+// actionRe matches a template action.
+var actionRe = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// mergeActions moves each action of the paragraph into a single text element, as Word may split the
+// text of an action across several runs.
+func mergeActions(p *ctypes.Paragraph) {
+	for _, segments := range textStretches(p.Children) {
+		replaceInSegments(segments, func(text string) []textMatch {
+			var matches []textMatch
+			for _, m := range actionRe.FindAllStringIndex(text, -1) {
+				matches = append(matches, textMatch{start: m[0], end: m[1], repl: text[m[0]:m[1]]})
+			}
+			return matches
+		})
+	}
+}
+
+type actionKind int
+
+const (
+	actionValue  actionKind = iota // prints a value
+	actionOutput                   // prints the output of a template
+	actionSilent                   // prints nothing: variables, comments, break and continue
+	actionOpen                     // starts a block: if, range, with, block and define
+	actionElse                     // else and else if
+	actionEnd                      // ends a block
+)
+
+var declarationRe = regexp.MustCompile(`^\$\w*(\s*,\s*\$\w*)?\s*:?=`)
+
+// parseAction splits the action into its trim markers and pipeline and tells what it does.
+func parseAction(action string) (leftTrim, pipeline, rightTrim string, kind actionKind) {
+	pipeline = strings.TrimSuffix(strings.TrimPrefix(action, "{{"), "}}")
+	if strings.HasPrefix(pipeline, "- ") {
+		leftTrim = "- "
+	}
+	if strings.HasSuffix(pipeline, " -") {
+		rightTrim = " -"
+	}
+	pipeline = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(pipeline, leftTrim), rightTrim))
+
+	keyword := pipeline
+	if i := strings.IndexAny(keyword, " \t("); i >= 0 {
+		keyword = keyword[:i]
+	}
+
+	switch {
+	case keyword == "if" || keyword == "range" || keyword == "with" || keyword == "block" || keyword == "define":
+		kind = actionOpen
+	case keyword == "else":
+		kind = actionElse
+	case keyword == "end":
+		kind = actionEnd
+	case keyword == "break" || keyword == "continue":
+		kind = actionSilent
+	case keyword == "template":
+		kind = actionOutput
+	case strings.HasPrefix(pipeline, "/*") || declarationRe.MatchString(pipeline):
+		kind = actionSilent
+	default:
+		kind = actionValue
+	}
+	return leftTrim, pipeline, rightTrim, kind
+}
+
+// rewriteActions unescapes the actions of the template source, which went through the XML encoder, and
+// escapes the values they print.
+func rewriteActions(src string) string {
+	return actionRe.ReplaceAllStringFunc(src, func(action string) string {
+		action = html.UnescapeString(action)
+		leftTrim, pipeline, rightTrim, kind := parseAction(action)
+		if kind != actionValue {
+			return action
+		}
+		return "{{" + leftTrim + templateEscapeFunc + " (" + pipeline + ")" + rightTrim + "}}"
+	})
+}
+
+// templateAction is an action written in the text of a paragraph.
+type templateAction struct {
+	text       *ctypes.Text
+	start, end int // position of the action in the text
+	kind       actionKind
+	cell       *ctypes.Cell // cell that holds the paragraph, when the actions of a row are collected
+
+	// leading tells whether the action comes before any text in its paragraph or row
+	leading bool
+}
+
+func actionSources(actions []templateAction) string {
+	var src strings.Builder
+	for _, action := range actions {
+		src.WriteString(action.text.Text[action.start:action.end])
+	}
+	return src.String()
+}
+
+// scanActions returns the actions of the paragraph. seen tells whether text came before, and is updated.
+func scanActions(p *ctypes.Paragraph, seen *bool) []templateAction {
+	var actions []templateAction
+	for _, segments := range textStretches(p.Children) {
+		for _, segment := range segments {
+			text := segment.text.Text
+			last := 0
+			for _, m := range actionRe.FindAllStringIndex(text, -1) {
+				if strings.TrimSpace(text[last:m[0]]) != "" {
+					*seen = true
+				}
+				last = m[1]
+
+				_, _, _, kind := parseAction(text[m[0]:m[1]])
+				actions = append(actions, templateAction{
+					text:    segment.text,
+					start:   m[0],
+					end:     m[1],
+					kind:    kind,
+					leading: !*seen,
+				})
+				if kind == actionValue || kind == actionOutput {
+					*seen = true
+				}
+			}
+			if strings.TrimSpace(text[last:]) != "" {
+				*seen = true
+			}
+		}
+	}
+	return actions
+}
+
+// onlyControlActions tells whether the paragraph holds nothing but actions that print nothing.
+func onlyControlActions(p *ctypes.Paragraph, actions []templateAction) bool {
+	if len(actions) == 0 {
+		return false
+	}
+	for _, action := range actions {
+		if action.kind == actionValue || action.kind == actionOutput {
+			return false
+		}
+	}
+
+	only := true
+	walkParagraphMarkup(p, nil, func(item markupItem) bool {
+		if item.run == nil {
+			return true
+		}
+		for _, child := range item.run.Children {
+			switch {
+			case child.Text != nil:
+				if strings.TrimSpace(actionRe.ReplaceAllString(child.Text.Text, "")) != "" {
+					only = false
+				}
+			case child.LastRenPgBrk == nil:
+				only = false
+			}
+		}
+		return only
+	})
+	return only
+}
+
+// hoistActions returns the actions whose block does not lie within a single unit, such as a paragraph
+// or a cell, or is not complete: those that go before the content of the units and those that go after.
+func hoistActions(actions []templateAction, unit func(templateAction) any) (before, after []templateAction) {
+	hoisted := make([]bool, len(actions))
+	var stack [][]int
+
+	for i, action := range actions {
+		switch action.kind {
+		case actionOpen:
+			stack = append(stack, []int{i})
+		case actionElse, actionEnd:
+			if len(stack) == 0 {
+				hoisted[i] = true
+				continue
+			}
+			top := len(stack) - 1
+			stack[top] = append(stack[top], i)
+			if action.kind == actionElse {
+				continue
+			}
+
+			block := stack[top]
+			stack = stack[:top]
+			for _, part := range block {
+				if unit(actions[part]) != unit(actions[block[0]]) {
+					for _, part := range block {
+						hoisted[part] = true
+					}
+					break
+				}
+			}
+		}
+	}
+	for _, block := range stack {
+		for _, part := range block {
+			hoisted[part] = true
+		}
+	}
+
+	for i, action := range actions {
+		if !hoisted[i] {
+			continue
+		}
+		if action.kind == actionOpen || (action.kind == actionElse && action.leading) {
+			before = append(before, action)
+		} else {
+			after = append(after, action)
+		}
+	}
+	return before, after
+}
+
+// removeActions removes the actions from their text.
+func removeActions(actions []templateAction) {
+	sorted := append([]templateAction(nil), actions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].start > sorted[j].start
+	})
+	for _, action := range sorted {
+		action.text.Text = action.text.Text[:action.start] + action.text.Text[action.end:]
+	}
+}
+
+// templateBlock is a block-level element of the body, a header, a footer or a table cell.
+type templateBlock struct {
+	para  *ctypes.Paragraph
+	table *ctypes.Table
+	other xml.Marshaler
+}
+
+func documentBlocks(children []DocumentChild) []templateBlock {
+	blocks := make([]templateBlock, 0, len(children))
+	for _, child := range children {
+		switch {
+		case child.Para != nil:
+			blocks = append(blocks, templateBlock{para: child.Para.ct})
+		case child.Table != nil:
+			blocks = append(blocks, templateBlock{table: child.Table.ct})
+		case child.RngMarkup != nil:
+			blocks = append(blocks, templateBlock{other: child.RngMarkup})
+		case child.Sdt != nil:
+			blocks = append(blocks, templateBlock{other: child.Sdt})
+		case child.Raw != nil:
+			blocks = append(blocks, templateBlock{other: child.Raw})
+		}
+	}
+	return blocks
+}
+
+func cellBlocks(contents []ctypes.TCBlockContent) []templateBlock {
+	blocks := make([]templateBlock, 0, len(contents))
+	for _, content := range contents {
+		switch {
+		case content.Paragraph != nil:
+			blocks = append(blocks, templateBlock{para: content.Paragraph})
+		case content.Table != nil:
+			blocks = append(blocks, templateBlock{table: content.Table})
+		case content.RngMarkup != nil:
+			blocks = append(blocks, templateBlock{other: content.RngMarkup})
+		case content.Sdt != nil:
+			blocks = append(blocks, templateBlock{other: content.Sdt})
+		}
+	}
+	return blocks
+}
+
+// templateWriter writes the source of the template: the XML of the content, with the control actions
+// moved around the paragraphs and rows they apply to.
+type templateWriter struct {
+	src strings.Builder
+}
+
+func (w *templateWriter) blocks(blocks []templateBlock) error {
+	for _, block := range blocks {
+		var err error
+		switch {
+		case block.para != nil:
+			err = w.paragraph(block.para)
+		case block.table != nil:
+			err = w.table(block.table)
+		default:
+			err = w.element(block.other)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *templateWriter) paragraph(p *ctypes.Paragraph) error {
+	seen := false
+	actions := scanActions(p, &seen)
+	if onlyControlActions(p, actions) {
+		w.src.WriteString(actionSources(actions))
+		return nil
+	}
+
+	before, after := hoistActions(actions, func(templateAction) any { return nil })
+	beforeSrc, afterSrc := actionSources(before), actionSources(after)
+	removeActions(append(before, after...))
+
+	w.src.WriteString(beforeSrc)
+	if err := w.element(p); err != nil {
+		return err
+	}
+	w.src.WriteString(afterSrc)
+	return nil
+}
+
+func (w *templateWriter) table(tbl *ctypes.Table) error {
+	shell := *tbl
+	shell.RowContents = nil
+	if err := w.start(shell, "</w:tbl>"); err != nil {
+		return err
+	}
+
+	for _, rowContent := range tbl.RowContents {
+		var err error
+		switch {
+		case rowContent.Row != nil:
+			err = w.row(rowContent.Row)
+		case rowContent.RngMarkup != nil:
+			err = w.element(rowContent.RngMarkup)
+		case rowContent.Sdt != nil:
+			err = w.element(rowContent.Sdt)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	w.src.WriteString("</w:tbl>")
+	return nil
+}
+
+// row writes the row, with the actions whose block spans several cells or rows around it.
+func (w *templateWriter) row(row *ctypes.Row) error {
+	seen := false
+	var actions []templateAction
+	for _, cellContent := range row.Contents {
+		if cellContent.Cell == nil {
+			continue
+		}
+		for _, block := range cellContent.Cell.Contents {
+			if block.Paragraph == nil {
+				continue
+			}
+			for _, action := range scanActions(block.Paragraph, &seen) {
+				action.cell = cellContent.Cell
+				actions = append(actions, action)
+			}
+		}
+	}
+
+	before, after := hoistActions(actions, func(action templateAction) any { return action.cell })
+	beforeSrc, afterSrc := actionSources(before), actionSources(after)
+	removeActions(append(before, after...))
+
+	w.src.WriteString(beforeSrc)
+	shell := *row
+	shell.Contents = nil
+	if err := w.start(shell, "</w:tr>"); err != nil {
+		return err
+	}
+
+	for _, cellContent := range row.Contents {
+		switch {
+		case cellContent.Cell != nil:
+			cellShell := *cellContent.Cell
+			cellShell.Contents = nil
+			if err := w.start(cellShell, "</w:tc>"); err != nil {
+				return err
+			}
+			if err := w.blocks(cellBlocks(cellContent.Cell.Contents)); err != nil {
+				return err
+			}
+			w.src.WriteString("</w:tc>")
+		case cellContent.RngMarkup != nil:
+			if err := w.element(cellContent.RngMarkup); err != nil {
+				return err
+			}
+		case cellContent.Sdt != nil:
+			if err := w.element(cellContent.Sdt); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.src.WriteString("</w:tr>")
+	w.src.WriteString(afterSrc)
+	return nil
+}
+
+// start writes the XML of an element without its content, up to the end tag.
+func (w *templateWriter) start(elem xml.Marshaler, endTag string) error {
+	var out strings.Builder
+	e := xml.NewEncoder(&out)
+	if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+	w.src.WriteString(strings.TrimSuffix(out.String(), endTag))
+	return nil
+}
+
+// element writes the XML of the element.
+func (w *templateWriter) element(elem xml.Marshaler) error {
+	e := xml.NewEncoder(&w.src)
+	if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+		return err
+	}
+	return e.Flush()
+}
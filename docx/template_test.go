@@ -0,0 +1,185 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+type invoiceItem struct {
+	Name  string
+	Price string
+}
+
+type invoice struct {
+	Client  string
+	Address string
+	Paid    bool
+	Items   []invoiceItem
+	Notes   []string
+}
+
+var testInvoice = invoice{
+	Client:  "Smith & Sons",
+	Address: "1 Main St\nSpringfield",
+	Items:   []invoiceItem{{"Chairs", "120"}, {"Table", "300"}},
+	Notes:   []string{"Deliver by Friday.", "Call ahead."},
+}
+
+// bodyText returns the text of the paragraphs of the body, tables included, one line per paragraph.
+func bodyText(rd *RootDoc) string {
+	var lines []string
+	forEachParagraph(rd.Document.Body.Children, func(p *ctypes.Paragraph) {
+		var line strings.Builder
+		walkParagraphMarkup(p, nil, func(item markupItem) bool {
+			if item.run != nil {
+				line.WriteString(runText(item.run))
+			}
+			return true
+		})
+		lines = append(lines, line.String())
+	})
+	return strings.Join(lines, "|")
+}
+
+func TestRootDoc_ExecuteTemplate(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	p.AddText("Dear {{.Cli").Bold(true)
+	p.AddText("ent}},")
+	rd.AddParagraph("{{if .Paid}}Paid, thank you.{{else}}Payment due.{{end}}")
+
+	assert.NoError(t, rd.ExecuteTemplate(testInvoice))
+	assert.Equal(t, "Dear Smith & Sons,|Payment due.", bodyText(rd))
+
+	// The value takes the formatting of the run the action starts in
+	run := rd.Document.Body.Children[0].Para.ct.Children[0].Run
+	assert.NotNil(t, run.Property.Bold)
+	assert.Equal(t, "Dear Smith & Sons", run.Children[0].Text.Text)
+}
+
+func TestRootDoc_ExecuteTemplate_Blocks(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Notes:")
+	rd.AddParagraph("{{range .Notes}}")
+	rd.AddParagraph("- {{.}}").Style("ListBullet")
+	rd.AddParagraph("{{end}}")
+	rd.AddParagraph("{{if .Paid}}Paid.")
+	rd.AddParagraph("Thank you.{{end}}")
+	rd.AddParagraph("Address: {{.Address}}")
+
+	assert.NoError(t, rd.ExecuteTemplate(testInvoice))
+	assert.Equal(t, "Notes:|- Deliver by Friday.|- Call ahead.|Address: 1 Main St\nSpringfield", bodyText(rd))
+
+	children := rd.Document.Body.Children
+	assert.Len(t, children, 4)
+	assert.Equal(t, "ListBullet", children[2].Para.ct.Property.Style.Val)
+
+	// Line feeds become breaks
+	address := children[3].Para.ct.Children[0].Run.Children
+	assert.Len(t, address, 3)
+	assert.NotNil(t, address[1].Break)
+}
+
+func TestRootDoc_ExecuteTemplate_TableRows(t *testing.T) {
+	rd := NewRootDoc()
+	tbl := rd.AddTable()
+	header := tbl.AddRow()
+	header.AddCell().AddParagraph("Item")
+	header.AddCell().AddParagraph("Price")
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("{{range .Items}}{{.Name}}")
+	row.AddCell().AddParagraph("{{.Price}}{{end}}")
+	tbl.AddRow().AddCell().AddParagraph("{{if .Paid}}Paid{{end}}")
+
+	assert.NoError(t, rd.ExecuteTemplate(testInvoice))
+	assert.Equal(t, "Item|Price|Chairs|120|Table|300|", bodyText(rd))
+	assert.Len(t, rd.Document.Body.Children[0].Table.ct.RowContents, 4)
+}
+
+func TestRootDoc_ExecuteTemplate_Funcs(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph(`Visit {{link "https://example.com" "our site"}} or call {{upper .Client}}.`)
+	rd.AddParagraph(`{{image .Logo 1 0.5}}`)
+
+	data := map[string]any{
+		"Client": "acme",
+		"Logo":   []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"),
+	}
+	err := NewTemplate(rd).Funcs(template.FuncMap{"upper": strings.ToUpper}).Execute(data)
+	assert.NoError(t, err)
+
+	children := rd.Document.Body.Children[0].Para.ct.Children
+	assert.Len(t, children, 3)
+	assert.Equal(t, "Visit ", children[0].Run.Children[0].Text.Text)
+	assert.Equal(t, "our site", children[1].Link.Run.Children[0].Text.Text)
+	assert.Equal(t, "Hyperlink", children[1].Link.Run.Property.Style.Val)
+	assert.Equal(t, " or call ACME.", children[2].Run.Children[0].Text.Text)
+
+	rel := rd.Document.relationByID(children[1].Link.ID)
+	assert.NotNil(t, rel)
+	assert.Equal(t, "https://example.com", rel.Target)
+
+	picture := rd.Document.Body.Children[1].Para.ct.Children[0].Run.Children
+	assert.Len(t, picture, 1)
+	assert.NotNil(t, picture[0].Drawing)
+	_, stored := rd.FileMap.Load("word/media/image1.png")
+	assert.True(t, stored)
+}
+
+func TestRootDoc_ExecuteTemplate_Header(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	header.AddParagraph("Invoice for {{.Client}}")
+
+	assert.NoError(t, rd.ExecuteTemplate(testInvoice))
+	output, err := xml.Marshal(header.Children[0].Para.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "Invoice for Smith &amp; Sons")
+
+	// Content added after the execution is related to the header
+	header.Children[0].Para.AddLink("site", "https://example.com")
+	assert.Len(t, header.Rels.Relationships, 1)
+
+	// Hyperlinks and images belong to the document body
+	header.AddParagraph(`{{link "https://example.com" "site"}}`)
+	assert.Error(t, rd.ExecuteTemplate(testInvoice))
+}
+
+func TestRootDoc_ExecuteTemplate_Errors(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("{{if .Paid}}Unclosed")
+	assert.Error(t, rd.ExecuteTemplate(testInvoice))
+
+	rd = NewRootDoc()
+	rd.AddParagraph("{{.Missing}}")
+	assert.Error(t, rd.ExecuteTemplate(testInvoice))
+}
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		action   string
+		pipeline string
+		kind     actionKind
+	}{
+		{"{{.Name}}", ".Name", actionValue},
+		{"{{- .Name | upper -}}", ".Name | upper", actionValue},
+		{"{{range $i, $item := .Items}}", "range $i, $item := .Items", actionOpen},
+		{"{{else if .Paid}}", "else if .Paid", actionElse},
+		{"{{ end }}", "end", actionEnd},
+		{"{{$total := 0}}", "$total := 0", actionSilent},
+		{"{{/* note */}}", "/* note */", actionSilent},
+		{`{{template "row" .}}`, `template "row" .`, actionOutput},
+	}
+
+	for _, tt := range tests {
+		_, pipeline, _, kind := parseAction(tt.action)
+		assert.Equal(t, tt.pipeline, pipeline, tt.action)
+		assert.Equal(t, tt.kind, kind, tt.action)
+	}
+}
@@ -0,0 +1,661 @@
+package docx
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// TextOptions sets what the plain text of a document, paragraph or table holds. The zero value suits
+// most uses, such as indexing documents for search.
+type TextOptions struct {
+	IncludeDeleted bool // Include the text of tracked deletions
+	IncludeHidden  bool // Include text formatted as hidden
+	TableGrid      bool // Draw tables as a grid of cells instead of a line of tab-separated cells per row
+	LinkTargets    bool // Follow the text of hyperlinks with their URL, as in "our site (https://example.com)"
+
+	SkipHeadersFooters bool // Leave out the text of headers and footers
+	SkipNotes          bool // Leave out the text of footnotes and endnotes; their reference marks are kept
+}
+
+// Text returns the plain text of the document, see TextWithOptions.
+func (rd *RootDoc) Text() string {
+	return rd.TextWithOptions(TextOptions{})
+}
+
+// TextWithOptions returns the plain text of the document: the text of the headers, the body, the
+// footnotes, the endnotes and the footers, separated by blank lines. Headers and footers that are
+// the same in several sections appear once.
+//
+// Paragraphs end with a line feed; tabs and breaks within them become "\t" and "\n". Numbered and
+// bulleted paragraphs start with their label, such as "1.\t" or "•\t". Footnote and endnote reference
+// marks are written as "[1]" and "[i]", and the text of the notes follows the body in the same form.
+// The cells of a table row are separated by tabs, or drawn as a grid with TableGrid. Deleted and hidden
+// text is left out unless the options ask for it.
+//
+// Example:
+//
+//	text := document.TextWithOptions(docx.TextOptions{IncludeDeleted: true})
+func (rd *RootDoc) TextWithOptions(opts TextOptions) string {
+	w := newTextWriter(rd, opts)
+
+	var parts []string
+	addPart := func(lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		part := strings.Join(lines, "\n")
+		for _, existing := range parts {
+			if existing == part {
+				return
+			}
+		}
+		parts = append(parts, part)
+	}
+
+	if !opts.SkipHeadersFooters {
+		for _, header := range rd.Headers {
+			w.rels = &header.Rels
+			addPart(w.blocks(header.Children))
+		}
+	}
+	w.rels = nil
+	addPart(w.blocks(rd.Document.Body.Children))
+	if !opts.SkipNotes {
+		addPart(w.notes(rd.Footnotes, w.footnotes))
+		addPart(w.notes(rd.Endnotes, w.endnotes))
+	}
+	if !opts.SkipHeadersFooters {
+		for _, footer := range rd.Footers {
+			w.rels = &footer.Rels
+			addPart(w.blocks(footer.Children))
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// Text returns the plain text of the paragraph, see TextWithOptions.
+func (p *Paragraph) Text() string {
+	return p.TextWithOptions(TextOptions{})
+}
+
+// TextWithOptions returns the plain text of the paragraph, without a line feed at the end. It renders
+// the paragraph as RootDoc.TextWithOptions does, so that list labels and note reference marks take
+// their place in the document into account.
+func (p *Paragraph) TextWithOptions(opts TextOptions) string {
+	w := newTextWriter(p.root, opts)
+	w.rels = p.rels
+	w.skipTo(p.ct)
+	return w.paragraph(p.ct)
+}
+
+// Text returns the plain text of the table, see TextWithOptions.
+func (t *Table) Text() string {
+	return t.TextWithOptions(TextOptions{})
+}
+
+// TextWithOptions returns the plain text of the table, one line per row, or a grid with TableGrid. It
+// renders the table as RootDoc.TextWithOptions does.
+func (t *Table) TextWithOptions(opts TextOptions) string {
+	w := newTextWriter(t.root, opts)
+	w.rels = t.rels
+	var first *ctypes.Paragraph
+	forEachTableParagraph(t.ct, func(p *ctypes.Paragraph) {
+		if first == nil {
+			first = p
+		}
+	})
+	if first != nil {
+		w.skipTo(first)
+	}
+	return strings.Join(w.table(t.ct), "\n")
+}
+
+// textWriter renders content as plain text. It keeps the state that depends on the position of the
+// content in the document: the counters of the lists and the reference marks of the notes.
+type textWriter struct {
+	root      *RootDoc
+	opts      TextOptions
+	numbering *ctypes.Numbering
+	rels      *Relationships // Relationships of the part being rendered, nil for the document
+
+	lists     map[int]*listCounter // Counters by abstract numbering definition
+	seenNums  map[int]bool
+	footnotes []noteMark
+	endnotes  []noteMark
+}
+
+// listCounter holds the current number of each level of a list, 0 when the level has not started.
+type listCounter struct {
+	counts  [maxListLevel + 1]int
+	started [maxListLevel + 1]bool
+}
+
+// noteMark is a reference to a footnote or an endnote, with the mark that stands for it in the text.
+type noteMark struct {
+	id   int
+	mark string
+}
+
+func newTextWriter(rd *RootDoc, opts TextOptions) *textWriter {
+	w := &textWriter{
+		root:     rd,
+		opts:     opts,
+		lists:    map[int]*listCounter{},
+		seenNums: map[int]bool{},
+	}
+
+	// Read the numbering of an opened document without registering it as a part of the document
+	if rd != nil {
+		w.numbering = rd.Numbering
+		if w.numbering == nil {
+			if content, ok := rd.FileMap.Load(numberingFileName); ok {
+				w.numbering, _ = LoadNumbering(numberingFileName, content.([]byte))
+			}
+		}
+	}
+	return w
+}
+
+// skipTo renders the body paragraphs that precede target, so that list counters and note marks are
+// where they are at target. It does nothing when target is not in the body.
+func (w *textWriter) skipTo(target *ctypes.Paragraph) {
+	if w.root == nil || w.root.Document == nil || w.root.Document.Body == nil {
+		return
+	}
+
+	var preceding []*ctypes.Paragraph
+	found := false
+	forEachParagraph(w.root.Document.Body.Children, func(p *ctypes.Paragraph) {
+		if p == target {
+			found = true
+		}
+		if !found {
+			preceding = append(preceding, p)
+		}
+	})
+	if !found {
+		return
+	}
+
+	for _, p := range preceding {
+		w.paragraph(p)
+	}
+}
+
+// blocks returns the lines of block-level content.
+func (w *textWriter) blocks(children []DocumentChild) []string {
+	var lines []string
+	for _, child := range children {
+		switch {
+		case child.Para != nil:
+			lines = append(lines, w.paragraph(child.Para.ct))
+		case child.Table != nil:
+			lines = append(lines, w.table(child.Table.ct)...)
+		case child.Sdt != nil:
+			lines = append(lines, w.cellBlocks(child.Sdt.Content)...)
+		}
+	}
+	return lines
+}
+
+// cellBlocks returns the lines of the block-level content of a cell or a content control.
+func (w *textWriter) cellBlocks(blocks []ctypes.TCBlockContent) []string {
+	var lines []string
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			lines = append(lines, w.paragraph(block.Paragraph))
+		case block.Table != nil:
+			lines = append(lines, w.table(block.Table)...)
+		case block.Sdt != nil:
+			lines = append(lines, w.cellBlocks(block.Sdt.Content)...)
+		}
+	}
+	return lines
+}
+
+// table returns the lines of the table.
+func (w *textWriter) table(tbl *ctypes.Table) []string {
+	var rows [][][]string
+	var addRows func(rowContents []ctypes.RowContent)
+	addRows = func(rowContents []ctypes.RowContent) {
+		for _, rowContent := range rowContents {
+			switch {
+			case rowContent.Sdt != nil:
+				addRows(rowContent.Sdt.Content)
+			case rowContent.Row != nil:
+				row := rowContent.Row
+				if row.Property != nil && row.Property.Del != nil && !w.opts.IncludeDeleted {
+					continue
+				}
+				rows = append(rows, w.rowCells(row.Contents))
+			}
+		}
+	}
+	addRows(tbl.RowContents)
+
+	if w.opts.TableGrid {
+		return textGrid(rows)
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.Join(cell, " ")
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+	return lines
+}
+
+// rowCells returns the lines of each cell of a row.
+func (w *textWriter) rowCells(cellContents []ctypes.TRCellContent) [][]string {
+	var cells [][]string
+	for _, cellContent := range cellContents {
+		switch {
+		case cellContent.Sdt != nil:
+			cells = append(cells, w.rowCells(cellContent.Sdt.Content)...)
+		case cellContent.Cell != nil:
+			var lines []string
+			for _, line := range w.cellBlocks(cellContent.Cell.Contents) {
+				lines = append(lines, strings.Split(line, "\n")...)
+			}
+			cells = append(cells, lines)
+		}
+	}
+	return cells
+}
+
+// textGrid draws the cells of the rows as a grid, the columns as wide as their widest line.
+func textGrid(rows [][][]string) []string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			for _, line := range cell {
+				if n := utf8.RuneCountInString(line); n > widths[i] {
+					widths[i] = n
+				}
+			}
+		}
+	}
+
+	var border strings.Builder
+	border.WriteString("+")
+	for _, width := range widths {
+		border.WriteString(strings.Repeat("-", width+2) + "+")
+	}
+
+	lines := []string{border.String()}
+	for _, row := range rows {
+		height := 1
+		for _, cell := range row {
+			if len(cell) > height {
+				height = len(cell)
+			}
+		}
+		for i := 0; i < height; i++ {
+			var line strings.Builder
+			line.WriteString("|")
+			for c, width := range widths {
+				text := ""
+				if c < len(row) && i < len(row[c]) {
+					text = row[c][i]
+				}
+				line.WriteString(" " + text + strings.Repeat(" ", width-utf8.RuneCountInString(text)) + " |")
+			}
+			lines = append(lines, line.String())
+		}
+		lines = append(lines, border.String())
+	}
+	return lines
+}
+
+// paragraph returns the text of the paragraph, starting with its list label.
+func (w *textWriter) paragraph(p *ctypes.Paragraph) string {
+	var text strings.Builder
+	text.WriteString(w.listLabel(p))
+	w.paragraphChildren(&text, p.Children)
+	return text.String()
+}
+
+func (w *textWriter) paragraphChildren(text *strings.Builder, children []ctypes.ParagraphChild) {
+	for _, child := range children {
+		switch {
+		case child.Run != nil:
+			w.run(text, child.Run)
+		case child.Link != nil:
+			start := text.Len()
+			if child.Link.Run != nil {
+				w.run(text, child.Link.Run)
+			}
+			w.paragraphChildren(text, child.Link.Children)
+			if w.opts.LinkTargets {
+				w.linkTarget(text, child.Link, text.String()[start:])
+			}
+		case child.Ins != nil:
+			w.paragraphChildren(text, child.Ins.Children)
+		case child.MoveTo != nil:
+			w.paragraphChildren(text, child.MoveTo.Children)
+		case child.Del != nil && w.opts.IncludeDeleted:
+			w.paragraphChildren(text, child.Del.Children)
+		case child.MoveFrom != nil && w.opts.IncludeDeleted:
+			w.paragraphChildren(text, child.MoveFrom.Children)
+		case child.Sdt != nil:
+			w.paragraphChildren(text, child.Sdt.Content)
+		}
+	}
+}
+
+// linkTarget writes the URL of an external hyperlink after its text, unless the text is the URL.
+func (w *textWriter) linkTarget(text *strings.Builder, link *ctypes.Hyperlink, linkText string) {
+	if link.ID == "" || w.root == nil {
+		return
+	}
+	rel := w.root.partRelationByID(w.rels, link.ID)
+	if rel == nil || rel.Target == linkText {
+		return
+	}
+	text.WriteString(" (" + rel.Target + ")")
+}
+
+func (w *textWriter) run(text *strings.Builder, run *ctypes.Run) {
+	if !w.opts.IncludeHidden && run.Property != nil && run.Property.Vanish != nil && run.Property.Vanish.Enabled() {
+		return
+	}
+
+	for _, child := range run.Children {
+		switch {
+		case child.Text != nil:
+			text.WriteString(child.Text.Text)
+		case child.DelText != nil:
+			text.WriteString(child.DelText.Text)
+		case child.Tab != nil, child.PTab != nil:
+			text.WriteString("\t")
+		case child.Break != nil, child.CarrRtn != nil:
+			text.WriteString("\n")
+		case child.NoBreakHyphen != nil:
+			text.WriteString("-")
+		case child.Sym != nil && child.Sym.Char != nil:
+			if char, err := strconv.ParseUint(*child.Sym.Char, 16, 32); err == nil {
+				text.WriteRune(rune(char))
+			}
+		case child.FootnoteReference != nil:
+			text.WriteString(w.noteMark(&w.footnotes, child.FootnoteReference, strconv.Itoa))
+		case child.EndnoteReference != nil:
+			text.WriteString(w.noteMark(&w.endnotes, child.EndnoteReference, romanNumeral))
+		}
+	}
+}
+
+// noteMark records the reference to a note and returns its mark, numbered in the order of the
+// references. Notes with a custom mark have it in the text that follows the reference.
+func (w *textWriter) noteMark(marks *[]noteMark, ref *ctypes.FtnEdnRef, format func(int) string) string {
+	if ref.CustomMarkFollows != nil && ref.CustomMarkFollows.ToBool() {
+		*marks = append(*marks, noteMark{id: ref.ID, mark: "[*]"})
+		return ""
+	}
+
+	number := 1
+	for _, mark := range *marks {
+		if mark.mark != "[*]" {
+			number++
+		}
+	}
+	mark := "[" + format(number) + "]"
+	*marks = append(*marks, noteMark{id: ref.ID, mark: mark})
+	return mark
+}
+
+// notes returns the lines of the referenced notes, each starting with its mark.
+func (w *textWriter) notes(notes *Notes, marks []noteMark) []string {
+	if notes == nil {
+		return nil
+	}
+
+	w.rels = &notes.Rels
+	var lines []string
+	for _, mark := range marks {
+		note := notes.Note(mark.id)
+		if note == nil {
+			continue
+		}
+		text := strings.TrimSpace(strings.Join(w.blocks(note.Children), "\n"))
+		lines = append(lines, mark.mark+" "+text)
+	}
+	return lines
+}
+
+// listEntry is the place of a paragraph in a list.
+type listEntry struct {
+	numID   int
+	ilvl    int
+	level   *ctypes.Level
+	counter *listCounter
+}
+
+// number returns the number of the paragraph at its level.
+func (item listEntry) number() int {
+	return item.counter.counts[item.ilvl]
+}
+
+// bullet reports whether the level shows a bullet rather than a number.
+func (item listEntry) bullet() bool {
+	return item.level.NumFmt != nil && item.level.NumFmt.Val == stypes.NumFmtBullet
+}
+
+// listEntry counts the paragraph in its list and returns its place in it. It returns false for
+// paragraphs that are not list items.
+func (w *textWriter) listEntry(p *ctypes.Paragraph) (listEntry, bool) {
+	numID, ilvl := w.paragraphNumbering(p)
+	if numID <= 0 || w.numbering == nil || ilvl < 0 || ilvl > maxListLevel {
+		return listEntry{}, false
+	}
+	num := w.numbering.Num(numID)
+	level := w.numbering.Level(numID, ilvl)
+	if num == nil || level == nil {
+		return listEntry{}, false
+	}
+
+	abstractID := -numID
+	if num.AbstractNumID != nil {
+		abstractID = num.AbstractNumID.Val
+	}
+	counter := w.lists[abstractID]
+	if counter == nil {
+		counter = &listCounter{}
+		w.lists[abstractID] = counter
+	}
+
+	// An instance with start overrides restarts the list where it is first used
+	if !w.seenNums[numID] {
+		w.seenNums[numID] = true
+		for _, override := range num.Overrides {
+			if override.StartOverride != nil && override.ILvl >= 0 && override.ILvl <= maxListLevel {
+				counter.started[override.ILvl] = false
+			}
+		}
+	}
+
+	if counter.started[ilvl] {
+		counter.counts[ilvl]++
+	} else {
+		counter.counts[ilvl] = w.levelStart(numID, ilvl)
+		counter.started[ilvl] = true
+	}
+	for deeper := ilvl + 1; deeper <= maxListLevel; deeper++ {
+		counter.started[deeper] = false
+	}
+
+	return listEntry{numID: numID, ilvl: ilvl, level: level, counter: counter}, true
+}
+
+// listLabel returns the number or bullet of a list paragraph followed by its suffix, and counts the
+// paragraph in its list. It returns an empty string for other paragraphs.
+func (w *textWriter) listLabel(p *ctypes.Paragraph) string {
+	item, ok := w.listEntry(p)
+	if !ok {
+		return ""
+	}
+	numID, ilvl, level, counter := item.numID, item.ilvl, item.level, item.counter
+
+	if level.Text == nil {
+		return ""
+	}
+	label := level.Text.Val
+	for l := 0; l <= ilvl; l++ {
+		placeholder := "%" + strconv.Itoa(l+1)
+		if !strings.Contains(label, placeholder) {
+			continue
+		}
+		count := counter.counts[l]
+		if !counter.started[l] {
+			count = w.levelStart(numID, l)
+		}
+		format := stypes.NumFmtDecimal
+		if levelDef := w.numbering.Level(numID, l); levelDef != nil && levelDef.NumFmt != nil &&
+			(level.IsLgl == nil || !level.IsLgl.Enabled()) {
+			format = levelDef.NumFmt.Val
+		}
+		label = strings.ReplaceAll(label, placeholder, formatListNumber(count, format))
+	}
+
+	if item.bullet() {
+		label = bulletText(label)
+	}
+
+	suffix := "\t"
+	if level.Suffix != nil {
+		switch level.Suffix.Val {
+		case stypes.LevelSuffixSpace:
+			suffix = " "
+		case stypes.LevelSuffixNothing:
+			suffix = ""
+		}
+	}
+	return label + suffix
+}
+
+// paragraphNumbering returns the numbering instance and level of the paragraph, set on the paragraph
+// or on its style.
+func (w *textWriter) paragraphNumbering(p *ctypes.Paragraph) (numID int, ilvl int) {
+	if p.Property == nil {
+		return 0, 0
+	}
+
+	numPr := p.Property.NumProp
+	if numPr == nil && p.Property.Style != nil && w.root != nil {
+		if style := w.root.GetStyleByID(p.Property.Style.Val, stypes.StyleTypeParagraph); style != nil && style.ParaProp != nil {
+			numPr = style.ParaProp.NumProp
+		}
+	}
+	if numPr == nil || numPr.NumID == nil {
+		return 0, 0
+	}
+	if numPr.ILvl != nil {
+		ilvl = numPr.ILvl.Val
+	}
+	return numPr.NumID.Val, ilvl
+}
+
+// levelStart returns the first number of a level of a numbering instance.
+func (w *textWriter) levelStart(numID int, ilvl int) int {
+	if num := w.numbering.Num(numID); num != nil {
+		if override := num.Override(ilvl); override != nil && override.StartOverride != nil {
+			return override.StartOverride.Val
+		}
+	}
+	if level := w.numbering.Level(numID, ilvl); level != nil && level.Start != nil {
+		return level.Start.Val
+	}
+	return 0
+}
+
+// formatListNumber formats a list number. Formats without a plain text form are written as decimals.
+func formatListNumber(n int, format stypes.NumFmt) string {
+	switch format {
+	case stypes.NumFmtNone, stypes.NumFmtBullet:
+		return ""
+	case stypes.NumFmtLowerRoman:
+		return romanNumeral(n)
+	case stypes.NumFmtUpperRoman:
+		return strings.ToUpper(romanNumeral(n))
+	case stypes.NumFmtLowerLetter:
+		return letterNumber(n)
+	case stypes.NumFmtUpperLetter:
+		return strings.ToUpper(letterNumber(n))
+	case stypes.NumFmtDecimalZero:
+		if n >= 0 && n < 10 {
+			return "0" + strconv.Itoa(n)
+		}
+	case stypes.NumFmtOrdinal:
+		return strconv.Itoa(n) + ordinalSuffix(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// romanNumeral returns the number in lower case roman numerals, or as a decimal when it has none.
+func romanNumeral(n int) string {
+	if n <= 0 || n >= 4000 {
+		return strconv.Itoa(n)
+	}
+
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"m", "cm", "d", "cd", "c", "xc", "l", "xl", "x", "ix", "v", "iv", "i"}
+	var roman strings.Builder
+	for i, value := range values {
+		for n >= value {
+			roman.WriteString(symbols[i])
+			n -= value
+		}
+	}
+	return roman.String()
+}
+
+// letterNumber returns the number as Word writes it with letters: a to z, then aa to zz, and so on.
+func letterNumber(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	letter := string(rune('a' + (n-1)%26))
+	return strings.Repeat(letter, (n-1)/26+1)
+}
+
+func ordinalSuffix(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return "th"
+	case n%10 == 1:
+		return "st"
+	case n%10 == 2:
+		return "nd"
+	case n%10 == 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// bulletText replaces the characters of symbol fonts, such as the bullet of the Symbol font, with a
+// bullet.
+func bulletText(label string) string {
+	var text strings.Builder
+	for _, r := range label {
+		if r >= 0xF000 && r <= 0xF0FF {
+			r = '•'
+		}
+		text.WriteRune(r)
+	}
+	return text.String()
+}
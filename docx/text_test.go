@@ -0,0 +1,124 @@
+package docx
+
+import (
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_Text(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("ACME Ltd.")
+	rd.AddFooter(stypes.HdrFtrDefault).AddParagraph("Confidential")
+
+	p := rd.AddParagraph("Name:")
+	p.AddText("\tJane").AddBreak(nil)
+	p.AddText("Visit ")
+	p.AddLink("our site", "https://example.com")
+	p.AddFootnote("See the terms.")
+
+	steps := rd.AddNumberedList()
+	steps.AddItem("Unpack", 0)
+	steps.AddItem("Check the parts", 1)
+	steps.AddItem("Assemble", 0)
+	rd.AddBulletList().AddItem("Keep the box", 0)
+
+	row := rd.AddTable().AddRow()
+	row.AddCell().AddParagraph("Item")
+	row.AddCell().AddParagraph("Price")
+
+	rd.AddParagraph("The end").AddEndnote("Printed in 2024.")
+
+	expected := "ACME Ltd.\n\n" +
+		"Name:\tJane\nVisit our site[1]\n" +
+		"1.\tUnpack\n" +
+		"a.\tCheck the parts\n" +
+		"2.\tAssemble\n" +
+		"•\tKeep the box\n" +
+		"Item\tPrice\n" +
+		"The end[i]\n\n" +
+		"[1] See the terms.\n\n" +
+		"[i] Printed in 2024.\n\n" +
+		"Confidential\n"
+	assert.Equal(t, expected, rd.Text())
+
+	text := rd.TextWithOptions(TextOptions{SkipHeadersFooters: true, SkipNotes: true, LinkTargets: true})
+	assert.Contains(t, text, "Visit our site (https://example.com)[1]\n")
+	assert.NotContains(t, text, "ACME")
+	assert.NotContains(t, text, "See the terms.")
+}
+
+func TestRootDoc_TextWithOptions_PartLinks(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("Home: ")
+	header.AddLink("ACME", "https://acme.example")
+	note := rd.AddParagraph("Terms").AddFootnote("").AddParagraph("See ")
+	note.AddLink("the terms", "https://acme.example/terms")
+
+	text := rd.TextWithOptions(TextOptions{LinkTargets: true})
+	assert.Contains(t, text, "Home: ACME (https://acme.example)\n")
+	assert.Contains(t, text, "See the terms (https://acme.example/terms)\n")
+	assert.Equal(t, "Home: ACME (https://acme.example)", header.TextWithOptions(TextOptions{LinkTargets: true}))
+}
+
+func TestRootDoc_TextWithOptions_Revisions(t *testing.T) {
+	rd := NewRootDoc()
+	p := rd.AddEmptyParagraph()
+	p.AddText("Shown ")
+	p.AddText("secret ").HideText(true)
+	old := p.AddText("old")
+
+	rd.TrackChanges("Reviewer")
+	old.Remove()
+	p.AddText("new")
+
+	assert.Equal(t, "Shown new\n", rd.Text())
+	assert.Equal(t, "Shown secret oldnew\n", rd.TextWithOptions(TextOptions{IncludeDeleted: true, IncludeHidden: true}))
+}
+
+func TestParagraph_Text(t *testing.T) {
+	rd := NewRootDoc()
+	list := rd.AddNumberedList()
+	list.AddItem("First", 0)
+	second := list.AddItem("Second", 0)
+	rd.AddParagraph("Note").AddFootnote("A note.")
+	third := rd.AddParagraph("More")
+	third.AddFootnote("Another note.")
+
+	// List labels and note marks depend on what precedes the paragraph
+	assert.Equal(t, "2.\tSecond", second.Text())
+	assert.Equal(t, "More[2]", third.Text())
+}
+
+func TestTable_Text(t *testing.T) {
+	rd := NewRootDoc()
+	tbl := rd.AddTable()
+	header := tbl.AddRow()
+	header.AddCell().AddParagraph("Item")
+	header.AddCell().AddParagraph("Price")
+	row := tbl.AddRow()
+	cell := row.AddCell()
+	cell.AddParagraph("Chairs")
+	cell.AddParagraph("(set of 4)")
+	row.AddCell().AddParagraph("120")
+
+	assert.Equal(t, "Item\tPrice\nChairs (set of 4)\t120", tbl.Text())
+
+	expected := "+------------+-------+\n" +
+		"| Item       | Price |\n" +
+		"+------------+-------+\n" +
+		"| Chairs     | 120   |\n" +
+		"| (set of 4) |       |\n" +
+		"+------------+-------+"
+	assert.Equal(t, expected, tbl.TextWithOptions(TextOptions{TableGrid: true}))
+}
+
+func TestFormatListNumber(t *testing.T) {
+	assert.Equal(t, "xiv", formatListNumber(14, stypes.NumFmtLowerRoman))
+	assert.Equal(t, "MCMXC", formatListNumber(1990, stypes.NumFmtUpperRoman))
+	assert.Equal(t, "bb", formatListNumber(28, stypes.NumFmtLowerLetter))
+	assert.Equal(t, "07", formatListNumber(7, stypes.NumFmtDecimalZero))
+	assert.Equal(t, "22nd", formatListNumber(22, stypes.NumFmtOrdinal))
+	assert.Equal(t, "", formatListNumber(3, stypes.NumFmtNone))
+}
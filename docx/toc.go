@@ -4,9 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mrlijnden/godocx/internal"
 	"github.com/mrlijnden/godocx/wml/ctypes"
+	"github.com/mrlijnden/godocx/wml/stypes"
 )
 
+// defaultTextWidth is the width between the margins of a Letter page with 1 inch margins, in twips.
+const defaultTextWidth = 9350
+
 // TOC represents a Table of Contents in a document
 type TOC struct {
 	Title              string
@@ -16,23 +21,37 @@ type TOC struct {
 	IncludePageNumbers bool
 	Indentation        int
 	root               *RootDoc
+
+	paragraphs []*Paragraph // paragraphs of the table in the document body
 }
 
 // TOCEntry represents a single entry in the table of contents
 type TOCEntry struct {
-	Text  string
-	Level int
+	Text     string
+	Level    int
+	Bookmark string // name of the _Toc bookmark on the heading, empty for headings outside the table
+}
+
+// tocHeading is a heading paragraph of the document body.
+type tocHeading struct {
+	para  *Paragraph
+	entry TOCEntry
 }
 
 /*
 	 	AddTableOfContents creates a new Table of Contents in the document
 	 	This follows the project's simple, direct API pattern
+
+		The table is a Word TOC field: its entries link to the headings and Word refreshes the page
+		numbers when the document is opened. The entries use the TOC1..TOC9 paragraph styles, which
+		are added to the document when missing.
+
 		Default values:
 		Title: "Table of Contents"
 		MaxLevel: 3
 		MinLevel: 1
 		IncludePageNumbers: true
-		Indentation: 20
+		Indentation: 220 (indentation of each level in the TOC styles, in twips)
 */
 func (rd *RootDoc) AddTableOfContents(title string, maxLevel int, minLevel int, includePageNumbers bool, indentation int) *TOC {
 	toc := &TOC{
@@ -44,69 +63,311 @@ func (rd *RootDoc) AddTableOfContents(title string, maxLevel int, minLevel int,
 		root:               rd,
 	}
 
-	// Get all headings
-	headings, err := rd.getHeadingStructure()
-	if err == nil {
-		toc.Entries = headings
-	}
-
-	// Create TOC content paragraphs
-	tocParagraphs := rd.createTOCContentParagraphs(toc)
-
-	// Insert at the beginning
-	rd.Document.Body.Children = append(tocParagraphs, rd.Document.Body.Children...)
-
+	toc.Update()
 	return toc
 }
 
 // SetTitle sets the title of the TOC
 func (toc *TOC) SetTitle(title string) *TOC {
 	toc.Title = title
-	return toc
+	return toc.Update()
 }
 
 // SetMaxLevel sets the maximum heading level to include
 func (toc *TOC) SetMaxLevel(level int) *TOC {
 	toc.MaxLevel = level
-	return toc
+	return toc.Update()
 }
 
 // SetMinLevel sets the minimum heading level to include
 func (toc *TOC) SetMinLevel(level int) *TOC {
 	toc.MinLevel = level
-	return toc
+	return toc.Update()
 }
 
 // SetIncludePageNumbers sets whether to include page numbers
 func (toc *TOC) SetIncludePageNumbers(include bool) *TOC {
 	toc.IncludePageNumbers = include
-	return toc
+	return toc.Update()
 }
 
 // SetIndentation sets the indentation for the TOC
 func (toc *TOC) SetIndentation(indent int) *TOC {
 	toc.Indentation = indent
+	return toc.Update()
+}
+
+// Update rebuilds the table from the headings of the document, e.g. after headings were added.
+// The table keeps its position; a new table is inserted at the beginning of the document.
+func (toc *TOC) Update() *TOC {
+	rd := toc.root
+	minLevel, maxLevel := toc.levels()
+
+	toc.Entries = nil
+	for _, heading := range rd.headings() {
+		entry := heading.entry
+		if entry.Level >= minLevel && entry.Level <= maxLevel {
+			entry.Bookmark = heading.para.tocBookmark()
+		}
+		toc.Entries = append(toc.Entries, entry)
+	}
+
+	rd.ensureTOCStyles(maxLevel, toc.Indentation)
+	rd.settings().SetOnOff("w:updateFields", true)
+
+	paragraphs := toc.createParagraphs()
+	children := make([]DocumentChild, len(paragraphs))
+	for i, p := range paragraphs {
+		children[i] = DocumentChild{Para: p}
+	}
+
+	body := rd.Document.Body
+	pos := 0
+	if len(toc.paragraphs) > 0 {
+		pos = -1
+		old := map[*Paragraph]bool{}
+		for _, p := range toc.paragraphs {
+			old[p] = true
+		}
+		kept := body.Children[:0]
+		for _, child := range body.Children {
+			if child.Para != nil && old[child.Para] {
+				if pos < 0 {
+					pos = len(kept)
+				}
+				continue
+			}
+			kept = append(kept, child)
+		}
+		body.Children = kept
+		if pos < 0 {
+			pos = 0
+		}
+	}
+	body.insertChildren(pos, children...)
+
+	toc.paragraphs = paragraphs
 	return toc
 }
 
-// getHeadingStructure retrieves all headings from the document
-func (rd *RootDoc) getHeadingStructure() ([]TOCEntry, error) {
-	var headings []TOCEntry
+// levels returns the range of heading levels of the table, within the levels supported by Word.
+func (toc *TOC) levels() (int, int) {
+	minLevel, maxLevel := toc.MinLevel, toc.MaxLevel
+	if minLevel < 1 {
+		minLevel = 1
+	}
+	if maxLevel > 9 {
+		maxLevel = 9
+	}
+	if maxLevel < minLevel {
+		maxLevel = minLevel
+	}
+	return minLevel, maxLevel
+}
+
+// instruction returns the TOC field instruction: outline levels, hyperlinked entries, no page numbers
+// in web layout and paragraphs with an outline level.
+func (toc *TOC) instruction() string {
+	minLevel, maxLevel := toc.levels()
+	instr := fmt.Sprintf(`TOC \o "%d-%d" \h \z \u`, minLevel, maxLevel)
+	if !toc.IncludePageNumbers {
+		instr += ` \n`
+	}
+	return instr
+}
+
+// createParagraphs creates the title and the entries of the table. The TOC field starts in the first
+// entry and ends in a paragraph of its own.
+func (toc *TOC) createParagraphs() []*Paragraph {
+	rd := toc.root
+	var paragraphs []*Paragraph
+
+	if toc.Title != "" {
+		title := newParagraph(rd)
+		if rd.GetStyleByID("TOCHeading", stypes.StyleTypeParagraph) != nil {
+			title.Style("TOCHeading")
+			title.AddText(toc.Title)
+		} else {
+			title.AddText(toc.Title).Bold(true).Size(18)
+		}
+		paragraphs = append(paragraphs, title)
+	}
+
+	start := []*ctypes.Run{
+		fieldCharRun(stypes.FieldCharTypeBegin),
+		instrRun(toc.instruction()),
+		fieldCharRun(stypes.FieldCharTypeSeparate),
+	}
+
+	for _, entry := range toc.Entries {
+		if entry.Bookmark == "" {
+			continue
+		}
+
+		p := newParagraph(rd)
+		p.Style(fmt.Sprintf("TOC%d", entry.Level))
+		for _, run := range start {
+			p.appendRun(run)
+		}
+		start = nil
+
+		link := &ctypes.Hyperlink{
+			Anchor:  internal.ToPtr(entry.Bookmark),
+			History: internal.ToPtr(stypes.OnOffOne),
+		}
+		link.Children = append(link.Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+			Children: []ctypes.RunChild{{Text: ctypes.TextFromString(entry.Text)}},
+		}})
+		if toc.IncludePageNumbers {
+			link.Children = append(link.Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+				Children: []ctypes.RunChild{{Tab: &ctypes.Empty{}}},
+			}})
+			page := fmt.Sprint(rd.estimatePage(entry.Bookmark))
+			for _, run := range fieldRuns(`PAGEREF `+entry.Bookmark+` \h`, page) {
+				link.Children = append(link.Children, ctypes.ParagraphChild{Run: run})
+			}
+		}
+		p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Link: link})
+
+		paragraphs = append(paragraphs, p)
+	}
+
+	end := newParagraph(rd)
+	if start != nil {
+		// Word shows this text in a table of contents of a document without headings
+		for _, run := range start {
+			end.appendRun(run)
+		}
+		end.AddText("No table of contents entries found.")
+	}
+	end.appendRun(fieldCharRun(stypes.FieldCharTypeEnd))
+	paragraphs = append(paragraphs, end)
+
+	return paragraphs
+}
+
+// ensureTOCStyles adds the TOC1..TOCn paragraph styles the document does not define. Each level is
+// indented by the given number of twips and ends with a right aligned tab stop with a dot leader at
+// the right margin, for the page numbers.
+func (rd *RootDoc) ensureTOCStyles(maxLevel int, indentation int) {
+	if rd.DocStyles == nil {
+		rd.DocStyles = &ctypes.Styles{}
+	}
+	if indentation <= 0 {
+		indentation = 220
+	}
+
+	leader := stypes.CustLeadCharDot
+	for level := 1; level <= maxLevel; level++ {
+		id := fmt.Sprintf("TOC%d", level)
+		if rd.GetStyleByID(id, stypes.StyleTypeParagraph) != nil {
+			continue
+		}
+
+		prop := &ctypes.ParagraphProp{
+			Tabs: ctypes.Tabs{Tab: []ctypes.Tab{
+				{Val: stypes.CustTabStopRight, Position: rd.textWidth(), LeaderChar: &leader},
+			}},
+			Spacing: &ctypes.Spacing{After: internal.ToPtr(uint64(100))},
+		}
+		if level > 1 {
+			prop.Indent = &ctypes.Indent{Left: internal.ToPtr((level - 1) * indentation)}
+		}
+
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+			Type:           internal.ToPtr(stypes.StyleTypeParagraph),
+			ID:             internal.ToPtr(id),
+			Name:           ctypes.NewCTString(fmt.Sprintf("toc %d", level)),
+			BasedOn:        ctypes.NewCTString("Normal"),
+			Next:           ctypes.NewCTString("Normal"),
+			AutoRedefine:   &ctypes.OnOff{},
+			UIPriority:     ctypes.NewDecimalNum(39),
+			UnhideWhenUsed: &ctypes.OnOff{},
+			ParaProp:       prop,
+		})
+	}
+}
+
+// textWidth returns the width between the margins of the last section, in twips.
+func (rd *RootDoc) textWidth() int {
+	sectPr := rd.Document.Body.SectPr
+	if sectPr == nil || sectPr.PageSize == nil || sectPr.PageSize.Width == nil {
+		return defaultTextWidth
+	}
+
+	width := int(*sectPr.PageSize.Width)
+	if margin := sectPr.PageMargin; margin != nil {
+		if margin.Left != nil {
+			width -= *margin.Left
+		}
+		if margin.Right != nil {
+			width -= *margin.Right
+		}
+	}
+	if width <= 0 {
+		return defaultTextWidth
+	}
+	return width
+}
+
+// estimatePage returns the page of the paragraph holding the bookmark, counting the page and section
+// breaks before it. It is only the result shown until Word updates the fields, as the text itself is
+// not laid out.
+func (rd *RootDoc) estimatePage(bookmark string) int {
+	page := 1
+	for _, child := range rd.Document.Body.Children {
+		p := child.Para
+		if p == nil {
+			continue
+		}
+		if p.ct.Property != nil && p.ct.Property.PageBreakBefore != nil {
+			page++
+		}
+		if p.bookmarkWith(bookmark) == bookmark {
+			return page
+		}
+		for _, run := range p.Runs() {
+			for _, runChild := range run.ct.Children {
+				if runChild.Break != nil && runChild.Break.BreakType != nil && *runChild.Break.BreakType == stypes.BreakTypePage {
+					page++
+				}
+			}
+		}
+		if paragraphSectPr(p) != nil {
+			page++
+		}
+	}
+	return page
+}
+
+// headings returns the heading paragraphs of the document body.
+func (rd *RootDoc) headings() []tocHeading {
+	var headings []tocHeading
 
 	if rd.Document == nil || rd.Document.Body == nil {
-		return headings, nil
+		return headings
 	}
 
 	for _, child := range rd.Document.Body.Children {
 		if child.Para != nil {
 			heading := rd.extractHeadingFromParagraph(child.Para)
 			if heading != nil {
-				headings = append(headings, *heading)
+				headings = append(headings, tocHeading{para: child.Para, entry: *heading})
 			}
 		}
 	}
 
-	return headings, nil
+	return headings
+}
+
+// getHeadingStructure retrieves all headings from the document
+func (rd *RootDoc) getHeadingStructure() ([]TOCEntry, error) {
+	var entries []TOCEntry
+	for _, heading := range rd.headings() {
+		heading.entry.Bookmark = heading.para.bookmarkWith(tocBookmarkPrefix)
+		entries = append(entries, heading.entry)
+	}
+	return entries, nil
 }
 
 // extractHeadingFromParagraph extracts heading information from a paragraph
@@ -152,108 +413,6 @@ func (rd *RootDoc) extractTextFromParagraph(para *Paragraph) string {
 	return text.String()
 }
 
-// createTOCContentParagraphs creates the TOC content as paragraphs
-func (rd *RootDoc) createTOCContentParagraphs(toc *TOC) []DocumentChild {
-	var paragraphs []DocumentChild
-
-	// Create TOC title paragraph with professional styling
-	tocPara := newParagraph(rd)
-	tocPara.AddText(toc.Title).Bold(true).Size(18)
-	paragraphs = append(paragraphs, DocumentChild{Para: tocPara})
-
-	// Add a blank line after title
-	blankPara := newParagraph(rd)
-	blankPara.AddText("")
-	paragraphs = append(paragraphs, DocumentChild{Para: blankPara})
-
-	// Add TOC entries as separate paragraphs
-	for _, heading := range toc.Entries {
-		// Skip if outside the level range
-		if heading.Level < toc.MinLevel || heading.Level > toc.MaxLevel {
-			continue
-		}
-
-		// Create TOC entry paragraph with professional formatting
-		entryPara := newParagraph(rd)
-
-		// Add proper indentation based on level
-		indent := (heading.Level - toc.MinLevel) * toc.Indentation
-		if indent > 0 {
-			entryPara.Indent(&ctypes.Indent{Left: &indent})
-		}
-
-		// Add heading text with appropriate styling
-		if heading.Level == 1 {
-			entryPara.AddText(heading.Text).Bold(true)
-		} else {
-			entryPara.AddText(heading.Text)
-		}
-
-		// Add professional dotted leader with page number
-		if toc.IncludePageNumbers {
-			// Add space and dots for traditional TOC formatting
-			entryPara.AddText(" ")
-
-			// Add multiple dots for the leader
-			for i := 0; i < 20; i++ {
-				entryPara.AddText(".")
-			}
-
-			// Add page number
-			pageNum := rd.calculatePageNumber(heading)
-			entryPara.AddText(fmt.Sprintf(" %d", pageNum))
-		}
-
-		paragraphs = append(paragraphs, DocumentChild{Para: entryPara})
-	}
-
-	return paragraphs
-}
-
-// calculatePageNumber calculates the page number for a heading
-func (rd *RootDoc) calculatePageNumber(heading TOCEntry) int {
-	if rd.Document == nil || rd.Document.Body == nil {
-		return 1
-	}
-
-	// Count paragraphs to estimate page breaks
-	paragraphsPerPage := 20
-
-	// Find the heading's position in the document
-	headingIndex := rd.findHeadingIndex(heading.Text)
-	if headingIndex == -1 {
-		return 1
-	}
-
-	// Calculate page number based on position
-	pageNumber := (headingIndex / paragraphsPerPage) + 1
-
-	// Ensure minimum page number
-	if pageNumber < 1 {
-		pageNumber = 1
-	}
-
-	return pageNumber
-}
-
-// findHeadingIndex finds the index of a heading in the document
-func (rd *RootDoc) findHeadingIndex(headingText string) int {
-	if rd.Document == nil || rd.Document.Body == nil {
-		return -1
-	}
-
-	for i, child := range rd.Document.Body.Children {
-		if child.Para != nil {
-			text := rd.extractTextFromParagraph(child.Para)
-			if text == headingText {
-				return i
-			}
-		}
-	}
-
-	return -1
-}
-
 // GetHeadingStructure returns the heading structure for external use
 func (rd *RootDoc) GetHeadingStructure() ([]TOCEntry, error) {
 	return rd.getHeadingStructure()
@@ -0,0 +1,104 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootDoc_AddTableOfContents(t *testing.T) {
+	rd := NewRootDoc()
+	intro, _ := rd.AddHeading("Introduction", 1)
+	rd.AddParagraph("Body text.")
+	install, _ := rd.AddHeading("Installation", 2)
+	rd.AddHeading("Details", 4)
+
+	toc := rd.AddTableOfContents("Contents", 3, 1, true, 220)
+
+	// Title, two entries and the paragraph ending the field
+	children := rd.Document.Body.Children
+	assert.Len(t, children, 8)
+	assert.Same(t, intro, children[4].Para)
+
+	assert.Len(t, toc.Entries, 3)
+	introBookmark := toc.Entries[0].Bookmark
+	assert.Equal(t, "_Toc000000000", introBookmark)
+	assert.Equal(t, introBookmark, intro.bookmarkWith(tocBookmarkPrefix))
+	assert.NotEmpty(t, install.bookmarkWith(tocBookmarkPrefix))
+	assert.Empty(t, toc.Entries[2].Bookmark)
+
+	output, err := xml.Marshal(children[1].Para.ct)
+	assert.NoError(t, err)
+	assert.Equal(t, `<w:p><w:pPr><w:pStyle w:val="TOC1"></w:pStyle></w:pPr>`+
+		`<w:r><w:fldChar w:fldCharType="begin"></w:fldChar></w:r>`+
+		`<w:r><w:instrText xml:space="preserve"> TOC \o &#34;1-3&#34; \h \z \u </w:instrText></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="separate"></w:fldChar></w:r>`+
+		`<w:hyperlink w:anchor="_Toc000000000" w:history="1">`+
+		`<w:r><w:t>Introduction</w:t></w:r><w:r><w:tab></w:tab></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="begin"></w:fldChar></w:r>`+
+		`<w:r><w:instrText xml:space="preserve"> PAGEREF _Toc000000000 \h </w:instrText></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="separate"></w:fldChar></w:r>`+
+		`<w:r><w:t>1</w:t></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="end"></w:fldChar></w:r>`+
+		`</w:hyperlink></w:p>`, string(output))
+
+	assert.NotNil(t, children[3].Para.ct.Children[0].Run.Children[0].FldChar)
+
+	style := rd.GetStyleByID("TOC2", stypes.StyleTypeParagraph)
+	assert.NotNil(t, style)
+	assert.Equal(t, 220, *style.ParaProp.Indent.Left)
+	assert.Equal(t, stypes.CustTabStopRight, style.ParaProp.Tabs.Tab[0].Val)
+	assert.Equal(t, defaultTextWidth, style.ParaProp.Tabs.Tab[0].Position)
+	assert.Nil(t, rd.GetStyleByID("TOC4", stypes.StyleTypeParagraph))
+
+	assert.True(t, rd.Settings.OnOff("w:updateFields"))
+}
+
+func TestTOC_Update(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Cover")
+	first, _ := rd.AddHeading("First", 1)
+	first.AddRun().AddBreak(internal.ToPtr(stypes.BreakTypePage))
+	rd.AddHeading("Second", 1)
+
+	toc := rd.AddTableOfContents("", 3, 1, true, 220)
+	assert.Len(t, rd.Document.Body.Children, 6)
+	assert.Equal(t, "Cover", rd.extractTextFromParagraph(rd.Document.Body.Children[3].Para))
+
+	// The table is rebuilt in place, without new bookmarks
+	bookmark := first.bookmarkWith(tocBookmarkPrefix)
+	old := rd.Document.Body.Children
+	rd.Document.Body.Children = []DocumentChild{old[3], old[0], old[1], old[2], old[4], old[5]}
+	rd.AddHeading("Third", 2)
+	toc.SetIncludePageNumbers(false)
+
+	children := rd.Document.Body.Children
+	assert.Len(t, children, 8)
+	assert.Equal(t, "Cover", rd.extractTextFromParagraph(children[0].Para))
+	assert.Equal(t, bookmark, first.bookmarkWith(tocBookmarkPrefix))
+	assert.Len(t, first.ct.Children, 4)
+
+	output, err := xml.Marshal(children[1].Para.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `TOC \o &#34;1-3&#34; \h \z \u \n`)
+	assert.NotContains(t, string(output), `PAGEREF`)
+
+	// Page numbers shown until Word updates the fields
+	toc.SetIncludePageNumbers(true)
+	output, err = xml.Marshal(rd.Document.Body.Children[2].Para.ct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<w:t>2</w:t>`)
+}
+
+func TestRootDoc_AddTableOfContents_NoHeadings(t *testing.T) {
+	rd := NewRootDoc()
+	rd.AddParagraph("Text")
+	rd.AddTableOfContents("", 3, 1, true, 220)
+
+	p := rd.Document.Body.Children[0].Para
+	assert.Equal(t, "No table of contents entries found.", rd.extractTextFromParagraph(p))
+	assert.Len(t, p.ct.Children, 5)
+}
@@ -0,0 +1,519 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/mrlijnden/godocx/common/constants"
+	"github.com/mrlijnden/godocx/dml"
+	"github.com/mrlijnden/godocx/wml/ctypes"
+)
+
+// NodeKind is the kind of element a Node stands for.
+type NodeKind int
+
+const (
+	StoryNode     NodeKind = iota // Body, header, footer, note, comment or text box
+	ParagraphNode                 // Paragraph
+	RunNode                       // Run, also within hyperlinks and tracked changes
+	HyperlinkNode                 // Hyperlink
+	TableNode                     // Table
+	RowNode                       // Table row
+	CellNode                      // Table cell
+	DrawingNode                   // DrawingML object of a run, such as a picture
+)
+
+// StoryKind is the kind of a story, a separate flow of content of the document.
+type StoryKind int
+
+const (
+	BodyStory StoryKind = iota
+	HeaderStory
+	FooterStory
+	FootnoteStory
+	EndnoteStory
+	CommentStory
+	TextBoxStory
+)
+
+// Node is an element of the document visited by Walk. The field of its kind is set, and for stories
+// the header, footer, note or comment they belong to. Changes made through the wrappers apply to the
+// document.
+type Node struct {
+	Kind   NodeKind
+	Parent *Node // Enclosing node, nil for the stories of the document
+
+	Story   StoryKind
+	Header  *Header
+	Footer  *Footer
+	Note    *Note
+	Comment *Comment
+
+	Paragraph *Paragraph
+	Run       *Run
+	Hyperlink *Hyperlink
+	Table     *Table
+	Row       *Row
+	Cell      *Cell
+	Drawing   *dml.Drawing
+}
+
+// Path returns the nodes from the story of the node down to the node itself.
+func (n *Node) Path() []*Node {
+	var path []*Node
+	for node := n; node != nil; node = node.Parent {
+		path = append(path, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Visitor is called by Walk for each node. Returning SkipChildren skips the children of the node and
+// SkipAll skips the rest of the document. Any other error stops the walk and is returned by Walk.
+type Visitor func(n *Node) error
+
+var (
+	// SkipChildren is returned by a Visitor to skip the children of the node, as fs.SkipDir.
+	SkipChildren = errors.New("skip children")
+
+	// SkipAll is returned by a Visitor to skip the remaining nodes, as fs.SkipAll.
+	SkipAll = errors.New("skip all")
+)
+
+// Walk visits the content of the document in document order, each node before its children: the
+// stories of the body, the headers, the footers, the footnotes, the endnotes and the comments, and
+// within them the paragraphs, tables, rows and cells, and the hyperlinks, runs and drawings of the
+// paragraphs. Text boxes are visited as stories within the run that holds them. Content controls and
+// tracked changes are looked through, and the separators of the notes are left out.
+//
+// Word writes each text box twice, for new and for older versions of Word; only the first is visited,
+// and the changes made to it are copied to the other.
+//
+// Example:
+//
+//	err := docx.Walk(document, func(n *docx.Node) error {
+//		if n.Kind == docx.TableNode {
+//			return docx.SkipChildren
+//		}
+//		if n.Kind == docx.ParagraphNode {
+//			fmt.Println(len(n.Path()), n.Paragraph.Text())
+//		}
+//		return nil
+//	})
+func Walk(rd *RootDoc, visit Visitor) error {
+	w := &walker{root: rd, visit: visit}
+	if err := w.stories(); err != nil && err != SkipAll {
+		return err
+	}
+	return nil
+}
+
+// walker visits the nodes of a document.
+type walker struct {
+	root  *RootDoc
+	visit Visitor
+	rels  *Relationships // Relationships of the part of the story being visited; nil for the document
+}
+
+// node visits the node and then, unless the visitor skips them, its children.
+func (w *walker) node(n *Node, children func(n *Node) error) error {
+	err := w.visit(n)
+	if err == SkipChildren {
+		return nil
+	}
+	if err != nil || children == nil {
+		return err
+	}
+	return children(n)
+}
+
+func (w *walker) stories() error {
+	if err := w.story(&Node{Kind: StoryNode, Story: BodyStory}, w.root.Document.Body.Children); err != nil {
+		return err
+	}
+	for _, header := range w.root.Headers {
+		w.rels = &header.Rels
+		if err := w.story(&Node{Kind: StoryNode, Story: HeaderStory, Header: header}, header.Children); err != nil {
+			return err
+		}
+	}
+	for _, footer := range w.root.Footers {
+		w.rels = &footer.Rels
+		if err := w.story(&Node{Kind: StoryNode, Story: FooterStory, Footer: footer}, footer.Children); err != nil {
+			return err
+		}
+	}
+
+	for _, notes := range []*Notes{w.root.Footnotes, w.root.Endnotes} {
+		if notes == nil {
+			continue
+		}
+		story := FootnoteStory
+		if notes == w.root.Endnotes {
+			story = EndnoteStory
+		}
+		w.rels = &notes.Rels
+		for _, note := range notes.Notes {
+			if note.Type != nil {
+				continue
+			}
+			if err := w.story(&Node{Kind: StoryNode, Story: story, Note: note}, note.Children); err != nil {
+				return err
+			}
+		}
+	}
+
+	if w.root.Comments != nil {
+		w.rels = &w.root.Comments.Rels
+		for _, comment := range w.root.Comments.Comments {
+			if err := w.story(&Node{Kind: StoryNode, Story: CommentStory, Comment: comment}, comment.Children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *walker) story(n *Node, children []DocumentChild) error {
+	return w.node(n, func(n *Node) error {
+		for _, child := range children {
+			var err error
+			switch {
+			case child.Para != nil:
+				err = w.paragraph(n, child.Para)
+			case child.Table != nil:
+				err = w.table(n, child.Table)
+			case child.Sdt != nil:
+				err = w.blocks(n, child.Sdt.Content)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (w *walker) blocks(parent *Node, blocks []ctypes.TCBlockContent) error {
+	for _, block := range blocks {
+		var err error
+		switch {
+		case block.Paragraph != nil:
+			err = w.paragraph(parent, &Paragraph{root: w.root, ct: block.Paragraph, rels: w.rels})
+		case block.Table != nil:
+			err = w.table(parent, &Table{root: w.root, ct: block.Table, rels: w.rels})
+		case block.Sdt != nil:
+			err = w.blocks(parent, block.Sdt.Content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) table(parent *Node, tbl *Table) error {
+	return w.node(&Node{Kind: TableNode, Parent: parent, Table: tbl}, func(n *Node) error {
+		return w.rows(n, tbl.ct.RowContents)
+	})
+}
+
+func (w *walker) rows(parent *Node, rows []ctypes.RowContent) error {
+	for _, content := range rows {
+		var err error
+		switch {
+		case content.Row != nil:
+			row := &Row{root: w.root, ct: content.Row, rels: w.rels}
+			err = w.node(&Node{Kind: RowNode, Parent: parent, Row: row}, func(n *Node) error {
+				return w.cells(n, row.ct.Contents)
+			})
+		case content.Sdt != nil:
+			err = w.rows(parent, content.Sdt.Content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) cells(parent *Node, cells []ctypes.TRCellContent) error {
+	for _, content := range cells {
+		var err error
+		switch {
+		case content.Cell != nil:
+			cell := &Cell{root: w.root, ct: content.Cell, rels: w.rels}
+			err = w.node(&Node{Kind: CellNode, Parent: parent, Cell: cell}, func(n *Node) error {
+				return w.blocks(n, cell.ct.Contents)
+			})
+		case content.Sdt != nil:
+			err = w.cells(parent, content.Sdt.Content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) paragraph(parent *Node, p *Paragraph) error {
+	return w.node(&Node{Kind: ParagraphNode, Parent: parent, Paragraph: p}, func(n *Node) error {
+		return w.paragraphChildren(n, p, p.ct.Children)
+	})
+}
+
+func (w *walker) paragraphChildren(parent *Node, p *Paragraph, children []ctypes.ParagraphChild) error {
+	for _, child := range children {
+		var err error
+		switch {
+		case child.Run != nil:
+			err = w.run(parent, p, child.Run)
+		case child.Link != nil:
+			link := child.Link
+			err = w.node(&Node{Kind: HyperlinkNode, Parent: parent, Hyperlink: newHyperlink(w.root, w.rels, link)}, func(n *Node) error {
+				if link.Run != nil {
+					if err := w.run(n, p, link.Run); err != nil {
+						return err
+					}
+				}
+				return w.paragraphChildren(n, p, link.Children)
+			})
+		case child.Ins != nil:
+			err = w.paragraphChildren(parent, p, child.Ins.Children)
+		case child.Del != nil:
+			err = w.paragraphChildren(parent, p, child.Del.Children)
+		case child.MoveFrom != nil:
+			err = w.paragraphChildren(parent, p, child.MoveFrom.Children)
+		case child.MoveTo != nil:
+			err = w.paragraphChildren(parent, p, child.MoveTo.Children)
+		case child.Sdt != nil:
+			err = w.paragraphChildren(parent, p, child.Sdt.Content)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) run(parent *Node, p *Paragraph, ct *ctypes.Run) error {
+	run := &Run{root: w.root, ct: ct, parent: p}
+	return w.node(&Node{Kind: RunNode, Parent: parent, Run: run}, func(n *Node) error {
+		for _, child := range ct.Children {
+			var err error
+			switch {
+			case child.Drawing != nil:
+				err = w.node(&Node{Kind: DrawingNode, Parent: n, Drawing: child.Drawing}, nil)
+			case child.Raw != nil:
+				err = w.textBoxes(n, child.Raw)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// textBoxes visits the text boxes (w:txbxContent) within an element kept as raw XML, such as a w:pict
+// or an mc:AlternateContent. The content of a text box that the visitor changes replaces the one of the
+// raw XML, and the content of the matching text box of the mc:Fallback.
+func (w *walker) textBoxes(parent *Node, raw *ctypes.RawXML) error {
+	boxes, fallbacks := textBoxRanges(raw.Tokens)
+	if len(boxes) == 0 {
+		boxes, fallbacks = fallbacks, nil
+	}
+
+	type replacement struct {
+		start, end int
+		tokens     []xml.Token
+	}
+	var replacements []replacement
+
+	var err error
+	for i, box := range boxes {
+		children, decodeErr := decodeTextBox(w.root, w.rels, raw.Tokens[box[0]+1:box[1]])
+		if decodeErr != nil {
+			continue
+		}
+		before, _ := encodeTextBox(children)
+
+		err = w.story(&Node{Kind: StoryNode, Parent: parent, Story: TextBoxStory}, children)
+
+		after, encodeErr := encodeTextBox(children)
+		if encodeErr == nil && !bytes.Equal(before, after) {
+			if tokens, tokensErr := textBoxTokens(after); tokensErr == nil {
+				replacements = append(replacements, replacement{box[0] + 1, box[1], tokens})
+				if len(fallbacks) == len(boxes) {
+					replacements = append(replacements, replacement{fallbacks[i][0] + 1, fallbacks[i][1], tokens})
+				}
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	// Replace from the end, so that the ranges before stay valid
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].start > replacements[j].start })
+	for _, r := range replacements {
+		tokens := append(append(append([]xml.Token{}, raw.Tokens[:r.start]...), r.tokens...), raw.Tokens[r.end:]...)
+		raw.Tokens = tokens
+	}
+	return err
+}
+
+// textBoxRanges returns the indexes of the start and end tokens of the outermost text boxes of raw XML,
+// apart for those of mc:Fallback elements.
+func textBoxRanges(tokens []xml.Token) (boxes, fallbacks [][2]int) {
+	fallback := 0
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i].(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "mc:Fallback":
+				fallback++
+			case "w:txbxContent":
+				end := endToken(tokens, i)
+				if fallback > 0 {
+					fallbacks = append(fallbacks, [2]int{i, end})
+				} else {
+					boxes = append(boxes, [2]int{i, end})
+				}
+				i = end
+			}
+		case xml.EndElement:
+			if tok.Name.Local == "mc:Fallback" {
+				fallback--
+			}
+		}
+	}
+	return boxes, fallbacks
+}
+
+// endToken returns the index of the end token of the element that starts at tokens[start].
+func endToken(tokens []xml.Token, start int) int {
+	depth := 0
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i].(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// fragmentDecoder returns a decoder of XML data written without namespace declarations, such as the
+// content of a text box, within an element that declares the namespaces of WordprocessingML. The decoder
+// is positioned after the start of that element.
+func fragmentDecoder(data []byte) (*xml.Decoder, error) {
+	start := xml.StartElement{Name: xml.Name{Local: "txbxContent"}}
+	for space, prefix := range constants.NSToLocal {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: space})
+	}
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := e.EncodeToken(start); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.WriteString("</txbxContent>")
+
+	d := xml.NewDecoder(&buf)
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// decodeTextBox decodes the content of a text box from the tokens of raw XML.
+func decodeTextBox(root *RootDoc, rels *Relationships, tokens []xml.Token) ([]DocumentChild, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	for _, tok := range tokens {
+		if err := e.EncodeToken(tok); err != nil {
+			return nil, err
+		}
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+
+	d, err := fragmentDecoder(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var children []DocumentChild
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch elem := tok.(type) {
+		case xml.StartElement:
+			child, err := unmarshalDocumentChild(root, rels, d, elem)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		case xml.EndElement:
+			return children, nil
+		}
+	}
+}
+
+func encodeTextBox(children []DocumentChild) ([]byte, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := marshalDocumentChildren(e, children); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// textBoxTokens returns the encoded content of a text box as tokens of raw XML.
+func textBoxTokens(data []byte) ([]xml.Token, error) {
+	d, err := fragmentDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []xml.Token
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch elem := tok.(type) {
+		case xml.StartElement:
+			raw := ctypes.NewRawXML()
+			if err := raw.UnmarshalXML(d, elem); err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, raw.Tokens...)
+		case xml.EndElement:
+			return tokens, nil
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// walkTrace returns a line for each node Walk visits, indented by the depth of the node.
+func walkTrace(t *testing.T, rd *RootDoc, visit Visitor) []string {
+	var trace []string
+	err := Walk(rd, func(n *Node) error {
+		line := strings.Repeat("  ", len(n.Path())-1)
+		switch n.Kind {
+		case StoryNode:
+			line += fmt.Sprintf("story %d", n.Story)
+		case ParagraphNode:
+			line += "p"
+		case RunNode:
+			line += "r " + runText(n.Run.ct)
+		case HyperlinkNode:
+			line += "a"
+		case TableNode:
+			line += "tbl"
+		case RowNode:
+			line += "tr"
+		case CellNode:
+			line += "tc"
+		case DrawingNode:
+			line += "drawing"
+		}
+		trace = append(trace, line)
+		if visit != nil {
+			return visit(n)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	return trace
+}
+
+const walkBody = `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:p><w:r><w:t xml:space="preserve">See </w:t></w:r><w:hyperlink w:anchor="terms"><w:r><w:t>terms</w:t></w:r></w:hyperlink>` +
+	`<w:ins w:id="1" w:author="A"><w:r><w:t>added</w:t></w:r></w:ins></w:p>` +
+	`<w:tbl><w:tblPr/><w:tblGrid/><w:tr><w:tc><w:p><w:r><w:t>Cell</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+	`<w:sdt><w:sdtContent><w:p><w:r><w:t>Control</w:t></w:r></w:p></w:sdtContent></w:sdt>` +
+	`</w:body>`
+
+func TestWalk(t *testing.T) {
+	rd := NewRootDoc()
+	assert.NoError(t, xml.Unmarshal([]byte(walkBody), rd.Document.Body))
+	rd.AddHeader(stypes.HdrFtrDefault).AddParagraph("Header")
+	rd.Document.Body.Children[0].Para.AddFootnote("Note")
+	rd.Document.Body.Children[0].Para.AddComment("Jane Doe", "JD", "Comment")
+
+	assert.Equal(t, []string{
+		"story 0",
+		"  p",
+		"    r See ",
+		"    a",
+		"      r terms",
+		"    r added",
+		"    r ",
+		"    r ",
+		"  tbl",
+		"    tr",
+		"      tc",
+		"        p",
+		"          r Cell",
+		"  p",
+		"    r Control",
+		"story 1",
+		"  p",
+		"    r Header",
+		"story 3",
+		"  p",
+		"    r ",
+		"    r  Note",
+		"story 5",
+		"  p",
+		"    r ",
+		"    r Comment",
+	}, walkTrace(t, rd, nil))
+
+	// The wrappers of content read from a document change the document
+	err := Walk(rd, func(n *Node) error {
+		if n.Kind == ParagraphNode && n.Parent.Kind == CellNode {
+			n.Paragraph.Style("TableText")
+			n.Parent.Cell.BackgroundColor("D9D9D9")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	cell := rd.Document.Body.Children[1].Table.ct.RowContents[0].Row.Contents[0].Cell
+	assert.Equal(t, "TableText", cell.Contents[0].Paragraph.Property.Style.Val)
+	assert.Equal(t, "D9D9D9", *cell.Property.Shading.Fill)
+}
+
+func TestWalk_Skip(t *testing.T) {
+	rd := NewRootDoc()
+	assert.NoError(t, xml.Unmarshal([]byte(walkBody), rd.Document.Body))
+
+	trace := walkTrace(t, rd, func(n *Node) error {
+		switch {
+		case n.Kind == TableNode:
+			return SkipChildren
+		case n.Kind == RunNode && runText(n.Run.ct) == "Control":
+			return SkipAll
+		}
+		return nil
+	})
+	assert.Equal(t, []string{"story 0", "  p", "    r See ", "    a", "      r terms", "    r added", "  tbl", "  p", "    r Control"}, trace)
+
+	stop := errors.New("stop")
+	err := Walk(rd, func(n *Node) error {
+		if n.Kind == HyperlinkNode {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+}
+
+func TestWalk_TextBoxes(t *testing.T) {
+	box := `<w:txbxContent><w:p><w:r><w:t>Boxed</w:t></w:r></w:p></w:txbxContent>`
+	input := `<w:body xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" ` +
+		`xmlns:wps="http://schemas.microsoft.com/office/word/2010/wordprocessingShape" ` +
+		`xmlns:v="urn:schemas-microsoft-com:vml">` +
+		`<w:p><w:r><mc:AlternateContent><mc:Choice Requires="wps"><w:drawing><wps:wsp><wps:txbx>` + box +
+		`</wps:txbx></wps:wsp></w:drawing></mc:Choice><mc:Fallback><w:pict><v:shape><v:textbox>` + box +
+		`</v:textbox></v:shape></w:pict></mc:Fallback></mc:AlternateContent></w:r></w:p>` +
+		`</w:body>`
+
+	rd := NewRootDoc()
+	assert.NoError(t, xml.Unmarshal([]byte(input), rd.Document.Body))
+	raw := rd.Document.Body.Children[0].Para.ct.Children[0].Run.Children[0].Raw
+	original := append(raw.Tokens[:0:0], raw.Tokens...)
+
+	assert.Equal(t, []string{"story 0", "  p", "    r ", "      story 6", "        p", "          r Boxed"}, walkTrace(t, rd, nil))
+	assert.Equal(t, original, raw.Tokens)
+
+	err := Walk(rd, func(n *Node) error {
+		if n.Kind == RunNode && runText(n.Run.ct) == "Boxed" {
+			n.Run.ct.Children[0].Text.Text = "Changed"
+			n.Run.Bold(true)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	output, err := xml.Marshal(rd.Document.Body.Children[0].Para.ct)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(output), `<w:txbxContent><w:p><w:r><w:rPr><w:b w:val="true"></w:b></w:rPr><w:t>Changed</w:t></w:r></w:p></w:txbxContent>`))
+	assert.Contains(t, string(output), `<v:textbox>`)
+}
+
+func TestWalk_PartRelationships(t *testing.T) {
+	rd := NewRootDoc()
+	header := rd.AddHeader(stypes.HdrFtrDefault)
+	header.AddEmptyParagraph().AddLink("Header link", "https://example.com/header")
+	note := rd.AddEmptyParagraph().AddFootnote("Note")
+	note.AddEmptyParagraph().AddLink("Note link", "https://example.com/note")
+	docRels := len(rd.Document.DocRels.Relationships)
+
+	var targets []string
+	err := Walk(rd, func(n *Node) error {
+		switch n.Kind {
+		case HyperlinkNode:
+			target, err := n.Hyperlink.Target()
+			if err != nil {
+				return err
+			}
+			targets = append(targets, target)
+		case ParagraphNode:
+			if n.Parent.Story == HeaderStory {
+				n.Paragraph.AddLink("Added", "https://example.com/added")
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/header", "https://example.com/added", "https://example.com/note"}, targets)
+	assert.Len(t, header.Rels.Relationships, 2)
+	assert.Len(t, rd.Document.DocRels.Relationships, docRels)
+}
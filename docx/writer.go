@@ -81,11 +81,63 @@ func (rd *RootDoc) writeToZip(zw *zip.Writer) error {
 	}
 	rd.FileMap.Store(rd.DocStyles.RelativePath, docStyleBytes)
 
+	if rd.Settings != nil {
+		settingsBytes, err := marshal(rd.Settings)
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(rd.Settings.RelativePath, settingsBytes)
+	}
+
+	if rd.Numbering != nil {
+		numberingBytes, err := marshal(rd.Numbering)
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(rd.Numbering.RelativePath, numberingBytes)
+	}
+
 	// Serialize headers and footers
 	if err := rd.serializeHeadersAndFooters(); err != nil {
 		return err
 	}
 
+	// Serialize footnotes and endnotes
+	for _, notes := range []*Notes{rd.Footnotes, rd.Endnotes} {
+		if notes == nil {
+			continue
+		}
+		notesBytes, err := marshal(notes)
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(notes.filename, notesBytes)
+
+		if err := rd.storePartRels(notes.Rels); err != nil {
+			return err
+		}
+	}
+
+	if rd.Comments != nil {
+		commentsBytes, err := marshal(rd.Comments)
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(rd.Comments.filename, commentsBytes)
+
+		if err := rd.storePartRels(rd.Comments.Rels); err != nil {
+			return err
+		}
+	}
+
+	if rd.CommentsExtended != nil {
+		commentsExBytes, err := marshal(rd.CommentsExtended)
+		if err != nil {
+			return err
+		}
+		rd.FileMap.Store(rd.CommentsExtended.RelativePath, commentsExBytes)
+	}
+
 	rd.FileMap.Range(func(path, content any) bool {
 		files = append(files, path.(string))
 		return true
@@ -113,6 +165,10 @@ func (rd *RootDoc) serializeHeadersAndFooters() error {
 			return err
 		}
 		rd.FileMap.Store(header.filename, xmlData)
+
+		if err := rd.storePartRels(header.Rels); err != nil {
+			return err
+		}
 	}
 
 	// Serialize all footers
@@ -122,11 +178,30 @@ func (rd *RootDoc) serializeHeadersAndFooters() error {
 			return err
 		}
 		rd.FileMap.Store(footer.filename, xmlData)
+
+		if err := rd.storePartRels(footer.Rels); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// storePartRels stores the relationships of a part in the FileMap, or removes them when there are none.
+func (rd *RootDoc) storePartRels(rels Relationships) error {
+	if len(rels.Relationships) == 0 {
+		rd.FileMap.Delete(rels.RelativePath)
+		return nil
+	}
+
+	relsData, err := marshal(rels)
+	if err != nil {
+		return err
+	}
+	rd.FileMap.Store(rels.RelativePath, relsData)
+	return nil
+}
+
 // Save method saves the RootDoc to the specified file path.
 func (rd *RootDoc) Save() error {
 	return rd.SaveTo(rd.Path)
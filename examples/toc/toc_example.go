@@ -39,7 +39,7 @@ func main() {
 	doc.AddParagraph("Summary and next steps.")
 
 	// Add TOC with fluent API configuration
-	toc := doc.AddTableOfContents("Table of Contents", 3, 1, true, 20)
+	toc := doc.AddTableOfContents("Table of Contents", 3, 1, true, 220)
 
 	log.Printf("TOC created with %d entries", len(toc.Entries))
 
@@ -52,11 +52,10 @@ func main() {
 	log.Println("✅ Document with TOC created successfully!")
 	log.Println("📄 Open 'toc_example.docx' to see the table of contents.")
 	log.Println("🎯 Features:")
-	log.Println("   • Professional formatting with dotted leaders")
-	log.Println("   • Bold Level 1 headings")
+	log.Println("   • Word TOC field with dotted leaders")
+	log.Println("   • Entries linked to their headings")
 	log.Println("   • Proper indentation for hierarchy")
-	log.Println("   • Realistic page numbers")
-	log.Println("   • Immediate visibility (no manual update needed)")
+	log.Println("   • Page numbers refreshed by Word when the document is opened")
 
 	// Print the heading structure
 	headings, err := doc.GetHeadingStructure()
@@ -3,6 +3,7 @@ package packager
 import (
 	"fmt"
 	"path"
+	"strings"
 )
 
 // GetRelsURI returns the URI of the .rels file for the specified OPC file.
@@ -13,3 +14,12 @@ func GetRelsURI(filePath string) (*string, error) {
 	relsURI := path.Join(baseURI, "_rels", relsFilename)
 	return &relsURI, nil
 }
+
+// partPath resolves the target of a document relationship to a path within the package.
+// Targets are relative to the directory of the main document unless they start with "/".
+func partPath(wordDir string, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(wordDir, target)
+}
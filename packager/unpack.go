@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/mrlijnden/godocx/common/constants"
@@ -111,6 +112,10 @@ func Unpack(content *[]byte) (*docx.RootDoc, error) {
 	rID := 0
 	for _, relation := range docRelations.Relationships {
 		rID += 1
+		// New relationships must not reuse the ID of an existing one, which need not be sequential
+		if n, err := strconv.Atoi(strings.TrimPrefix(relation.ID, "rId")); err == nil && n > rID {
+			rID = n
+		}
 		switch relation.Type {
 		case constants.StylesType:
 			sFileName := relation.Target
@@ -127,6 +132,130 @@ func Unpack(content *[]byte) (*docx.RootDoc, error) {
 			}
 			delete(fileIndex, stylesPath)
 			rd.DocStyles = stylesObj
+		case constants.SourceRelationshipSettings:
+			settingsPath := partPath(wordDir, relation.Target)
+			settingsFile, ok := fileIndex[settingsPath]
+			if !ok {
+				continue
+			}
+
+			settings, err := docx.LoadSettings(settingsPath, settingsFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, settingsPath)
+			rd.Settings = settings
+		case constants.SourceRelationshipNumbering:
+			numberingPath := partPath(wordDir, relation.Target)
+			numberingFile, ok := fileIndex[numberingPath]
+			if !ok {
+				continue
+			}
+
+			numbering, err := docx.LoadNumbering(numberingPath, numberingFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, numberingPath)
+			rd.Numbering = numbering
+		case constants.SourceRelationshipHeader:
+			headerPath := partPath(wordDir, relation.Target)
+			headerFile, ok := fileIndex[headerPath]
+			if !ok {
+				continue
+			}
+
+			header, err := docx.LoadHeaderXml(rd, relation.ID, headerPath, headerFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, headerPath)
+
+			if header.Rels, err = loadPartRels(fileIndex, headerPath); err != nil {
+				return nil, err
+			}
+			rd.Headers = append(rd.Headers, header)
+		case constants.SourceRelationshipFooter:
+			footerPath := partPath(wordDir, relation.Target)
+			footerFile, ok := fileIndex[footerPath]
+			if !ok {
+				continue
+			}
+
+			footer, err := docx.LoadFooterXml(rd, relation.ID, footerPath, footerFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, footerPath)
+
+			if footer.Rels, err = loadPartRels(fileIndex, footerPath); err != nil {
+				return nil, err
+			}
+			rd.Footers = append(rd.Footers, footer)
+		case constants.SourceRelationshipFootnotes:
+			footnotesPath := partPath(wordDir, relation.Target)
+			footnotesFile, ok := fileIndex[footnotesPath]
+			if !ok {
+				continue
+			}
+
+			footnotes, err := docx.LoadFootnotesXml(rd, footnotesPath, footnotesFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, footnotesPath)
+
+			if footnotes.Rels, err = loadPartRels(fileIndex, footnotesPath); err != nil {
+				return nil, err
+			}
+			rd.Footnotes = footnotes
+		case constants.SourceRelationshipEndnotes:
+			endnotesPath := partPath(wordDir, relation.Target)
+			endnotesFile, ok := fileIndex[endnotesPath]
+			if !ok {
+				continue
+			}
+
+			endnotes, err := docx.LoadEndnotesXml(rd, endnotesPath, endnotesFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, endnotesPath)
+
+			if endnotes.Rels, err = loadPartRels(fileIndex, endnotesPath); err != nil {
+				return nil, err
+			}
+			rd.Endnotes = endnotes
+		case constants.SourceRelationshipComments:
+			commentsPath := partPath(wordDir, relation.Target)
+			commentsFile, ok := fileIndex[commentsPath]
+			if !ok {
+				continue
+			}
+
+			comments, err := docx.LoadCommentsXml(rd, commentsPath, commentsFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, commentsPath)
+
+			if comments.Rels, err = loadPartRels(fileIndex, commentsPath); err != nil {
+				return nil, err
+			}
+			rd.Comments = comments
+		case constants.SourceRelationshipCommentsExtended:
+			commentsExPath := partPath(wordDir, relation.Target)
+			commentsExFile, ok := fileIndex[commentsExPath]
+			if !ok {
+				continue
+			}
+
+			commentsEx, err := docx.LoadCommentsExtended(commentsExPath, commentsExFile)
+			if err != nil {
+				return nil, err
+			}
+			delete(fileIndex, commentsExPath)
+			rd.CommentsExtended = commentsEx
 		}
 	}
 
@@ -141,3 +270,24 @@ func Unpack(content *[]byte) (*docx.RootDoc, error) {
 
 	return rd, nil
 }
+
+// loadPartRels loads the relationships of the part at partPath and removes them from the file index.
+// A part without relationships gets an empty collection, to which relationships can be added.
+func loadPartRels(fileIndex map[string][]byte, partPath string) (docx.Relationships, error) {
+	relsURI, err := GetRelsURI(partPath)
+	if err != nil {
+		return docx.Relationships{}, err
+	}
+
+	relsFile, ok := fileIndex[*relsURI]
+	if !ok {
+		return docx.Relationships{RelativePath: *relsURI, Xmlns: constants.XMLNS}, nil
+	}
+
+	rels, err := LoadRelationShips(*relsURI, relsFile)
+	if err != nil {
+		return docx.Relationships{}, err
+	}
+	delete(fileIndex, *relsURI)
+	return *rels, nil
+}
@@ -83,6 +83,24 @@ loop:
 				c.Contents = append(c.Contents, TCBlockContent{
 					Table: &tbl,
 				})
+			case "bookmarkStart", "bookmarkEnd":
+				rng := RngMarkupElem{}
+				if err = rng.UnmarshalXML(d, elem); err != nil {
+					return err
+				}
+
+				c.Contents = append(c.Contents, TCBlockContent{
+					RngMarkup: &rng,
+				})
+			case "sdt":
+				sdt := SdtBlock{}
+				if err = sdt.UnmarshalXML(d, elem); err != nil {
+					return err
+				}
+
+				c.Contents = append(c.Contents, TCBlockContent{
+					Sdt: &sdt,
+				})
 			default:
 				if err = d.Skip(); err != nil {
 					return err
@@ -104,6 +122,10 @@ type TCBlockContent struct {
 	//Table
 	//	- ZeroOrMore: Any number of times Table can repeat within cell
 	Table *Table
+	//Bookmark start or end between paragraphs and tables
+	RngMarkup *RngMarkupElem
+	//Content control around paragraphs and tables
+	Sdt *SdtBlock
 }
 
 func (t TCBlockContent) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -115,5 +137,13 @@ func (t TCBlockContent) MarshalXML(e *xml.Encoder, start xml.StartElement) error
 		return t.Table.MarshalXML(e, xml.StartElement{})
 	}
 
+	if t.RngMarkup != nil {
+		return t.RngMarkup.MarshalXML(e, xml.StartElement{})
+	}
+
+	if t.Sdt != nil {
+		return t.Sdt.MarshalXML(e, xml.StartElement{})
+	}
+
 	return nil
 }
@@ -0,0 +1,71 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// Column Definitions : w:cols
+type Columns struct {
+	Num        *int          `xml:"num,attr,omitempty"`        // Number of Equal Width Columns
+	Space      *int          `xml:"space,attr,omitempty"`      // Spacing Between Equal Width Columns
+	Sep        *stypes.OnOff `xml:"sep,attr,omitempty"`        // Draw Line Between Columns
+	EqualWidth *stypes.OnOff `xml:"equalWidth,attr,omitempty"` // Equal Column Widths
+
+	// Individual column definitions, used when the columns are not of equal width
+	Col []ColumnDef `xml:"col,omitempty"`
+}
+
+// Single Column Definition : w:col
+type ColumnDef struct {
+	Width *int `xml:"w,attr,omitempty"`     // Column Width
+	Space *int `xml:"space,attr,omitempty"` // Space Before Following Column
+}
+
+func (c Columns) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:cols"
+
+	if c.Num != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:num"}, Value: strconv.Itoa(*c.Num)})
+	}
+
+	if c.Space != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:space"}, Value: strconv.Itoa(*c.Space)})
+	}
+
+	if c.Sep != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:sep"}, Value: string(*c.Sep)})
+	}
+
+	if c.EqualWidth != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:equalWidth"}, Value: string(*c.EqualWidth)})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, col := range c.Col {
+		if err := col.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (c ColumnDef) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:col"
+
+	if c.Width != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:w"}, Value: strconv.Itoa(*c.Width)})
+	}
+
+	if c.Space != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:space"}, Value: strconv.Itoa(*c.Space)})
+	}
+
+	return e.EncodeElement("", start)
+}
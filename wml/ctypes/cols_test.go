@@ -0,0 +1,67 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+func TestColumns_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Columns
+		expected string
+	}{
+		{
+			name:     "Equal width",
+			input:    Columns{Num: intPtr(2), Space: intPtr(720)},
+			expected: `<w:cols w:num="2" w:space="720"></w:cols>`,
+		},
+		{
+			name: "Individual columns",
+			input: Columns{
+				Num:        intPtr(2),
+				Sep:        OnOffPtr(stypes.OnOffOne),
+				EqualWidth: OnOffPtr(stypes.OnOffZero),
+				Col:        []ColumnDef{{Width: intPtr(3000), Space: intPtr(500)}, {Width: intPtr(5000)}},
+			},
+			expected: `<w:cols w:num="2" w:sep="1" w:equalWidth="0"><w:col w:w="3000" w:space="500"></w:col><w:col w:w="5000"></w:col></w:cols>`,
+		},
+		{
+			name:     "Empty",
+			input:    Columns{},
+			expected: `<w:cols></w:cols>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := marshalToString(t, tt.input); got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestColumns_UnmarshalXML(t *testing.T) {
+	input := `<w:cols xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" w:num="2" w:sep="1" w:equalWidth="0">` +
+		`<w:col w:w="3000" w:space="500"/><w:col w:w="5000"/></w:cols>`
+
+	expected := Columns{
+		Num:        intPtr(2),
+		Sep:        OnOffPtr(stypes.OnOffOne),
+		EqualWidth: OnOffPtr(stypes.OnOffZero),
+		Col:        []ColumnDef{{Width: intPtr(3000), Space: intPtr(500)}, {Width: intPtr(5000)}},
+	}
+
+	var result Columns
+	if err := xml.Unmarshal([]byte(input), &result); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, result)
+	}
+}
@@ -0,0 +1,153 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+var defaultCommentsExNSAttrs = map[string]string{
+	"xmlns:w15":    "http://schemas.microsoft.com/office/word/2012/wordml",
+	"xmlns:mc":     "http://schemas.openxmlformats.org/markup-compatibility/2006",
+	"mc:Ignorable": "w15",
+}
+
+// Extended Comment Information : w15:commentsEx (word/commentsExtended.xml)
+//
+// Each entry refers to a comment through the paragraph ID (w14:paraId) of the comment's last paragraph.
+type CommentsEx struct {
+	RelativePath string `xml:"-"`
+	Attr         []xml.Attr
+	Comments     []*CommentEx
+}
+
+func NewCommentsEx(relativePath string) *CommentsEx {
+	return &CommentsEx{RelativePath: relativePath}
+}
+
+// Get returns the entry for the comment whose last paragraph has the given ID, or nil if there is none.
+func (c *CommentsEx) Get(paraID string) *CommentEx {
+	for _, comment := range c.Comments {
+		if comment.ParaID == paraID {
+			return comment
+		}
+	}
+	return nil
+}
+
+// Remove removes the entry for the comment whose last paragraph has the given ID.
+func (c *CommentsEx) Remove(paraID string) {
+	for i, comment := range c.Comments {
+		if comment.ParaID == paraID {
+			c.Comments = append(c.Comments[:i], c.Comments[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *CommentsEx) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w15:commentsEx"
+
+	if len(c.Attr) == 0 {
+		for key, value := range defaultCommentsExNSAttrs {
+			attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
+			start.Attr = append(start.Attr, attr)
+		}
+	} else {
+		start.Attr = c.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, comment := range c.Comments {
+		if err := comment.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (c *CommentsEx) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	c.Attr = QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			if elem.Name.Local != "commentEx" {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			comment := &CommentEx{}
+			if err = comment.UnmarshalXML(d, elem); err != nil {
+				return err
+			}
+			c.Comments = append(c.Comments, comment)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Extended Comment Information : w15:commentEx
+type CommentEx struct {
+	// Paragraph ID of the comment's last paragraph
+	ParaID string
+
+	// Paragraph ID of the last paragraph of the comment this one replies to
+	ParaIDParent string
+
+	// Whether the comment is resolved
+	Done *stypes.OnOff
+
+	// Attributes that are not modelled, kept for round trip
+	Attr []xml.Attr
+}
+
+func (c CommentEx) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w15:commentEx"}
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w15:paraId"}, Value: c.ParaID})
+	if c.ParaIDParent != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w15:paraIdParent"}, Value: c.ParaIDParent})
+	}
+	if c.Done != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w15:done"}, Value: string(*c.Done)})
+	}
+	start.Attr = append(start.Attr, c.Attr...)
+
+	return e.EncodeElement("", start)
+}
+
+func (c *CommentEx) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "paraId":
+			c.ParaID = attr.Value
+		case "paraIdParent":
+			c.ParaIDParent = attr.Value
+		case "done":
+			done, err := stypes.OnOffFromStr(attr.Value)
+			if err != nil {
+				return err
+			}
+			c.Done = &done
+		default:
+			attrs = append(attrs, attr)
+		}
+	}
+	c.Attr = QualifyAttrs(attrs)
+
+	return d.Skip()
+}
@@ -0,0 +1,71 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+func TestCommentsEx_RoundTrip(t *testing.T) {
+	input := `<w15:commentsEx xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" mc:Ignorable="w15">` +
+		`<w15:commentEx w15:paraId="1A2B3C4D" w15:done="0"/>` +
+		`<w15:commentEx w15:paraId="1A2B3C4E" w15:paraIdParent="1A2B3C4D" w15:done="1"/>` +
+		`</w15:commentsEx>`
+
+	var c CommentsEx
+	if err := xml.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(c.Comments) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(c.Comments))
+	}
+
+	reply := c.Get("1A2B3C4E")
+	if reply == nil || reply.ParaIDParent != "1A2B3C4D" || !reply.Done.ToBool() {
+		t.Errorf("Unexpected reply entry: %+v", reply)
+	}
+
+	expected := `<w15:commentsEx xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" mc:Ignorable="w15">` +
+		`<w15:commentEx w15:paraId="1A2B3C4D" w15:done="0"></w15:commentEx>` +
+		`<w15:commentEx w15:paraId="1A2B3C4E" w15:paraIdParent="1A2B3C4D" w15:done="1"></w15:commentEx>` +
+		`</w15:commentsEx>`
+	if got := marshalToString(t, &c); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+
+	c.Remove("1A2B3C4D")
+	if len(c.Comments) != 1 || c.Get("1A2B3C4D") != nil {
+		t.Errorf("Expected the entry to be removed, got %+v", c.Comments)
+	}
+}
+
+func TestCommentEx_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    CommentEx
+		expected string
+	}{
+		{
+			name:     "Paragraph ID only",
+			input:    CommentEx{ParaID: "00000001"},
+			expected: `<w15:commentEx w15:paraId="00000001"></w15:commentEx>`,
+		},
+		{
+			name:     "Resolved reply",
+			input:    CommentEx{ParaID: "00000002", ParaIDParent: "00000001", Done: OnOffPtr(stypes.OnOffOne)},
+			expected: `<w15:commentEx w15:paraId="00000002" w15:paraIdParent="00000001" w15:done="1"></w15:commentEx>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := marshalToString(t, tt.input); got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -9,6 +9,15 @@ import (
 // FieldChar represents a field character in a document
 type FieldChar struct {
 	FieldCharType stypes.FieldCharType `xml:"fldCharType,attr"`
+
+	// Field Result Invalidated
+	Dirty *stypes.OnOff `xml:"dirty,attr,omitempty"`
+
+	// Field Should Not Be Recalculated
+	FldLock *stypes.OnOff `xml:"fldLock,attr,omitempty"`
+
+	// Form Field Properties (w:ffData), kept as is
+	FFData *RawXML `xml:"-"`
 }
 
 func NewFieldChar(fieldCharType stypes.FieldCharType) *FieldChar {
@@ -23,7 +32,28 @@ func (fc FieldChar) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		Name:  xml.Name{Local: "w:fldCharType"},
 		Value: string(fc.FieldCharType),
 	})
-	return e.EncodeElement("", start)
+
+	if fc.Dirty != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:dirty"}, Value: string(*fc.Dirty)})
+	}
+
+	if fc.FldLock != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:fldLock"}, Value: string(*fc.FldLock)})
+	}
+
+	if fc.FFData == nil {
+		return e.EncodeElement("", start)
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := fc.FFData.MarshalXML(e, xml.StartElement{}); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(start.End())
 }
 
 func (fc *FieldChar) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
@@ -35,7 +65,42 @@ func (fc *FieldChar) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error
 				return err
 			}
 			fc.FieldCharType = fieldCharType
+		case "dirty":
+			val, err := stypes.OnOffFromStr(attr.Value)
+			if err != nil {
+				return err
+			}
+			fc.Dirty = &val
+		case "fldLock":
+			val, err := stypes.OnOffFromStr(attr.Value)
+			if err != nil {
+				return err
+			}
+			fc.FldLock = &val
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			if elem.Name.Local != "ffData" {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			fc.FFData = NewRawXML()
+			if err = fc.FFData.UnmarshalXML(d, elem); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
 		}
 	}
-	return d.Skip()
 }
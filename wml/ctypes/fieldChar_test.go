@@ -0,0 +1,66 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+func TestFieldChar_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    FieldChar
+		expected string
+	}{
+		{
+			name:     "Begin",
+			input:    *NewFieldChar(stypes.FieldCharTypeBegin),
+			expected: `<w:fldChar w:fldCharType="begin"></w:fldChar>`,
+		},
+		{
+			name: "End with dirty and lock",
+			input: FieldChar{
+				FieldCharType: stypes.FieldCharTypeEnd,
+				Dirty:         internal.ToPtr(stypes.OnOffTrue),
+				FldLock:       internal.ToPtr(stypes.OnOffFalse),
+			},
+			expected: `<w:fldChar w:fldCharType="end" w:dirty="true" w:fldLock="false"></w:fldChar>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := marshalToString(t, tt.input); got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFieldChar_UnmarshalXML(t *testing.T) {
+	input := `<w:fldChar xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" w:fldCharType="begin" w:dirty="1">` +
+		`<w:ffData><w:name w:val="Text1"/><w:enabled/></w:ffData></w:fldChar>`
+
+	fc := FieldChar{}
+	if err := xml.Unmarshal([]byte(input), &fc); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if fc.FieldCharType != stypes.FieldCharTypeBegin {
+		t.Errorf("Expected begin, got %s", fc.FieldCharType)
+	}
+	if fc.Dirty == nil || *fc.Dirty != stypes.OnOffOne {
+		t.Errorf("Expected dirty to be 1")
+	}
+	if fc.FFData == nil {
+		t.Fatalf("Expected ffData to be preserved")
+	}
+
+	expected := `<w:fldChar w:fldCharType="begin" w:dirty="1">` +
+		`<w:ffData><w:name w:val="Text1"></w:name><w:enabled></w:enabled></w:ffData></w:fldChar>`
+	if got := marshalToString(t, fc); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
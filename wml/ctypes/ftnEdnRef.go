@@ -0,0 +1,30 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// FtnEdnRef is a reference to a footnote (w:footnoteReference) or an endnote (w:endnoteReference)
+type FtnEdnRef struct {
+	// Suppress Footnote/Endnote Reference Mark
+	CustomMarkFollows *stypes.OnOff `xml:"customMarkFollows,attr,omitempty"`
+
+	// Footnote/Endnote ID Reference
+	ID int `xml:"id,attr"`
+}
+
+func NewFtnEdnRef(id int) *FtnEdnRef {
+	return &FtnEdnRef{ID: id}
+}
+
+func (r FtnEdnRef) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if r.CustomMarkFollows != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:customMarkFollows"}, Value: string(*r.CustomMarkFollows)})
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(r.ID)})
+
+	return e.EncodeElement("", start)
+}
@@ -0,0 +1,78 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+func TestFtnEdnRef_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    FtnEdnRef
+		expected string
+	}{
+		{
+			name:     "With ID",
+			input:    FtnEdnRef{ID: 2},
+			expected: `<w:footnoteReference w:id="2"></w:footnoteReference>`,
+		},
+		{
+			name:     "With custom mark",
+			input:    FtnEdnRef{ID: 3, CustomMarkFollows: OnOffPtr(stypes.OnOffTrue)},
+			expected: `<w:footnoteReference w:customMarkFollows="true" w:id="3"></w:footnoteReference>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result strings.Builder
+			encoder := xml.NewEncoder(&result)
+			start := xml.StartElement{Name: xml.Name{Local: "w:footnoteReference"}}
+
+			if err := tt.input.MarshalXML(encoder, start); err != nil {
+				t.Fatalf("Error marshaling XML: %v", err)
+			}
+
+			encoder.Flush()
+			if result.String() != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, result.String())
+			}
+		})
+	}
+}
+
+func TestFtnEdnRef_UnmarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputXML string
+		expected FtnEdnRef
+	}{
+		{
+			name:     "With ID",
+			inputXML: `<w:endnoteReference w:id="4"></w:endnoteReference>`,
+			expected: FtnEdnRef{ID: 4},
+		},
+		{
+			name:     "With custom mark",
+			inputXML: `<w:endnoteReference w:customMarkFollows="1" w:id="5"></w:endnoteReference>`,
+			expected: FtnEdnRef{ID: 5, CustomMarkFollows: OnOffPtr(stypes.OnOffOne)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result FtnEdnRef
+			if err := xml.Unmarshal([]byte(tt.inputXML), &result); err != nil {
+				t.Fatalf("Error unmarshaling XML: %v", err)
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v but got %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// Hyperlink represents the w:hyperlink element
+type Hyperlink struct {
+	// Attributes
+	ID          string        // Hyperlink Target (relationship ID)
+	Anchor      *string       // Hyperlink Anchor (bookmark in this document)
+	Tooltip     *string       // Associated String
+	TgtFrame    *string       // Hyperlink Target Frame
+	DocLocation *string       // Location in Target Document
+	History     *stypes.OnOff // Add To Viewed Hyperlinks
+
+	// Run created through the API. When decoding, a leading run is placed here
+	// and the remaining content in Children, so the original order is kept.
+	Run *Run
+
+	Children []ParagraphChild
+}
+
+func (h Hyperlink) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	start.Name.Local = "w:hyperlink"
+	start.Attr = nil
+
+	if h.ID != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "r:id"}, Value: h.ID})
+	}
+	if h.Anchor != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:anchor"}, Value: *h.Anchor})
+	}
+	if h.Tooltip != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tooltip"}, Value: *h.Tooltip})
+	}
+	if h.TgtFrame != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tgtFrame"}, Value: *h.TgtFrame})
+	}
+	if h.DocLocation != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:docLocation"}, Value: *h.DocLocation})
+	}
+	if h.History != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:history"}, Value: string(*h.History)})
+	}
+
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if h.Run != nil {
+		if err = h.Run.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range h.Children {
+		switch {
+		case child.Run != nil:
+			err = child.Run.MarshalXML(e, xml.StartElement{})
+		case child.Link != nil:
+			err = child.Link.MarshalXML(e, xml.StartElement{})
+		case child.Raw != nil:
+			err = child.Raw.MarshalXML(e, xml.StartElement{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (h *Hyperlink) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			h.ID = attr.Value
+		case "anchor":
+			value := attr.Value
+			h.Anchor = &value
+		case "tooltip":
+			value := attr.Value
+			h.Tooltip = &value
+		case "tgtFrame":
+			value := attr.Value
+			h.TgtFrame = &value
+		case "docLocation":
+			value := attr.Value
+			h.DocLocation = &value
+		case "history":
+			val, err := stypes.OnOffFromStr(attr.Value)
+			if err != nil {
+				return err
+			}
+			h.History = &val
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			child, err := unmarshalParagraphChild(d, elem)
+			if err != nil {
+				return err
+			}
+
+			if child.Run != nil && h.Run == nil && len(h.Children) == 0 {
+				h.Run = child.Run
+				continue
+			}
+
+			h.Children = append(h.Children, child)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Runs returns all runs of the hyperlink in document order.
+func (h *Hyperlink) Runs() []*Run {
+	var runs []*Run
+	if h.Run != nil {
+		runs = append(runs, h.Run)
+	}
+	for _, child := range h.Children {
+		if child.Run != nil {
+			runs = append(runs, child.Run)
+		}
+	}
+	return runs
+}
@@ -0,0 +1,133 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/internal"
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+func TestHyperlink_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Hyperlink
+		expected string
+	}{
+		{
+			name: "External link",
+			input: Hyperlink{
+				ID:  "rId5",
+				Run: &Run{Children: []RunChild{{Text: TextFromString("Go")}}},
+			},
+			expected: `<w:hyperlink r:id="rId5"><w:r><w:t>Go</w:t></w:r></w:hyperlink>`,
+		},
+		{
+			name: "Anchor with tooltip and history",
+			input: Hyperlink{
+				Anchor:  internal.ToPtr("_Toc1"),
+				Tooltip: internal.ToPtr("Jump"),
+				History: internal.ToPtr(stypes.OnOffTrue),
+				Children: []ParagraphChild{
+					{Run: &Run{Children: []RunChild{{Text: TextFromString("A")}}}},
+					{Run: &Run{Children: []RunChild{{Text: TextFromString("B")}}}},
+				},
+			},
+			expected: `<w:hyperlink w:anchor="_Toc1" w:tooltip="Jump" w:history="true">` +
+				`<w:r><w:t>A</w:t></w:r><w:r><w:t>B</w:t></w:r></w:hyperlink>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := marshalToString(t, tt.input)
+			if got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHyperlink_UnmarshalXML(t *testing.T) {
+	input := `<w:hyperlink xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId7" w:history="1">` +
+		`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t>Click</w:t></w:r>` +
+		`<w:proofErr w:type="spellStart"/>` +
+		`<w:r><w:t xml:space="preserve"> here</w:t></w:r>` +
+		`</w:hyperlink>`
+
+	link := Hyperlink{}
+	if err := xml.Unmarshal([]byte(input), &link); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if link.ID != "rId7" {
+		t.Errorf("Expected ID rId7, got %s", link.ID)
+	}
+	if link.History == nil || *link.History != "1" {
+		t.Errorf("Expected history to be 1")
+	}
+	if link.Run == nil || link.Run.Property == nil || link.Run.Property.Style.Val != "Hyperlink" {
+		t.Fatalf("Expected leading run with Hyperlink style")
+	}
+	if len(link.Children) != 2 || link.Children[0].Raw == nil || link.Children[1].Run == nil {
+		t.Fatalf("Expected raw proofErr followed by a run, got %+v", link.Children)
+	}
+	if len(link.Runs()) != 2 {
+		t.Errorf("Expected 2 runs, got %d", len(link.Runs()))
+	}
+
+	expected := `<w:hyperlink r:id="rId7" w:history="1">` +
+		`<w:r><w:rPr><w:rStyle w:val="Hyperlink"></w:rStyle></w:rPr><w:t>Click</w:t></w:r>` +
+		`<w:proofErr w:type="spellStart"></w:proofErr>` +
+		`<w:r><w:t xml:space="preserve"> here</w:t></w:r>` +
+		`</w:hyperlink>`
+
+	if got := marshalToString(t, link); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestHyperlink_RoundTripAttributes(t *testing.T) {
+	input := `<w:hyperlink xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`w:anchor="_Toc000000000" w:tooltip="Jump" w:tgtFrame="_top" w:docLocation="table" w:history="1">` +
+		`<w:r><w:t>Contents</w:t></w:r></w:hyperlink>`
+
+	link := Hyperlink{}
+	if err := xml.Unmarshal([]byte(input), &link); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	// Each attribute keeps its own value
+	expected := `<w:hyperlink w:anchor="_Toc000000000" w:tooltip="Jump" w:tgtFrame="_top" w:docLocation="table" w:history="1">` +
+		`<w:r><w:t>Contents</w:t></w:r></w:hyperlink>`
+	if got := marshalToString(t, link); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestParagraph_UnmarshalHyperlink(t *testing.T) {
+	input := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:r><w:t xml:space="preserve">See </w:t></w:r>` +
+		`<w:hyperlink r:id="rId3"><w:r><w:t>docs</w:t></w:r></w:hyperlink>` +
+		`</w:p>`
+
+	p := Paragraph{}
+	if err := xml.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(p.Children) != 2 || p.Children[1].Link == nil {
+		t.Fatalf("Expected run followed by hyperlink, got %+v", p.Children)
+	}
+
+	expected := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:r><w:t xml:space="preserve">See </w:t></w:r>` +
+		`<w:hyperlink r:id="rId3"><w:r><w:t>docs</w:t></w:r></w:hyperlink></w:p>`
+
+	if got := marshalToString(t, p); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,730 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+var defaultNumberingNSAttrs = map[string]string{
+	"xmlns:w": "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r": "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+}
+
+// Numbering Definitions : w:numbering
+type Numbering struct {
+	RelativePath string `xml:"-"`
+	Attr         []xml.Attr
+
+	// Sequence
+
+	//1. Picture Numbering Symbol Definitions, kept as read
+	PicBullets []*RawXML
+
+	//2. Abstract Numbering Definitions
+	AbstractNums []*AbstractNum
+
+	//3. Numbering Definition Instances
+	Nums []*Num
+
+	//4. Any other element, e.g. w:numIdMacAtCleanup, kept as read
+	Extra []*RawXML
+}
+
+func NewNumbering(relativePath string) *Numbering {
+	return &Numbering{RelativePath: relativePath}
+}
+
+func (n *Numbering) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:numbering"
+
+	if len(n.Attr) == 0 {
+		for key, value := range defaultNumberingNSAttrs {
+			attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
+			start.Attr = append(start.Attr, attr)
+		}
+	} else {
+		start.Attr = n.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, elem := range n.PicBullets {
+		if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("numPicBullet: %w", err)
+		}
+	}
+
+	for _, elem := range n.AbstractNums {
+		if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("abstractNum: %w", err)
+		}
+	}
+
+	for _, elem := range n.Nums {
+		if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("num: %w", err)
+		}
+	}
+
+	for _, elem := range n.Extra {
+		if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (n *Numbering) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n.Attr = QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "abstractNum":
+				abstractNum := &AbstractNum{}
+				if err = d.DecodeElement(abstractNum, &elem); err != nil {
+					return err
+				}
+				n.AbstractNums = append(n.AbstractNums, abstractNum)
+			case "num":
+				num := &Num{}
+				if err = d.DecodeElement(num, &elem); err != nil {
+					return err
+				}
+				n.Nums = append(n.Nums, num)
+			default:
+				raw := NewRawXML()
+				if err = raw.UnmarshalXML(d, elem); err != nil {
+					return err
+				}
+				if elem.Name.Local == "numPicBullet" {
+					n.PicBullets = append(n.PicBullets, raw)
+				} else {
+					n.Extra = append(n.Extra, raw)
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// AbstractNum returns the abstract numbering definition with the given ID, or nil if there is none.
+func (n *Numbering) AbstractNum(id int) *AbstractNum {
+	for _, abstractNum := range n.AbstractNums {
+		if abstractNum.ID == id {
+			return abstractNum
+		}
+	}
+	return nil
+}
+
+// Num returns the numbering definition instance with the given ID, or nil if there is none.
+func (n *Numbering) Num(id int) *Num {
+	for _, num := range n.Nums {
+		if num.ID == id {
+			return num
+		}
+	}
+	return nil
+}
+
+// AddAbstractNum adds the abstract numbering definition under a new, unused ID and returns that ID.
+func (n *Numbering) AddAbstractNum(abstractNum *AbstractNum) int {
+	id := 0
+	for _, existing := range n.AbstractNums {
+		if existing.ID >= id {
+			id = existing.ID + 1
+		}
+	}
+
+	abstractNum.ID = id
+	n.AbstractNums = append(n.AbstractNums, abstractNum)
+	return id
+}
+
+// AddNum adds a numbering definition instance of the given abstract numbering definition under a
+// new, unused ID. This is the ID paragraphs refer to in their numbering properties.
+func (n *Numbering) AddNum(abstractNumID int) *Num {
+	id := 1
+	for _, existing := range n.Nums {
+		if existing.ID >= id {
+			id = existing.ID + 1
+		}
+	}
+
+	num := NewNum(id, abstractNumID)
+	n.Nums = append(n.Nums, num)
+	return num
+}
+
+// Level returns the definition of the given level as used by paragraphs referring to the numbering
+// definition instance numID: the level of a level override if the instance has one, otherwise the
+// level of its abstract numbering definition. It returns nil if either cannot be found.
+func (n *Numbering) Level(numID int, ilvl int) *Level {
+	num := n.Num(numID)
+	if num == nil {
+		return nil
+	}
+
+	if override := num.Override(ilvl); override != nil && override.Level != nil {
+		return override.Level
+	}
+
+	if num.AbstractNumID == nil {
+		return nil
+	}
+
+	abstractNum := n.AbstractNum(num.AbstractNumID.Val)
+	if abstractNum == nil {
+		return nil
+	}
+
+	return abstractNum.Level(ilvl)
+}
+
+// Abstract Numbering Definition : w:abstractNum
+type AbstractNum struct {
+	// Attributes
+
+	//Abstract Numbering Definition ID
+	ID int
+
+	//Any other attribute, e.g. w15:restartNumberingAfterBreak
+	Attr []xml.Attr
+
+	// Sequence
+
+	//1. Abstract Numbering Definition Identifier
+	Nsid *GenSingleStrVal[stypes.LongHexNum]
+
+	//2. Abstract Numbering Definition Type
+	MultiLevelType *GenSingleStrVal[stypes.MultiLevelType]
+
+	//3. Numbering Template Code
+	Tmpl *GenSingleStrVal[stypes.LongHexNum]
+
+	//4. Abstract Numbering Definition Name
+	Name *CTString
+
+	//5. Numbering Style Definition
+	StyleLink *CTString
+
+	//6. Numbering Style Reference
+	NumStyleLink *CTString
+
+	//7. Numbering Level Definitions
+	Levels []*Level
+}
+
+// Level returns the definition of the given level, or nil if there is none.
+func (a *AbstractNum) Level(ilvl int) *Level {
+	for _, level := range a.Levels {
+		if level.ILvl == ilvl {
+			return level
+		}
+	}
+	return nil
+}
+
+func (a AbstractNum) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w:abstractNum"}
+	start.Attr = append([]xml.Attr{{Name: xml.Name{Local: "w:abstractNumId"}, Value: strconv.Itoa(a.ID)}}, a.Attr...)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if a.Nsid != nil {
+		if err := a.Nsid.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:nsid"}}); err != nil {
+			return fmt.Errorf("nsid: %w", err)
+		}
+	}
+
+	if a.MultiLevelType != nil {
+		if err := a.MultiLevelType.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:multiLevelType"}}); err != nil {
+			return fmt.Errorf("multiLevelType: %w", err)
+		}
+	}
+
+	if a.Tmpl != nil {
+		if err := a.Tmpl.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:tmpl"}}); err != nil {
+			return fmt.Errorf("tmpl: %w", err)
+		}
+	}
+
+	if a.Name != nil {
+		if err := a.Name.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:name"}}); err != nil {
+			return fmt.Errorf("name: %w", err)
+		}
+	}
+
+	if a.StyleLink != nil {
+		if err := a.StyleLink.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:styleLink"}}); err != nil {
+			return fmt.Errorf("styleLink: %w", err)
+		}
+	}
+
+	if a.NumStyleLink != nil {
+		if err := a.NumStyleLink.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numStyleLink"}}); err != nil {
+			return fmt.Errorf("numStyleLink: %w", err)
+		}
+	}
+
+	for _, level := range a.Levels {
+		if err := level.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("lvl: %w", err)
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (a *AbstractNum) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "abstractNumId" {
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return fmt.Errorf("abstractNumId: %w", err)
+			}
+			a.ID = id
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	a.Attr = QualifyAttrs(attrs)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "nsid":
+				a.Nsid = &GenSingleStrVal[stypes.LongHexNum]{}
+				err = d.DecodeElement(a.Nsid, &elem)
+			case "multiLevelType":
+				a.MultiLevelType = &GenSingleStrVal[stypes.MultiLevelType]{}
+				err = d.DecodeElement(a.MultiLevelType, &elem)
+			case "tmpl":
+				a.Tmpl = &GenSingleStrVal[stypes.LongHexNum]{}
+				err = d.DecodeElement(a.Tmpl, &elem)
+			case "name":
+				a.Name = &CTString{}
+				err = d.DecodeElement(a.Name, &elem)
+			case "styleLink":
+				a.StyleLink = &CTString{}
+				err = d.DecodeElement(a.StyleLink, &elem)
+			case "numStyleLink":
+				a.NumStyleLink = &CTString{}
+				err = d.DecodeElement(a.NumStyleLink, &elem)
+			case "lvl":
+				level := &Level{}
+				if err = d.DecodeElement(level, &elem); err == nil {
+					a.Levels = append(a.Levels, level)
+				}
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Numbering Level Definition : w:lvl
+type Level struct {
+	// Attributes
+
+	//Numbering Level
+	ILvl int
+
+	//Any other attribute, e.g. w:tplc or w:tentative
+	Attr []xml.Attr
+
+	// Sequence
+
+	//1. Starting Value
+	Start *DecimalNum
+
+	//2. Numbering Format
+	NumFmt *GenSingleStrVal[stypes.NumFmt]
+
+	//3. Elements the library does not model, e.g. an mc:AlternateContent holding a custom
+	//numbering format, kept as read and written after the numbering format
+	Extra []*RawXML
+
+	//4. Restart Numbering Level Symbol
+	LvlRestart *DecimalNum
+
+	//5. Paragraph Style's Associated Numbering Level
+	PStyle *CTString
+
+	//6. Display All Levels Using Arabic Numerals
+	IsLgl *OnOff
+
+	//7. Content Between Numbering Symbol and Paragraph Text
+	Suffix *GenSingleStrVal[stypes.LevelSuffix]
+
+	//8. Numbering Level Text
+	Text *CTString
+
+	//9. Picture Numbering Symbol Definition Reference
+	PicBulletID *DecimalNum
+
+	//10. Legacy Numbering Level Properties
+	Legacy *RawXML
+
+	//11. Justification
+	Justification *GenSingleStrVal[stypes.Justification]
+
+	//12. Numbering Level Associated Paragraph Properties
+	ParaProp *ParagraphProp
+
+	//13. Numbering Symbol Run Properties
+	RunProp *RunProperty
+}
+
+// NewLevel creates a numbering level that starts at 1 and shows the given text, in which %1 to %9
+// stand for the current number of levels 0 to 8 in the given format.
+func NewLevel(ilvl int, format stypes.NumFmt, text string) *Level {
+	return &Level{
+		ILvl:          ilvl,
+		Start:         NewDecimalNum(1),
+		NumFmt:        NewGenSingleStrVal(format),
+		Text:          NewCTString(text),
+		Justification: NewGenSingleStrVal(stypes.JustificationLeft),
+	}
+}
+
+func (l Level) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w:lvl"}
+	start.Attr = append([]xml.Attr{{Name: xml.Name{Local: "w:ilvl"}, Value: strconv.Itoa(l.ILvl)}}, l.Attr...)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if l.Start != nil {
+		if err := l.Start.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:start"}}); err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+	}
+
+	if l.NumFmt != nil {
+		if err := l.NumFmt.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numFmt"}}); err != nil {
+			return fmt.Errorf("numFmt: %w", err)
+		}
+	}
+
+	for _, elem := range l.Extra {
+		if err := elem.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	if l.LvlRestart != nil {
+		if err := l.LvlRestart.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlRestart"}}); err != nil {
+			return fmt.Errorf("lvlRestart: %w", err)
+		}
+	}
+
+	if l.PStyle != nil {
+		if err := l.PStyle.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:pStyle"}}); err != nil {
+			return fmt.Errorf("pStyle: %w", err)
+		}
+	}
+
+	if l.IsLgl != nil {
+		if err := l.IsLgl.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:isLgl"}}); err != nil {
+			return fmt.Errorf("isLgl: %w", err)
+		}
+	}
+
+	if l.Suffix != nil {
+		if err := l.Suffix.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:suff"}}); err != nil {
+			return fmt.Errorf("suff: %w", err)
+		}
+	}
+
+	if l.Text != nil {
+		if err := l.Text.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlText"}}); err != nil {
+			return fmt.Errorf("lvlText: %w", err)
+		}
+	}
+
+	if l.PicBulletID != nil {
+		if err := l.PicBulletID.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlPicBulletId"}}); err != nil {
+			return fmt.Errorf("lvlPicBulletId: %w", err)
+		}
+	}
+
+	if l.Legacy != nil {
+		if err := l.Legacy.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("legacy: %w", err)
+		}
+	}
+
+	if l.Justification != nil {
+		if err := l.Justification.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlJc"}}); err != nil {
+			return fmt.Errorf("lvlJc: %w", err)
+		}
+	}
+
+	if l.ParaProp != nil {
+		if err := l.ParaProp.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("pPr: %w", err)
+		}
+	}
+
+	if l.RunProp != nil {
+		if err := l.RunProp.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("rPr: %w", err)
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (l *Level) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "ilvl" {
+			ilvl, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return fmt.Errorf("ilvl: %w", err)
+			}
+			l.ILvl = ilvl
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	l.Attr = QualifyAttrs(attrs)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "start":
+				l.Start = &DecimalNum{}
+				err = d.DecodeElement(l.Start, &elem)
+			case "numFmt":
+				l.NumFmt = &GenSingleStrVal[stypes.NumFmt]{}
+				err = d.DecodeElement(l.NumFmt, &elem)
+			case "lvlRestart":
+				l.LvlRestart = &DecimalNum{}
+				err = d.DecodeElement(l.LvlRestart, &elem)
+			case "pStyle":
+				l.PStyle = &CTString{}
+				err = d.DecodeElement(l.PStyle, &elem)
+			case "isLgl":
+				l.IsLgl = &OnOff{}
+				err = d.DecodeElement(l.IsLgl, &elem)
+			case "suff":
+				l.Suffix = &GenSingleStrVal[stypes.LevelSuffix]{}
+				err = d.DecodeElement(l.Suffix, &elem)
+			case "lvlText":
+				l.Text = &CTString{}
+				err = d.DecodeElement(l.Text, &elem)
+			case "lvlPicBulletId":
+				l.PicBulletID = &DecimalNum{}
+				err = d.DecodeElement(l.PicBulletID, &elem)
+			case "legacy":
+				l.Legacy = NewRawXML()
+				err = l.Legacy.UnmarshalXML(d, elem)
+			case "lvlJc":
+				l.Justification = &GenSingleStrVal[stypes.Justification]{}
+				err = d.DecodeElement(l.Justification, &elem)
+			case "pPr":
+				l.ParaProp = &ParagraphProp{}
+				err = d.DecodeElement(l.ParaProp, &elem)
+			case "rPr":
+				l.RunProp = &RunProperty{}
+				err = d.DecodeElement(l.RunProp, &elem)
+			default:
+				raw := NewRawXML()
+				if err = raw.UnmarshalXML(d, elem); err == nil {
+					l.Extra = append(l.Extra, raw)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Numbering Definition Instance : w:num
+type Num struct {
+	// Attributes
+
+	//Numbering Definition Instance ID
+	ID int
+
+	//Any other attribute, e.g. w16cid:durableId
+	Attr []xml.Attr
+
+	// Sequence
+
+	//1. Abstract Numbering Definition Reference
+	AbstractNumID *DecimalNum
+
+	//2. Numbering Level Definition Overrides
+	Overrides []*LevelOverride
+}
+
+func NewNum(id int, abstractNumID int) *Num {
+	return &Num{
+		ID:            id,
+		AbstractNumID: NewDecimalNum(abstractNumID),
+	}
+}
+
+// Override returns the override of the given level, or nil if there is none.
+func (n *Num) Override(ilvl int) *LevelOverride {
+	for _, override := range n.Overrides {
+		if override.ILvl == ilvl {
+			return override
+		}
+	}
+	return nil
+}
+
+// SetStartOverride makes numbering of the given level start again at the given value in the
+// paragraphs that refer to this instance.
+func (n *Num) SetStartOverride(ilvl int, start int) {
+	override := n.Override(ilvl)
+	if override == nil {
+		override = &LevelOverride{ILvl: ilvl}
+		n.Overrides = append(n.Overrides, override)
+	}
+	override.StartOverride = NewDecimalNum(start)
+}
+
+func (n Num) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w:num"}
+	start.Attr = append([]xml.Attr{{Name: xml.Name{Local: "w:numId"}, Value: strconv.Itoa(n.ID)}}, n.Attr...)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if n.AbstractNumID != nil {
+		if err := n.AbstractNumID.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:abstractNumId"}}); err != nil {
+			return fmt.Errorf("abstractNumId: %w", err)
+		}
+	}
+
+	for _, override := range n.Overrides {
+		if err := override.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("lvlOverride: %w", err)
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (n *Num) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "numId" {
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return fmt.Errorf("numId: %w", err)
+			}
+			n.ID = id
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	n.Attr = QualifyAttrs(attrs)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "abstractNumId":
+				n.AbstractNumID = &DecimalNum{}
+				err = d.DecodeElement(n.AbstractNumID, &elem)
+			case "lvlOverride":
+				override := &LevelOverride{}
+				if err = d.DecodeElement(override, &elem); err == nil {
+					n.Overrides = append(n.Overrides, override)
+				}
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Numbering Level Definition Override : w:lvlOverride
+type LevelOverride struct {
+	//Numbering Level ID
+	ILvl int `xml:"ilvl,attr"`
+
+	//1. Numbering Level Starting Value Override
+	StartOverride *DecimalNum `xml:"startOverride,omitempty"`
+
+	//2. Numbering Level Override Definition
+	Level *Level `xml:"lvl,omitempty"`
+}
+
+func (o LevelOverride) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w:lvlOverride"}
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:ilvl"}, Value: strconv.Itoa(o.ILvl)}}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if o.StartOverride != nil {
+		if err := o.StartOverride.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:startOverride"}}); err != nil {
+			return fmt.Errorf("startOverride: %w", err)
+		}
+	}
+
+	if o.Level != nil {
+		if err := o.Level.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("lvl: %w", err)
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
@@ -0,0 +1,173 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+const numberingTestXML = `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" ` +
+	`xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml">` +
+	`<w:numPicBullet w:numPicBulletId="0"><w:pict></w:pict></w:numPicBullet>` +
+	`<w:abstractNum w:abstractNumId="3" w15:restartNumberingAfterBreak="0">` +
+	`<w:nsid w:val="FFFFFF7F"/>` +
+	`<w:multiLevelType w:val="hybridMultilevel"/>` +
+	`<w:lvl w:ilvl="0" w:tplc="04090001">` +
+	`<w:start w:val="1"/>` +
+	`<w:numFmt w:val="decimal"/>` +
+	`<mc:AlternateContent><mc:Fallback><w:numFmt w:val="decimal"/></mc:Fallback></mc:AlternateContent>` +
+	`<w:pStyle w:val="ListNumber"/>` +
+	`<w:lvlText w:val="%1."/>` +
+	`<w:lvlJc w:val="left"/>` +
+	`<w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr>` +
+	`</w:lvl>` +
+	`<w:lvl w:ilvl="1">` +
+	`<w:start w:val="1"/>` +
+	`<w:numFmt w:val="bullet"/>` +
+	`<w:suff w:val="space"/>` +
+	`<w:lvlText w:val="o"/>` +
+	`<w:lvlJc w:val="left"/>` +
+	`<w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New"/></w:rPr>` +
+	`</w:lvl>` +
+	`</w:abstractNum>` +
+	`<w:num w:numId="1"><w:abstractNumId w:val="3"/></w:num>` +
+	`<w:num w:numId="2"><w:abstractNumId w:val="3"/>` +
+	`<w:lvlOverride w:ilvl="0"><w:startOverride w:val="5"/></w:lvlOverride>` +
+	`<w:lvlOverride w:ilvl="1"><w:lvl w:ilvl="1"><w:numFmt w:val="lowerLetter"/><w:lvlText w:val="%2)"/></w:lvl></w:lvlOverride>` +
+	`</w:num>` +
+	`<w:numIdMacAtCleanup w:val="2"/>` +
+	`</w:numbering>`
+
+func TestNumbering_RoundTrip(t *testing.T) {
+	var n Numbering
+	if err := xml.Unmarshal([]byte(numberingTestXML), &n); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(n.PicBullets) != 1 || len(n.AbstractNums) != 1 || len(n.Nums) != 2 || len(n.Extra) != 1 {
+		t.Fatalf("Unexpected numbering children: %+v", n)
+	}
+
+	expected := `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" ` +
+		`xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml">` +
+		`<w:numPicBullet w:numPicBulletId="0"><w:pict></w:pict></w:numPicBullet>` +
+		`<w:abstractNum w:abstractNumId="3" w15:restartNumberingAfterBreak="0">` +
+		`<w:nsid w:val="FFFFFF7F"></w:nsid>` +
+		`<w:multiLevelType w:val="hybridMultilevel"></w:multiLevelType>` +
+		`<w:lvl w:ilvl="0" w:tplc="04090001">` +
+		`<w:start w:val="1"></w:start>` +
+		`<w:numFmt w:val="decimal"></w:numFmt>` +
+		`<mc:AlternateContent><mc:Fallback><w:numFmt w:val="decimal"></w:numFmt></mc:Fallback></mc:AlternateContent>` +
+		`<w:pStyle w:val="ListNumber"></w:pStyle>` +
+		`<w:lvlText w:val="%1."></w:lvlText>` +
+		`<w:lvlJc w:val="left"></w:lvlJc>` +
+		`<w:pPr><w:ind w:left="720" w:hanging="360"></w:ind></w:pPr>` +
+		`</w:lvl>` +
+		`<w:lvl w:ilvl="1">` +
+		`<w:start w:val="1"></w:start>` +
+		`<w:numFmt w:val="bullet"></w:numFmt>` +
+		`<w:suff w:val="space"></w:suff>` +
+		`<w:lvlText w:val="o"></w:lvlText>` +
+		`<w:lvlJc w:val="left"></w:lvlJc>` +
+		`<w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New"></w:rFonts></w:rPr>` +
+		`</w:lvl>` +
+		`</w:abstractNum>` +
+		`<w:num w:numId="1"><w:abstractNumId w:val="3"></w:abstractNumId></w:num>` +
+		`<w:num w:numId="2"><w:abstractNumId w:val="3"></w:abstractNumId>` +
+		`<w:lvlOverride w:ilvl="0"><w:startOverride w:val="5"></w:startOverride></w:lvlOverride>` +
+		`<w:lvlOverride w:ilvl="1"><w:lvl w:ilvl="1"><w:numFmt w:val="lowerLetter"></w:numFmt><w:lvlText w:val="%2)"></w:lvlText></w:lvl></w:lvlOverride>` +
+		`</w:num>` +
+		`<w:numIdMacAtCleanup w:val="2"></w:numIdMacAtCleanup>` +
+		`</w:numbering>`
+	if got := marshalToString(t, &n); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestNumbering_Level(t *testing.T) {
+	var n Numbering
+	if err := xml.Unmarshal([]byte(numberingTestXML), &n); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		numID    int
+		ilvl     int
+		expected stypes.NumFmt
+	}{
+		{"Abstract level", 1, 0, stypes.NumFmtDecimal},
+		{"Abstract level of second instance", 1, 1, stypes.NumFmtBullet},
+		{"Start override keeps the abstract level", 2, 0, stypes.NumFmtDecimal},
+		{"Level override", 2, 1, stypes.NumFmtLowerLetter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := n.Level(tt.numID, tt.ilvl)
+			if level == nil {
+				t.Fatal("Expected a level, got nil")
+			}
+			if level.NumFmt.Val != tt.expected {
+				t.Errorf("Expected format %s, got %s", tt.expected, level.NumFmt.Val)
+			}
+		})
+	}
+
+	if n.Level(1, 4) != nil {
+		t.Error("Expected no definition for an undefined level")
+	}
+	if n.Level(7, 0) != nil {
+		t.Error("Expected no definition for an unknown instance")
+	}
+}
+
+func TestNumbering_AddAbstractNumAndNum(t *testing.T) {
+	var n Numbering
+	if err := xml.Unmarshal([]byte(numberingTestXML), &n); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	abstractNum := &AbstractNum{Levels: []*Level{NewLevel(0, stypes.NumFmtUpperRoman, "%1.")}}
+	abstractID := n.AddAbstractNum(abstractNum)
+	if abstractID != 4 || abstractNum.ID != 4 {
+		t.Errorf("Expected abstract numbering ID 4, got %d", abstractID)
+	}
+
+	num := n.AddNum(abstractID)
+	if num.ID != 3 {
+		t.Errorf("Expected numbering instance ID 3, got %d", num.ID)
+	}
+
+	num.SetStartOverride(0, 1)
+	num.SetStartOverride(0, 3)
+	if len(num.Overrides) != 1 || num.Override(0).StartOverride.Val != 3 {
+		t.Errorf("Expected a single start override of 3, got %+v", num.Overrides)
+	}
+
+	expected := `<w:num w:numId="3"><w:abstractNumId w:val="4"></w:abstractNumId>` +
+		`<w:lvlOverride w:ilvl="0"><w:startOverride w:val="3"></w:startOverride></w:lvlOverride>` +
+		`</w:num>`
+	if got := marshalToString(t, num); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+
+	if level := n.Level(3, 0); level == nil || level.NumFmt.Val != stypes.NumFmtUpperRoman {
+		t.Errorf("Expected the new definition to be used by the new instance, got %+v", level)
+	}
+}
+
+func TestNewLevel(t *testing.T) {
+	expected := `<w:lvl w:ilvl="2">` +
+		`<w:start w:val="1"></w:start>` +
+		`<w:numFmt w:val="lowerRoman"></w:numFmt>` +
+		`<w:lvlText w:val="%3."></w:lvlText>` +
+		`<w:lvlJc w:val="left"></w:lvlJc>` +
+		`</w:lvl>`
+	if got := marshalToString(t, NewLevel(2, stypes.NumFmtLowerRoman, "%3.")); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
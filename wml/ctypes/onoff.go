@@ -38,6 +38,11 @@ func (n *OnOff) Disable() {
 	n.Val = &o
 }
 
+// Enabled reports whether the property is turned on. An element without a val attribute turns it on.
+func (n OnOff) Enabled() bool {
+	return n.Val == nil || n.Val.ToBool()
+}
+
 // MarshalXML implements the xml.Marshaler interface for the Bold type.
 // It encodes the instance into XML using the "w:XMLName" element with a "w:val" attribute.
 func (n OnOff) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
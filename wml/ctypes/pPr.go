@@ -385,12 +385,12 @@ func (p PPrChange) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name.Local = "w:pPrChange"
 
 	start.Attr = []xml.Attr{
-		{Name: xml.Name{Local: "id"}, Value: strconv.Itoa(p.ID)},
-		{Name: xml.Name{Local: "author"}, Value: p.Author},
+		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(p.ID)},
+		{Name: xml.Name{Local: "w:author"}, Value: p.Author},
 	}
 
 	if p.Date != nil {
-		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "date"}, Value: *p.Date})
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:date"}, Value: *p.Date})
 	}
 
 	err := e.EncodeToken(start)
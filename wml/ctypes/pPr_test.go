@@ -125,7 +125,7 @@ func TestParagraphProp_MarshalUnmarshal(t *testing.T) {
 			Author: "authortest",
 		},
 		SectPr: &SectionProp{
-			TitlePg: NewGenSingleStrVal(stypes.OnOffTrue),
+			TitlePg: OnOffFromBool(true),
 		},
 	}
 
@@ -301,7 +301,7 @@ func TestPPrChange_MarshalXML(t *testing.T) {
 					// Initialize ParagraphProp fields here if needed
 				},
 			},
-			expected: `<w:pPrChange id="123" author="John Doe" date="2024-06-19"><w:pPr></w:pPr></w:pPrChange>`,
+			expected: `<w:pPrChange w:id="123" w:author="John Doe" w:date="2024-06-19"><w:pPr></w:pPr></w:pPrChange>`,
 		},
 		{
 			name: "Without date attribute",
@@ -312,7 +312,7 @@ func TestPPrChange_MarshalXML(t *testing.T) {
 					// Initialize ParagraphProp fields here if needed
 				},
 			},
-			expected: `<w:pPrChange id="456" author="Jane Smith"><w:pPr></w:pPr></w:pPrChange>`,
+			expected: `<w:pPrChange w:id="456" w:author="Jane Smith"><w:pPr></w:pPr></w:pPrChange>`,
 		},
 		{
 			name: "Without paraProp",
@@ -321,7 +321,7 @@ func TestPPrChange_MarshalXML(t *testing.T) {
 				Author: "Alice Brown",
 				Date:   internal.ToPtr("2024-06-20"),
 			},
-			expected: `<w:pPrChange id="789" author="Alice Brown" date="2024-06-20"></w:pPrChange>`,
+			expected: `<w:pPrChange w:id="789" w:author="Alice Brown" w:date="2024-06-20"></w:pPrChange>`,
 		},
 	}
 
@@ -2,6 +2,7 @@ package ctypes
 
 import (
 	"encoding/xml"
+	"strconv"
 
 	"github.com/mrlijnden/godocx/wml/stypes"
 )
@@ -9,6 +10,7 @@ import (
 // PageNumbering represents the page numbering format in a Word document.
 type PageNumbering struct {
 	Format stypes.NumFmt `xml:"fmt,attr,omitempty"`
+	Start  *int          `xml:"start,attr,omitempty"` // Page number of the first page of the section
 }
 
 // MarshalXML implements the xml.Marshaler interface for the PageNumbering type.
@@ -18,5 +20,9 @@ func (p PageNumbering) MarshalXML(e *xml.Encoder, start xml.StartElement) error
 	if p.Format != "" {
 		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:fmt"}, Value: string(p.Format)})
 	}
+
+	if p.Start != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:start"}, Value: strconv.Itoa(*p.Start)})
+	}
 	return e.EncodeElement("", start)
 }
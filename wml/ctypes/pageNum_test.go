@@ -2,6 +2,7 @@ package ctypes
 
 import (
 	"encoding/xml"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -24,6 +25,11 @@ func TestPageNumbering_MarshalXML(t *testing.T) {
 			input:    PageNumbering{},
 			expected: `<w:pgNumType></w:pgNumType>`,
 		},
+		{
+			name:     "With format and start",
+			input:    PageNumbering{Format: stypes.NumFmtLowerRoman, Start: intPtr(1)},
+			expected: `<w:pgNumType w:fmt="lowerRoman" w:start="1"></w:pgNumType>`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -62,6 +68,11 @@ func TestPageNumbering_UnmarshalXML(t *testing.T) {
 			inputXML: `<w:pgNumType></w:pgNumType>`,
 			expected: PageNumbering{},
 		},
+		{
+			name:     "With start",
+			inputXML: `<w:pgNumType w:start="5"></w:pgNumType>`,
+			expected: PageNumbering{Start: intPtr(5)},
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,6 +87,10 @@ func TestPageNumbering_UnmarshalXML(t *testing.T) {
 			if result.Format != tt.expected.Format {
 				t.Errorf("Expected Format %s but got %s", tt.expected.Format, result.Format)
 			}
+
+			if !reflect.DeepEqual(result.Start, tt.expected.Start) {
+				t.Errorf("Expected Start %v but got %v", tt.expected.Start, result.Start)
+			}
 		})
 	}
 }
@@ -17,6 +17,9 @@ type Paragraph struct {
 	RsidP        *stypes.LongHexNum // Revision Identifier for Paragraph Properties
 	RsidRDefault *stypes.LongHexNum // Default Revision Identifier for Runs
 
+	// Attributes that are not modelled (e.g. w14:paraId), kept for round trip
+	Attr []xml.Attr
+
 	// 1. Paragraph Properties
 	Property *ParagraphProp
 
@@ -25,15 +28,18 @@ type Paragraph struct {
 }
 
 type ParagraphChild struct {
-	Link *Hyperlink // w:hyperlink
-	Run  *Run       // i.e w:r
-}
-
-type Hyperlink struct {
-	XMLName  xml.Name `xml:"http://schemas.openxmlformats.org/wordprocessingml/2006/main hyperlink,omitempty"`
-	ID       string   `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
-	Run      *Run
-	Children []ParagraphChild
+	Link           *Hyperlink      // w:hyperlink
+	Run            *Run            // i.e w:r
+	CmntRangeStart *MarkupRange    // w:commentRangeStart
+	CmntRangeEnd   *MarkupRange    // w:commentRangeEnd
+	BookmarkStart  *BookmarkStart  // w:bookmarkStart
+	BookmarkEnd    *MarkupRange    // w:bookmarkEnd
+	Ins            *RunTrackChange // w:ins
+	Del            *RunTrackChange // w:del
+	MoveFrom       *RunTrackChange // w:moveFrom
+	MoveTo         *RunTrackChange // w:moveTo
+	Sdt            *SdtRun         // w:sdt, content control around runs
+	Raw            *RawXML         // Element not modelled by this package, kept as is
 }
 
 func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
@@ -55,6 +61,7 @@ func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 	if p.RsidRDefault != nil {
 		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:rsidRDefault"}, Value: string(*p.RsidRDefault)})
 	}
+	start.Attr = append(start.Attr, p.Attr...)
 
 	if err = e.EncodeToken(start); err != nil {
 		return err
@@ -68,7 +75,17 @@ func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 		}
 	}
 
-	for _, cElem := range p.Children {
+	if err = marshalParagraphChildren(e, p.Children); err != nil {
+		return err
+	}
+
+	// Closing </w:p> element
+	return e.EncodeToken(start.End())
+}
+
+// marshalParagraphChildren encodes paragraph content elements in order.
+func marshalParagraphChildren(e *xml.Encoder, children []ParagraphChild) (err error) {
+	for _, cElem := range children {
 		if cElem.Run != nil {
 			if err = cElem.Run.MarshalXML(e, xml.StartElement{
 				Name: xml.Name{Local: "w:r"},
@@ -78,16 +95,91 @@ func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 		}
 
 		if cElem.Link != nil {
-			if err = e.EncodeElement(cElem.Link, xml.StartElement{
+			if err = cElem.Link.MarshalXML(e, xml.StartElement{
 				Name: xml.Name{Local: "w:hyperlink"},
 			}); err != nil {
 				return err
 			}
 		}
+
+		if cElem.CmntRangeStart != nil {
+			if err = cElem.CmntRangeStart.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:commentRangeStart"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.CmntRangeEnd != nil {
+			if err = cElem.CmntRangeEnd.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:commentRangeEnd"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.BookmarkStart != nil {
+			if err = cElem.BookmarkStart.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:bookmarkStart"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.BookmarkEnd != nil {
+			if err = cElem.BookmarkEnd.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:bookmarkEnd"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.Ins != nil {
+			if err = cElem.Ins.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:ins"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.Del != nil {
+			if err = cElem.Del.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:del"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.MoveFrom != nil {
+			if err = cElem.MoveFrom.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:moveFrom"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.MoveTo != nil {
+			if err = cElem.MoveTo.MarshalXML(e, xml.StartElement{
+				Name: xml.Name{Local: "w:moveTo"},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.Sdt != nil {
+			if err = cElem.Sdt.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.Raw != nil {
+			if err = cElem.Raw.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
 	}
 
-	// Closing </w:p> element
-	return e.EncodeToken(start.End())
+	return nil
 }
 
 func (p *Paragraph) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
@@ -104,8 +196,11 @@ func (p *Paragraph) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err er
 			p.RsidP = internal.ToPtr(stypes.LongHexNum(attr.Value))
 		case "rsidRDefault":
 			p.RsidRDefault = internal.ToPtr(stypes.LongHexNum(attr.Value))
+		default:
+			p.Attr = append(p.Attr, attr)
 		}
 	}
+	p.Attr = QualifyAttrs(p.Attr)
 
 loop:
 	for {
@@ -116,24 +211,20 @@ loop:
 
 		switch elem := currentToken.(type) {
 		case xml.StartElement:
-			switch elem.Name.Local {
-			case "r":
-				r := NewRun()
-				if err = d.DecodeElement(r, &elem); err != nil {
-					return err
-				}
-
-				p.Children = append(p.Children, ParagraphChild{Run: r})
-			case "pPr":
+			if elem.Name.Local == "pPr" {
 				p.Property = &ParagraphProp{}
 				if err = d.DecodeElement(p.Property, &elem); err != nil {
 					return err
 				}
-			default:
-				if err = d.Skip(); err != nil {
-					return err
-				}
+				continue
+			}
+
+			child, err := unmarshalParagraphChild(d, elem)
+			if err != nil {
+				return err
 			}
+
+			p.Children = append(p.Children, child)
 		case xml.EndElement:
 			break loop
 		}
@@ -142,6 +233,75 @@ loop:
 	return nil
 }
 
+// unmarshalParagraphChild decodes a single paragraph content element. Elements
+// that are not modelled are kept as RawXML.
+func unmarshalParagraphChild(d *xml.Decoder, elem xml.StartElement) (ParagraphChild, error) {
+	switch elem.Name.Local {
+	case "r":
+		r := NewRun()
+		if err := d.DecodeElement(r, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{Run: r}, nil
+	case "hyperlink":
+		link := &Hyperlink{}
+		if err := d.DecodeElement(link, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{Link: link}, nil
+	case "commentRangeStart":
+		rng := &MarkupRange{}
+		if err := d.DecodeElement(rng, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{CmntRangeStart: rng}, nil
+	case "ins", "del", "moveFrom", "moveTo":
+		change := &RunTrackChange{}
+		if err := d.DecodeElement(change, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		switch elem.Name.Local {
+		case "ins":
+			return ParagraphChild{Ins: change}, nil
+		case "del":
+			return ParagraphChild{Del: change}, nil
+		case "moveFrom":
+			return ParagraphChild{MoveFrom: change}, nil
+		}
+		return ParagraphChild{MoveTo: change}, nil
+	case "commentRangeEnd":
+		rng := &MarkupRange{}
+		if err := d.DecodeElement(rng, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{CmntRangeEnd: rng}, nil
+	case "bookmarkStart":
+		bookmark := &BookmarkStart{}
+		if err := d.DecodeElement(bookmark, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{BookmarkStart: bookmark}, nil
+	case "bookmarkEnd":
+		rng := &MarkupRange{}
+		if err := d.DecodeElement(rng, &elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{BookmarkEnd: rng}, nil
+	case "sdt":
+		sdt := &SdtRun{}
+		if err := sdt.UnmarshalXML(d, elem); err != nil {
+			return ParagraphChild{}, err
+		}
+		return ParagraphChild{Sdt: sdt}, nil
+	}
+
+	raw := NewRawXML()
+	if err := raw.UnmarshalXML(d, elem); err != nil {
+		return ParagraphChild{}, err
+	}
+	return ParagraphChild{Raw: raw}, nil
+}
+
 func (p *Paragraph) AddText(text string) *Run {
 	t := TextFromString(text)
 
@@ -0,0 +1,218 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/mrlijnden/godocx/common/constants"
+)
+
+// RawXML holds an element that is not modelled by this package.
+//
+// The element is captured token by token while decoding and written back
+// unchanged when the document is saved, so that content such as w:sdt,
+// w:bookmarkStart, w:ins or mc:AlternateContent survives a load/save round trip
+// at its original position.
+type RawXML struct {
+	Tokens []xml.Token
+}
+
+func NewRawXML() *RawXML {
+	return &RawXML{}
+}
+
+// Name returns the qualified name of the captured element, e.g. "w:sdt".
+func (r RawXML) Name() string {
+	if len(r.Tokens) == 0 {
+		return ""
+	}
+	if start, ok := r.Tokens[0].(xml.StartElement); ok {
+		return start.Name.Local
+	}
+	return ""
+}
+
+func (r RawXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, tok := range r.Tokens {
+		if err := e.EncodeToken(tok); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RawXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var (
+		tok   xml.Token = start
+		err   error
+		scope = map[string]string{}
+		names []xml.Name
+	)
+
+	for {
+		switch elem := tok.(type) {
+		case xml.StartElement:
+			// Namespaces declared inside the captured element keep their prefix
+			for _, attr := range elem.Attr {
+				if attr.Name.Space == "xmlns" {
+					scope[attr.Value] = attr.Name.Local
+				} else if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					scope[attr.Value] = ""
+				}
+			}
+
+			out := xml.StartElement{Name: qualifyName(elem.Name, scope)}
+			for _, attr := range elem.Attr {
+				out.Attr = append(out.Attr, xml.Attr{Name: qualifyName(attr.Name, scope), Value: attr.Value})
+			}
+			out.Attr = append(out.Attr, undeclaredNS(elem, scope)...)
+
+			names = append(names, out.Name)
+			r.Tokens = append(r.Tokens, out)
+		case xml.EndElement:
+			if len(names) == 0 {
+				return fmt.Errorf("raw xml: unexpected end element %s", elem.Name.Local)
+			}
+			r.Tokens = append(r.Tokens, xml.EndElement{Name: names[len(names)-1]})
+			names = names[:len(names)-1]
+			if len(names) == 0 {
+				return nil
+			}
+		default:
+			r.Tokens = append(r.Tokens, xml.CopyToken(tok))
+		}
+
+		if tok, err = d.Token(); err != nil {
+			return err
+		}
+	}
+}
+
+// QualifyAttrs converts attributes decoded by encoding/xml, whose namespaces are
+// URIs, back to their prefixed form (e.g. "w14:paraId") so they can be
+// re-encoded. Namespace declarations among attrs take precedence over the
+// well-known prefixes.
+func QualifyAttrs(attrs []xml.Attr) []xml.Attr {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	scope := map[string]string{}
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" {
+			scope[attr.Value] = attr.Name.Local
+		}
+	}
+
+	out := make([]xml.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		out = append(out, xml.Attr{Name: qualifyName(attr.Name, scope), Value: attr.Value})
+	}
+	return out
+}
+
+// qualifyName turns a namespace URI into the prefix used by WordprocessingML
+// producers. Namespace declarations are kept as "xmlns:prefix".
+func qualifyName(name xml.Name, scope map[string]string) xml.Name {
+	switch {
+	case name.Space == "":
+		return name
+	case name.Space == "xmlns":
+		return xml.Name{Local: "xmlns:" + name.Local}
+	}
+
+	if prefix, ok := scope[name.Space]; ok {
+		if prefix == "" {
+			return xml.Name{Local: name.Local}
+		}
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+
+	if prefix, ok := constants.NSToLocal[name.Space]; ok {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+
+	if !isURI(name.Space) {
+		// Undeclared prefix left untouched by the decoder
+		return xml.Name{Local: name.Space + ":" + name.Local}
+	}
+
+	return xml.Name{Local: nsAlias(name.Space) + ":" + name.Local}
+}
+
+// undeclaredNS returns namespace declarations for URIs used by the element
+// that have neither a well-known prefix nor a declaration in scope.
+func undeclaredNS(elem xml.StartElement, scope map[string]string) []xml.Attr {
+	var (
+		decls []xml.Attr
+		seen  = map[string]bool{}
+	)
+
+	check := func(space string) {
+		if space == "" || space == "xmlns" || !isURI(space) || seen[space] {
+			return
+		}
+		seen[space] = true
+		if _, ok := scope[space]; ok {
+			return
+		}
+		if _, ok := constants.NSToLocal[space]; ok {
+			return
+		}
+		decls = append(decls, xml.Attr{Name: xml.Name{Local: "xmlns:" + nsAlias(space)}, Value: space})
+	}
+
+	check(elem.Name.Space)
+	for _, attr := range elem.Attr {
+		check(attr.Name.Space)
+	}
+
+	return decls
+}
+
+// nsAlias derives a stable prefix for a namespace URI that is not known.
+func nsAlias(space string) string {
+	var h uint32 = 2166136261
+	for i := 0; i < len(space); i++ {
+		h ^= uint32(space[i])
+		h *= 16777619
+	}
+	return fmt.Sprintf("ns%d", h%100000)
+}
+
+func isURI(space string) bool {
+	for i := 0; i < len(space); i++ {
+		if space[i] == ':' || space[i] == '/' {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRawElement creates an empty element, e.g. NewRawElement("w:evenAndOddHeaders").
+// Names and attribute names are given in their prefixed form.
+func NewRawElement(name string, attrs ...xml.Attr) *RawXML {
+	return &RawXML{
+		Tokens: []xml.Token{
+			xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs},
+			xml.EndElement{Name: xml.Name{Local: name}},
+		},
+	}
+}
+
+// Attr returns the value of an attribute of the captured element, e.g. Attr("w:val").
+func (r RawXML) Attr(name string) (string, bool) {
+	if len(r.Tokens) == 0 {
+		return "", false
+	}
+	start, ok := r.Tokens[0].(xml.StartElement)
+	if !ok {
+		return "", false
+	}
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
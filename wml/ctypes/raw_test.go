@@ -0,0 +1,150 @@
+package ctypes
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+const rawTestNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml" ` +
+	`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"`
+
+func marshalToString(t *testing.T, v xml.Marshaler) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+	if err := v.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	if err := encoder.Flush(); err != nil {
+		t.Fatalf("Error flushing encoder: %v", err)
+	}
+	return buf.String()
+}
+
+func TestRawXML_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputXML string
+		expected string
+	}{
+		{
+			name:     "Empty element",
+			inputXML: `<w:bookmarkStart ` + rawTestNS + ` w:id="0" w:name="intro"/>`,
+			expected: `<w:bookmarkStart xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+				`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml" ` +
+				`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" ` +
+				`w:id="0" w:name="intro"></w:bookmarkStart>`,
+		},
+		{
+			name:     "Nested elements and text",
+			inputXML: `<mc:AlternateContent ` + rawTestNS + `><mc:Choice Requires="w14"><w:t>a &amp; b</w:t></mc:Choice></mc:AlternateContent>`,
+			expected: `<mc:AlternateContent xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+				`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml" ` +
+				`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">` +
+				`<mc:Choice Requires="w14"><w:t>a &amp; b</w:t></mc:Choice></mc:AlternateContent>`,
+		},
+		{
+			name:     "Custom prefix declared inside",
+			inputXML: `<x:item xmlns:x="urn:example"><x:val x:k="1"/></x:item>`,
+			expected: `<x:item xmlns:x="urn:example"><x:val x:k="1"></x:val></x:item>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := RawXML{}
+			if err := xml.Unmarshal([]byte(tt.inputXML), &raw); err != nil {
+				t.Fatalf("Error unmarshaling XML: %v", err)
+			}
+
+			got := marshalToString(t, raw)
+			if got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRawXML_Name(t *testing.T) {
+	raw := RawXML{}
+	if err := xml.Unmarshal([]byte(`<w:sdt `+rawTestNS+`><w:sdtPr/></w:sdt>`), &raw); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if raw.Name() != "w:sdt" {
+		t.Errorf("Expected name w:sdt, got %s", raw.Name())
+	}
+}
+
+func TestParagraph_PreservesUnknownContent(t *testing.T) {
+	input := `<w:p ` + rawTestNS + ` w:rsidR="00AB" w14:paraId="1A2B3C4D">` +
+		`<w:proofErr w:type="spellStart"/>` +
+		`<w:r><w:t>Hello</w:t></w:r>` +
+		`<w:proofErr w:type="spellEnd"/>` +
+		`</w:p>`
+
+	p := Paragraph{}
+	if err := xml.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(p.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(p.Children))
+	}
+	if p.Children[0].Raw == nil || p.Children[0].Raw.Name() != "w:proofErr" {
+		t.Errorf("Expected first child to be raw w:proofErr")
+	}
+	if p.Children[1].Run == nil {
+		t.Errorf("Expected second child to be a run")
+	}
+	if p.Children[2].Raw == nil || p.Children[2].Raw.Name() != "w:proofErr" {
+		t.Errorf("Expected third child to be raw w:proofErr")
+	}
+
+	expected := `<w:p w:rsidR="00AB" xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" w14:paraId="1A2B3C4D">` +
+		`<w:proofErr w:type="spellStart"></w:proofErr>` +
+		`<w:r><w:t>Hello</w:t></w:r>` +
+		`<w:proofErr w:type="spellEnd"></w:proofErr>` +
+		`</w:p>`
+
+	if got := marshalToString(t, p); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestRun_PreservesUnknownContent(t *testing.T) {
+	input := `<w:r ` + rawTestNS + `>` +
+		`<w:t>a</w:t>` +
+		`<mc:AlternateContent><mc:Choice Requires="w14"><w:t>b</w:t></mc:Choice><mc:Fallback/></mc:AlternateContent>` +
+		`<w:t>c</w:t>` +
+		`</w:r>`
+
+	r := Run{}
+	if err := xml.Unmarshal([]byte(input), &r); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(r.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(r.Children))
+	}
+	if r.Children[1].Raw == nil || r.Children[1].Raw.Name() != "mc:AlternateContent" {
+		t.Fatalf("Expected second child to be raw mc:AlternateContent")
+	}
+
+	expected := `<w:r xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml" ` +
+		`xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">` +
+		`<w:t>a</w:t>` +
+		`<mc:AlternateContent><mc:Choice Requires="w14"><w:t>b</w:t></mc:Choice><mc:Fallback></mc:Fallback></mc:AlternateContent>` +
+		`<w:t>c</w:t>` +
+		`</w:r>`
+
+	if got := marshalToString(t, r); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
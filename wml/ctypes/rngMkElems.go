@@ -1,12 +1,100 @@
 package ctypes
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+)
 
-// Range Markup elements
+// Range Markup elements: bookmarks that start or end between block-level, row-level or cell-level
+// content (e.g. a bookmark that spans several paragraphs or a table).
 type RngMarkupElem struct {
+	BookmarkStart *BookmarkStart // w:bookmarkStart
+	BookmarkEnd   *MarkupRange   // w:bookmarkEnd
+}
+
+// IsRngMarkupElem reports whether the element, given by its local name, is a range markup element.
+func IsRngMarkupElem(name string) bool {
+	return name == "bookmarkStart" || name == "bookmarkEnd"
 }
 
 func (r RngMarkupElem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	// TODO:
+	if r.BookmarkStart != nil {
+		return r.BookmarkStart.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:bookmarkStart"}})
+	}
+	if r.BookmarkEnd != nil {
+		return r.BookmarkEnd.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:bookmarkEnd"}})
+	}
 	return nil
 }
+
+func (r *RngMarkupElem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "bookmarkStart":
+		r.BookmarkStart = &BookmarkStart{}
+		return d.DecodeElement(r.BookmarkStart, &start)
+	case "bookmarkEnd":
+		r.BookmarkEnd = &MarkupRange{}
+		return d.DecodeElement(r.BookmarkEnd, &start)
+	}
+	return d.Skip()
+}
+
+// MarkupRange marks the start or the end of a range (e.g. w:commentRangeStart, w:commentRangeEnd)
+type MarkupRange struct {
+	// Annotation Identifier
+	ID int `xml:"id,attr"`
+
+	// Annotation Marker Relocated For Custom XML Markup
+	DisplacedByCustomXml *string `xml:"displacedByCustomXml,attr,omitempty"`
+}
+
+func NewMarkupRange(id int) *MarkupRange {
+	return &MarkupRange{ID: id}
+}
+
+func (r MarkupRange) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(r.ID)})
+	if r.DisplacedByCustomXml != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:displacedByCustomXml"}, Value: *r.DisplacedByCustomXml})
+	}
+	return e.EncodeElement("", start)
+}
+
+// Bookmark Start : w:bookmarkStart
+//
+// The bookmark ends at the w:bookmarkEnd element with the same ID.
+type BookmarkStart struct {
+	// Annotation Identifier
+	ID int `xml:"id,attr"`
+
+	// Bookmark Name
+	Name string `xml:"name,attr"`
+
+	// First and Last Table Column Covered By Bookmark, for bookmarks on table cells
+	ColFirst *int `xml:"colFirst,attr,omitempty"`
+	ColLast  *int `xml:"colLast,attr,omitempty"`
+
+	// Annotation Marker Relocated For Custom XML Markup
+	DisplacedByCustomXml *string `xml:"displacedByCustomXml,attr,omitempty"`
+}
+
+func NewBookmarkStart(id int, name string) *BookmarkStart {
+	return &BookmarkStart{ID: id, Name: name}
+}
+
+func (b BookmarkStart) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(b.ID)},
+		{Name: xml.Name{Local: "w:name"}, Value: b.Name},
+	}
+	if b.ColFirst != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:colFirst"}, Value: strconv.Itoa(*b.ColFirst)})
+	}
+	if b.ColLast != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:colLast"}, Value: strconv.Itoa(*b.ColLast)})
+	}
+	if b.DisplacedByCustomXml != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:displacedByCustomXml"}, Value: *b.DisplacedByCustomXml})
+	}
+	return e.EncodeElement("", start)
+}
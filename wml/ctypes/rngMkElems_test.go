@@ -0,0 +1,87 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestParagraph_CommentRange(t *testing.T) {
+	input := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:commentRangeStart w:id="3"/>` +
+		`<w:r><w:t>Reviewed text</w:t></w:r>` +
+		`<w:commentRangeEnd w:id="3" w:displacedByCustomXml="next"/>` +
+		`<w:r><w:commentReference w:id="3"/></w:r>` +
+		`</w:p>`
+
+	var p Paragraph
+	if err := xml.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(p.Children) != 4 {
+		t.Fatalf("Expected 4 children, got %d", len(p.Children))
+	}
+	if p.Children[0].CmntRangeStart == nil || p.Children[0].CmntRangeStart.ID != 3 {
+		t.Errorf("Expected comment range start 3, got %+v", p.Children[0])
+	}
+	if p.Children[2].CmntRangeEnd == nil || p.Children[2].CmntRangeEnd.ID != 3 {
+		t.Errorf("Expected comment range end 3, got %+v", p.Children[2])
+	}
+
+	expected := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:commentRangeStart w:id="3"></w:commentRangeStart>` +
+		`<w:r><w:t>Reviewed text</w:t></w:r>` +
+		`<w:commentRangeEnd w:id="3" w:displacedByCustomXml="next"></w:commentRangeEnd>` +
+		`<w:r><w:commentReference w:id="3"></w:commentReference></w:r>` +
+		`</w:p>`
+	if got := marshalToString(t, p); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestTable_Bookmarks(t *testing.T) {
+	input := `<w:tbl xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:bookmarkStart w:id="0" w:name="wholeTable"/>` +
+		`<w:tblPr/><w:tblGrid/>` +
+		`<w:tr><w:bookmarkStart w:id="1" w:name="cells" w:colFirst="0" w:colLast="1"/>` +
+		`<w:tc><w:p><w:bookmarkStart w:id="2" w:name="text"/><w:r><w:t>Cell</w:t></w:r><w:bookmarkEnd w:id="2"/></w:p>` +
+		`<w:bookmarkEnd w:id="1"/></w:tc></w:tr>` +
+		`<w:bookmarkEnd w:id="0"/>` +
+		`</w:tbl>`
+
+	var tbl Table
+	if err := xml.Unmarshal([]byte(input), &tbl); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(tbl.RngMarkupElems) != 1 || tbl.RngMarkupElems[0].BookmarkStart.Name != "wholeTable" {
+		t.Errorf("Expected table bookmark, got %+v", tbl.RngMarkupElems)
+	}
+	if len(tbl.RowContents) != 2 || tbl.RowContents[1].RngMarkup == nil || tbl.RowContents[1].RngMarkup.BookmarkEnd.ID != 0 {
+		t.Fatalf("Expected a row and a bookmark end, got %+v", tbl.RowContents)
+	}
+	row := tbl.RowContents[0].Row
+	start := row.Contents[0].RngMarkup.BookmarkStart
+	if start == nil || *start.ColFirst != 0 || *start.ColLast != 1 {
+		t.Errorf("Expected cell bookmark, got %+v", row.Contents[0])
+	}
+	cell := row.Contents[1].Cell
+	if cell.Contents[0].Paragraph.Children[0].BookmarkStart.Name != "text" {
+		t.Errorf("Expected paragraph bookmark, got %+v", cell.Contents[0].Paragraph.Children[0])
+	}
+	if cell.Contents[1].RngMarkup == nil || cell.Contents[1].RngMarkup.BookmarkEnd.ID != 1 {
+		t.Errorf("Expected bookmark end in cell, got %+v", cell.Contents[1])
+	}
+
+	expected := `<w:tbl>` +
+		`<w:bookmarkStart w:id="0" w:name="wholeTable"></w:bookmarkStart>` +
+		`<w:tblPr></w:tblPr><w:tblGrid></w:tblGrid>` +
+		`<w:tr><w:bookmarkStart w:id="1" w:name="cells" w:colFirst="0" w:colLast="1"></w:bookmarkStart>` +
+		`<w:tc><w:p><w:bookmarkStart w:id="2" w:name="text"></w:bookmarkStart><w:r><w:t>Cell</w:t></w:r><w:bookmarkEnd w:id="2"></w:bookmarkEnd></w:p>` +
+		`<w:bookmarkEnd w:id="1"></w:bookmarkEnd></w:tc></w:tr>` +
+		`<w:bookmarkEnd w:id="0"></w:bookmarkEnd>` +
+		`</w:tbl>`
+	if got := marshalToString(t, tbl); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -80,20 +80,14 @@ loop:
 				}
 
 				r.PropException = &propEx
-			case "tc":
-				cell := Cell{}
-				if err = d.DecodeElement(&cell, &elem); err != nil {
-					return err
-				}
-
-				r.Contents = append(r.Contents, TRCellContent{
-					Cell: &cell,
-				})
-
 			default:
-				if err = d.Skip(); err != nil {
+				content, ok, err := unmarshalCellContent(d, elem)
+				if err != nil {
 					return err
 				}
+				if ok {
+					r.Contents = append(r.Contents, content)
+				}
 			}
 		case xml.EndElement:
 			break loop
@@ -105,22 +99,84 @@ loop:
 
 type TRCellContent struct {
 	Cell *Cell `xml:"tc,omitempty"`
+
+	// Bookmark start or end between cells
+	RngMarkup *RngMarkupElem `xml:"-"`
+
+	// Content control around cells
+	Sdt *SdtCell `xml:"-"`
 }
 
 func (c TRCellContent) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if c.Cell != nil {
 		return c.Cell.MarshalXML(e, xml.StartElement{})
 	}
+	if c.RngMarkup != nil {
+		return c.RngMarkup.MarshalXML(e, xml.StartElement{})
+	}
+	if c.Sdt != nil {
+		return c.Sdt.MarshalXML(e, xml.StartElement{})
+	}
 	return nil
 }
 
+// unmarshalCellContent decodes a cell-level element of a row: a cell, a content control or a bookmark.
+// Other elements are skipped and ok is false.
+func unmarshalCellContent(d *xml.Decoder, elem xml.StartElement) (content TRCellContent, ok bool, err error) {
+	switch elem.Name.Local {
+	case "tc":
+		content.Cell = &Cell{}
+		err = d.DecodeElement(content.Cell, &elem)
+	case "sdt":
+		content.Sdt = &SdtCell{}
+		err = content.Sdt.UnmarshalXML(d, elem)
+	case "bookmarkStart", "bookmarkEnd":
+		content.RngMarkup = &RngMarkupElem{}
+		err = content.RngMarkup.UnmarshalXML(d, elem)
+	default:
+		return content, false, d.Skip()
+	}
+	return content, err == nil, err
+}
+
 type RowContent struct {
 	Row *Row `xml:"tr,omitempty"`
+
+	// Bookmark start or end between rows
+	RngMarkup *RngMarkupElem `xml:"-"`
+
+	// Content control around rows
+	Sdt *SdtRow `xml:"-"`
 }
 
 func (r RowContent) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if r.Row != nil {
 		return r.Row.MarshalXML(e, xml.StartElement{})
 	}
+	if r.RngMarkup != nil {
+		return r.RngMarkup.MarshalXML(e, xml.StartElement{})
+	}
+	if r.Sdt != nil {
+		return r.Sdt.MarshalXML(e, xml.StartElement{})
+	}
 	return nil
 }
+
+// unmarshalRowContent decodes a row-level element of a table: a row, a content control or a bookmark.
+// Other elements are skipped and ok is false.
+func unmarshalRowContent(d *xml.Decoder, elem xml.StartElement) (content RowContent, ok bool, err error) {
+	switch elem.Name.Local {
+	case "tr":
+		content.Row = &Row{}
+		err = d.DecodeElement(content.Row, &elem)
+	case "sdt":
+		content.Sdt = &SdtRow{}
+		err = content.Sdt.UnmarshalXML(d, elem)
+	case "bookmarkStart", "bookmarkEnd":
+		content.RngMarkup = &RngMarkupElem{}
+		err = content.RngMarkup.UnmarshalXML(d, elem)
+	default:
+		return content, false, d.Skip()
+	}
+	return content, err == nil, err
+}
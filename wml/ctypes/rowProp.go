@@ -296,11 +296,11 @@ type TRPrChange struct {
 	ID     int         `xml:"id,attr"`
 	Author string      `xml:"author,attr"`
 	Date   *string     `xml:"date,attr,omitempty"`
-	Prop   RowProperty `xml:"tcPr"`
+	Prop   RowProperty `xml:"trPr"`
 }
 
 func (t TRPrChange) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	start.Name.Local = "w:TRPrChange"
+	start.Name.Local = "w:trPrChange"
 
 	start.Attr = []xml.Attr{
 		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(t.ID)},
@@ -15,6 +15,9 @@ type Run struct {
 	RsidR   *stypes.LongHexNum // Revision Identifier for Run
 	RsidDel *stypes.LongHexNum // Revision Identifier for Run Deletion
 
+	// Attributes that are not modelled, kept for round trip
+	Attr []xml.Attr
+
 	// Sequence:
 
 	//1. Run Properties
@@ -82,6 +85,9 @@ type RunChild struct {
 	//Symbol Character
 	Sym *Sym `xml:"sym,omitempty"`
 
+	//Complex Field Character
+	FldChar *FieldChar `xml:"fldChar,omitempty"`
+
 	//Page Number Block
 	PgNumBlock *Empty `xml:"pgNum,omitempty"`
 
@@ -94,11 +100,13 @@ type RunChild struct {
 	//TODO:
 	// 	w:object    Inline Embedded Object
 	// w:pict    VML Object
-	// w:fldChar    Complex Field Character
 	// w:ruby    Phonetic Guide
-	// w:footnoteReference    Footnote Reference
-	// w:endnoteReference    Endnote Reference
-	// w:commentReference    Comment Content Reference Mark
+
+	//Footnote Reference
+	FootnoteReference *FtnEdnRef `xml:"footnoteReference,omitempty"`
+
+	//Endnote Reference
+	EndnoteReference *FtnEdnRef `xml:"endnoteReference,omitempty"`
 
 	//Comment Content Reference Mark
 	CmntRef *Markup `xml:"commentReference,omitempty"`
@@ -111,6 +119,9 @@ type RunChild struct {
 
 	//Position of Last Calculated Page Break
 	LastRenPgBrk *Empty `xml:"lastRenderedPageBreak,omitempty"`
+
+	// Element not modelled by this package, kept as is
+	Raw *RawXML `xml:"-"`
 }
 
 func NewRun() *Run {
@@ -129,6 +140,7 @@ func (r Run) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 	if r.RsidDel != nil {
 		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:rsidDel"}, Value: string(*r.RsidDel)})
 	}
+	start.Attr = append(start.Attr, r.Attr...)
 
 	err = e.EncodeToken(start)
 	if err != nil {
@@ -161,8 +173,11 @@ func (r *Run) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
 			r.RsidR = internal.ToPtr(stypes.LongHexNum(attr.Value))
 		case "rsidDel":
 			r.RsidDel = internal.ToPtr(stypes.LongHexNum(attr.Value))
+		default:
+			r.Attr = append(r.Attr, attr)
 		}
 	}
+	r.Attr = QualifyAttrs(r.Attr)
 
 loop:
 	for {
@@ -173,51 +188,20 @@ loop:
 
 		switch elem := currentToken.(type) {
 		case xml.StartElement:
-			switch elem.Name.Local {
-			case "t":
-				txt := NewText()
-				if err = d.DecodeElement(txt, &elem); err != nil {
-					return err
-				}
-
-				r.Children = append(r.Children, RunChild{Text: txt})
-			case "rPr":
+			if elem.Name.Local == "rPr" {
 				r.Property = &RunProperty{}
 				if err = d.DecodeElement(r.Property, &elem); err != nil {
 					return err
 				}
-			case "tab":
-				tabElem := &Empty{}
-				if err = d.DecodeElement(tabElem, &elem); err != nil {
-					return err
-				}
-
-				r.Children = append(r.Children, RunChild{
-					Tab: tabElem,
-				})
-			case "br":
-				br := Break{}
-				if err = d.DecodeElement(&br, &elem); err != nil {
-					return err
-				}
-
-				r.Children = append(r.Children, RunChild{
-					Break: &br,
-				})
-			case "drawing":
-				drawingElem := &dml.Drawing{}
-				if err = d.DecodeElement(drawingElem, &elem); err != nil {
-					return err
-				}
+				continue
+			}
 
-				r.Children = append(r.Children, RunChild{
-					Drawing: drawingElem,
-				})
-			default:
-				if err = d.Skip(); err != nil {
-					return err
-				}
+			child, err := unmarshalRunChild(d, elem)
+			if err != nil {
+				return err
 			}
+
+			r.Children = append(r.Children, child)
 		case xml.EndElement:
 			break loop
 		}
@@ -226,6 +210,111 @@ loop:
 	return nil
 }
 
+// runTextChildren maps the text-carrying run content elements to their RunChild field.
+var runTextChildren = map[string]func(*Text) RunChild{
+	"t":            func(t *Text) RunChild { return RunChild{Text: t} },
+	"delText":      func(t *Text) RunChild { return RunChild{DelText: t} },
+	"instrText":    func(t *Text) RunChild { return RunChild{InstrText: t} },
+	"delInstrText": func(t *Text) RunChild { return RunChild{DelInstrText: t} },
+}
+
+// runEmptyChildren maps the run content elements that carry no data to their RunChild field.
+var runEmptyChildren = map[string]func(*Empty) RunChild{
+	"noBreakHyphen":         func(e *Empty) RunChild { return RunChild{NoBreakHyphen: e} },
+	"softHyphen":            func(e *Empty) RunChild { return RunChild{SoftHyphen: e} },
+	"dayShort":              func(e *Empty) RunChild { return RunChild{DayShort: e} },
+	"monthShort":            func(e *Empty) RunChild { return RunChild{MonthShort: e} },
+	"yearShort":             func(e *Empty) RunChild { return RunChild{YearShort: e} },
+	"dayLong":               func(e *Empty) RunChild { return RunChild{DayLong: e} },
+	"monthLong":             func(e *Empty) RunChild { return RunChild{MonthLong: e} },
+	"yearLong":              func(e *Empty) RunChild { return RunChild{YearLong: e} },
+	"annotationRef":         func(e *Empty) RunChild { return RunChild{AnnotationRef: e} },
+	"footnoteRef":           func(e *Empty) RunChild { return RunChild{FootnoteRef: e} },
+	"endnoteRef":            func(e *Empty) RunChild { return RunChild{EndnoteRef: e} },
+	"separator":             func(e *Empty) RunChild { return RunChild{Separator: e} },
+	"continuationSeparator": func(e *Empty) RunChild { return RunChild{ContSeparator: e} },
+	"pgNum":                 func(e *Empty) RunChild { return RunChild{PgNumBlock: e} },
+	"cr":                    func(e *Empty) RunChild { return RunChild{CarrRtn: e} },
+	"tab":                   func(e *Empty) RunChild { return RunChild{Tab: e} },
+	"lastRenderedPageBreak": func(e *Empty) RunChild { return RunChild{LastRenPgBrk: e} },
+}
+
+// unmarshalRunChild decodes a single run content element. Elements that are
+// not modelled are kept as RawXML.
+func unmarshalRunChild(d *xml.Decoder, elem xml.StartElement) (RunChild, error) {
+	if newChild, ok := runTextChildren[elem.Name.Local]; ok {
+		txt := NewText()
+		if err := d.DecodeElement(txt, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return newChild(txt), nil
+	}
+
+	if newChild, ok := runEmptyChildren[elem.Name.Local]; ok {
+		if err := d.Skip(); err != nil {
+			return RunChild{}, err
+		}
+		return newChild(&Empty{}), nil
+	}
+
+	switch elem.Name.Local {
+	case "br":
+		br := Break{}
+		if err := d.DecodeElement(&br, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{Break: &br}, nil
+	case "sym":
+		sym := Sym{}
+		if err := d.DecodeElement(&sym, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{Sym: &sym}, nil
+	case "fldChar":
+		fldChar := FieldChar{}
+		if err := d.DecodeElement(&fldChar, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{FldChar: &fldChar}, nil
+	case "ptab":
+		ptab := PTab{}
+		if err := d.DecodeElement(&ptab, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{PTab: &ptab}, nil
+	case "commentReference":
+		ref := Markup{}
+		if err := d.DecodeElement(&ref, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{CmntRef: &ref}, nil
+	case "footnoteReference":
+		ref := FtnEdnRef{}
+		if err := d.DecodeElement(&ref, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{FootnoteReference: &ref}, nil
+	case "endnoteReference":
+		ref := FtnEdnRef{}
+		if err := d.DecodeElement(&ref, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{EndnoteReference: &ref}, nil
+	case "drawing":
+		drawingElem := &dml.Drawing{}
+		if err := d.DecodeElement(drawingElem, &elem); err != nil {
+			return RunChild{}, err
+		}
+		return RunChild{Drawing: drawingElem}, nil
+	}
+
+	raw := NewRawXML()
+	if err := raw.UnmarshalXML(d, elem); err != nil {
+		return RunChild{}, err
+	}
+	return RunChild{Raw: raw}, nil
+}
+
 // Sym represents a symbol character in a document.
 type Sym struct {
 	Font *string `xml:"font,attr,omitempty"`
@@ -293,6 +382,8 @@ func (r *Run) MarshalChild(e *xml.Encoder) error {
 			err = child.ContSeparator.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:continuationSeparator"}})
 		case child.Sym != nil:
 			err = child.Sym.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:sym"}})
+		case child.FldChar != nil:
+			err = child.FldChar.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:fldChar"}})
 		case child.PgNumBlock != nil:
 			err = child.PgNumBlock.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:pgNum"}})
 		case child.CarrRtn != nil:
@@ -307,7 +398,12 @@ func (r *Run) MarshalChild(e *xml.Encoder) error {
 			err = child.PTab.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:ptab"}})
 		case child.CmntRef != nil:
 			err = child.CmntRef.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:commentReference"}})
-
+		case child.FootnoteReference != nil:
+			err = child.FootnoteReference.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:footnoteReference"}})
+		case child.EndnoteReference != nil:
+			err = child.EndnoteReference.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:endnoteReference"}})
+		case child.Raw != nil:
+			err = child.Raw.MarshalXML(e, xml.StartElement{})
 		}
 
 		if err != nil {
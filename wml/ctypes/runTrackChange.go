@@ -0,0 +1,86 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// Inserted or Deleted Run Content : w:ins, w:del
+//
+// The element name is given by the paragraph child that holds it (ParagraphChild.Ins or ParagraphChild.Del).
+type RunTrackChange struct {
+	ID     int
+	Author string
+	Date   *string
+
+	// Attributes that are not modelled (e.g. w16du:dateUtc), kept for round trip
+	Attr []xml.Attr
+
+	// Runs and other paragraph content affected by the revision
+	Children []ParagraphChild
+}
+
+func NewRunTrackChange(id int, author string, date *string) *RunTrackChange {
+	return &RunTrackChange{ID: id, Author: author, Date: date}
+}
+
+func (r RunTrackChange) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(r.ID)},
+		{Name: xml.Name{Local: "w:author"}, Value: r.Author},
+	}
+	if r.Date != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:date"}, Value: *r.Date})
+	}
+	start.Attr = append(start.Attr, r.Attr...)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := marshalParagraphChildren(e, r.Children); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (r *RunTrackChange) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			id, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				return err
+			}
+			r.ID = id
+		case "author":
+			r.Author = attr.Value
+		case "date":
+			date := attr.Value
+			r.Date = &date
+		default:
+			attrs = append(attrs, attr)
+		}
+	}
+	r.Attr = QualifyAttrs(attrs)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			child, err := unmarshalParagraphChild(d, elem)
+			if err != nil {
+				return err
+			}
+			r.Children = append(r.Children, child)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/mrlijnden/godocx/internal"
+)
+
+func TestParagraph_RunTrackChange(t *testing.T) {
+	input := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:pPr><w:rPr><w:ins w:id="1" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z"/></w:rPr></w:pPr>` +
+		`<w:ins w:id="2" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z"><w:r><w:t>new</w:t></w:r></w:ins>` +
+		`<w:del w:id="3" w:author="John Smith"><w:r><w:delText xml:space="preserve">old </w:delText></w:r></w:del>` +
+		`<w:moveTo w:id="4" w:author="John Smith"><w:r><w:t>moved</w:t></w:r></w:moveTo>` +
+		`</w:p>`
+
+	var p Paragraph
+	if err := xml.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if p.Property.RunProperty.Ins == nil || p.Property.RunProperty.Ins.ID != 1 {
+		t.Errorf("Expected inserted paragraph mark 1, got %+v", p.Property.RunProperty)
+	}
+	if len(p.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(p.Children))
+	}
+	ins := p.Children[0].Ins
+	if ins == nil || ins.ID != 2 || ins.Author != "Jane Doe" || *ins.Date != "2024-05-01T10:00:00Z" {
+		t.Errorf("Expected insertion 2, got %+v", p.Children[0])
+	}
+	del := p.Children[1].Del
+	if del == nil || del.ID != 3 || del.Date != nil || del.Children[0].Run.Children[0].DelText == nil {
+		t.Errorf("Expected deletion 3, got %+v", p.Children[1])
+	}
+	if p.Children[2].MoveTo == nil || p.Children[2].MoveTo.ID != 4 {
+		t.Errorf("Expected move 4, got %+v", p.Children[2])
+	}
+
+	expected := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:pPr><w:rPr><w:ins w:id="1" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z"></w:ins></w:rPr></w:pPr>` +
+		`<w:ins w:id="2" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z"><w:r><w:t>new</w:t></w:r></w:ins>` +
+		`<w:del w:id="3" w:author="John Smith"><w:r><w:delText xml:space="preserve">old </w:delText></w:r></w:del>` +
+		`<w:moveTo w:id="4" w:author="John Smith"><w:r><w:t>moved</w:t></w:r></w:moveTo>` +
+		`</w:p>`
+	if got := marshalToString(t, p); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestRPrChange_MarshalXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    RunProperty
+		expected string
+	}{
+		{
+			name: "Previous properties",
+			input: RunProperty{
+				Bold: OnOffFromBool(true),
+				Change: &RPrChange{
+					ID:      5,
+					Author:  "Jane Doe",
+					Date:    internal.ToPtr("2024-05-01T10:00:00Z"),
+					RunProp: &RunProperty{Italic: OnOffFromBool(true)},
+				},
+			},
+			expected: `<w:rPr><w:b w:val="true"></w:b>` +
+				`<w:rPrChange w:id="5" w:author="Jane Doe" w:date="2024-05-01T10:00:00Z"><w:rPr><w:i w:val="true"></w:i></w:rPr></w:rPrChange>` +
+				`</w:rPr>`,
+		},
+		{
+			name: "No previous properties",
+			input: RunProperty{
+				Bold:   OnOffFromBool(true),
+				Change: &RPrChange{ID: 6, Author: "Jane Doe"},
+			},
+			expected: `<w:rPr><w:b w:val="true"></w:b><w:rPrChange w:id="6" w:author="Jane Doe"><w:rPr></w:rPr></w:rPrChange></w:rPr>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := marshalToString(t, tt.input); got != tt.expected {
+				t.Errorf("Expected XML:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+
+	var rp RunProperty
+	input := `<w:rPr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:b/>` +
+		`<w:rPrChange w:id="5" w:author="Jane Doe"><w:rPr><w:i/></w:rPr></w:rPrChange></w:rPr>`
+	if err := xml.Unmarshal([]byte(input), &rp); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if rp.Change == nil || rp.Change.ID != 5 || rp.Change.RunProp.Italic == nil {
+		t.Errorf("Expected run property change 5, got %+v", rp.Change)
+	}
+}
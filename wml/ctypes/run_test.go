@@ -98,3 +98,47 @@ func TestSym_UnmarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestRun_UnmarshalAllChildren(t *testing.T) {
+	content := `<w:t>text</w:t><w:delText>gone</w:delText><w:instrText xml:space="preserve"> PAGE </w:instrText>` +
+		`<w:delInstrText> DATE </w:delInstrText><w:noBreakHyphen></w:noBreakHyphen><w:softHyphen></w:softHyphen>` +
+		`<w:dayShort></w:dayShort><w:monthShort></w:monthShort><w:yearShort></w:yearShort>` +
+		`<w:dayLong></w:dayLong><w:monthLong></w:monthLong><w:yearLong></w:yearLong>` +
+		`<w:annotationRef></w:annotationRef><w:footnoteRef></w:footnoteRef><w:endnoteRef></w:endnoteRef>` +
+		`<w:separator></w:separator><w:continuationSeparator></w:continuationSeparator>` +
+		`<w:sym w:font="Wingdings" w:char="F0E0"></w:sym><w:fldChar w:fldCharType="begin"></w:fldChar>` +
+		`<w:pgNum></w:pgNum><w:cr></w:cr><w:tab></w:tab><w:br w:type="page"></w:br>` +
+		`<w:commentReference w:id="3"></w:commentReference>` +
+		`<w:footnoteReference w:id="1"></w:footnoteReference><w:endnoteReference w:id="2"></w:endnoteReference>` +
+		`<w:ptab w:alignment="right" w:relativeTo="margin" w:leader="dot"></w:ptab>` +
+		`<w:lastRenderedPageBreak></w:lastRenderedPageBreak>`
+
+	input := `<w:r xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` + content + `</w:r>`
+
+	r := Run{}
+	if err := xml.Unmarshal([]byte(input), &r); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(r.Children) != 28 {
+		t.Fatalf("Expected 28 children, got %d", len(r.Children))
+	}
+
+	for i, child := range r.Children {
+		if child.Raw != nil {
+			t.Errorf("Child %d (%s) was not decoded into a typed field", i, child.Raw.Name())
+		}
+	}
+
+	if r.Children[2].InstrText == nil || r.Children[2].InstrText.Text != " PAGE " {
+		t.Errorf("Expected instrText ' PAGE '")
+	}
+	if r.Children[18].FldChar == nil || r.Children[18].FldChar.FieldCharType != "begin" {
+		t.Errorf("Expected fldChar begin")
+	}
+
+	expected := `<w:r xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` + content + `</w:r>`
+	if got := marshalToString(t, r); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -3,6 +3,7 @@ package ctypes
 import (
 	"encoding/xml"
 	"fmt"
+	"strconv"
 
 	"github.com/mrlijnden/godocx/wml/stypes"
 )
@@ -125,6 +126,16 @@ type RunProperty struct {
 
 	//39.Office Open XML Math
 	OMath *OnOff `xml:"oMath,omitempty"`
+
+	//40.Revision Information for Run Properties
+	Change *RPrChange `xml:"rPrChange,omitempty"`
+
+	// Inserted, Deleted and Moved Paragraph Mark; only used in the run properties of a paragraph mark,
+	// where they come before the other properties
+	Ins      *TrackChange `xml:"ins,omitempty"`
+	Del      *TrackChange `xml:"del,omitempty"`
+	MoveFrom *TrackChange `xml:"moveFrom,omitempty"`
+	MoveTo   *TrackChange `xml:"moveTo,omitempty"`
 }
 
 // NewRunProperty creates a new RunProperty with default values.
@@ -145,6 +156,27 @@ func (rp RunProperty) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
+	// Inserted, Deleted and Moved Paragraph Mark
+	marks := []struct {
+		elem    *TrackChange
+		XMLName string
+	}{
+		{rp.Ins, "w:ins"},
+		{rp.Del, "w:del"},
+		{rp.MoveFrom, "w:moveFrom"},
+		{rp.MoveTo, "w:moveTo"},
+	}
+	for _, mark := range marks {
+		if mark.elem == nil {
+			continue
+		}
+		if err = mark.elem.MarshalXML(e, xml.StartElement{
+			Name: xml.Name{Local: mark.XMLName},
+		}); err != nil {
+			return fmt.Errorf("paragraph mark revision `%s`: %w", mark.XMLName, err)
+		}
+	}
+
 	// 1. Referenced Character Style
 	if rp.Style != nil {
 		if err = rp.Style.MarshalXML(e, xml.StartElement{
@@ -366,5 +398,50 @@ func (rp RunProperty) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		}
 	}
 
+	//40.Revision Information for Run Properties
+	if rp.Change != nil {
+		if err = rp.Change.MarshalXML(e, xml.StartElement{}); err != nil {
+			return fmt.Errorf("run property change: %w", err)
+		}
+	}
+
 	return e.EncodeToken(start.End())
 }
+
+// Revision Information for Run Properties : w:rPrChange
+//
+// RunProp holds the run properties as they were before the change.
+type RPrChange struct {
+	ID      int          `xml:"id,attr"`
+	Author  string       `xml:"author,attr"`
+	Date    *string      `xml:"date,attr,omitempty"`
+	RunProp *RunProperty `xml:"rPr"`
+}
+
+func (r RPrChange) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:rPrChange"
+
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(r.ID)},
+		{Name: xml.Name{Local: "w:author"}, Value: r.Author},
+	}
+
+	if r.Date != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:date"}, Value: *r.Date})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	// The properties before the change, possibly none
+	runProp := r.RunProp
+	if runProp == nil {
+		runProp = &RunProperty{}
+	}
+	if err := runProp.MarshalXML(e, xml.StartElement{}); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
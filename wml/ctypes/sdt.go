@@ -0,0 +1,701 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+// SdtProperty represents the properties of a structured document tag (content control), w:sdtPr.
+type SdtProperty struct {
+	RunProperty   *RunProperty                     // w:rPr, formatting of the content
+	Alias         *CTString                        // w:alias, friendly name
+	Tag           *CTString                        // w:tag
+	ID            *DecimalNum                      // w:id
+	Lock          *GenSingleStrVal[stypes.SdtLock] // w:lock
+	Placeholder   *SdtPlaceholder                  // w:placeholder
+	Temporary     *OnOff                           // w:temporary, remove the control when its content is edited
+	ShowingPlcHdr *OnOff                           // w:showingPlcHdr, the content is the placeholder text
+	DataBinding   *DataBinding                     // w:dataBinding
+
+	// Type of the control; none of them is set for a rich text control that does not say so
+	RichText     *Empty       // w:richText
+	Text         *SdtText     // w:text, plain text
+	ComboBox     *SdtList     // w:comboBox
+	DropDownList *SdtList     // w:dropDownList
+	Date         *SdtDate     // w:date, date picker
+	Picture      *Empty       // w:picture
+	Group        *Empty       // w:group
+	Checkbox     *SdtCheckbox // w14:checkbox
+
+	// Elements that are not modelled, kept for round trip. Those of the main namespace, such as
+	// w:docPartObj, are written before the type of the control, extensions after it.
+	Raw []RawXML
+}
+
+// SdtPlaceholder references the building block that holds the placeholder text of a control.
+type SdtPlaceholder struct {
+	DocPart string
+}
+
+// DataBinding maps the content of a control to an element of a custom XML part.
+type DataBinding struct {
+	PrefixMappings *string
+	XPath          string
+	StoreItemID    *string
+}
+
+// SdtText holds the settings of a plain text control.
+type SdtText struct {
+	MultiLine *stypes.OnOff // Allow line breaks
+}
+
+// SdtList holds the entries of a combo box or drop-down list control.
+type SdtList struct {
+	LastValue *string
+	Items     []SdtListItem
+}
+
+// SdtListItem is an entry of a combo box or drop-down list control.
+type SdtListItem struct {
+	DisplayText string
+	Value       string
+}
+
+// SdtDate holds the settings of a date picker control.
+type SdtDate struct {
+	FullDate          *string   // Selected date, in ISO 8601 format
+	DateFormat        *CTString // Word date-time picture of the displayed date, such as "d MMMM yyyy"
+	Lid               *CTString // Language of the displayed date
+	StoreMappedDataAs *CTString
+	Calendar          *CTString
+}
+
+// SdtCheckbox holds the state of a checkbox control, an extension of Word 2010.
+type SdtCheckbox struct {
+	Checked        bool
+	CheckedState   *SdtCheckboxSymbol
+	UncheckedState *SdtCheckboxSymbol
+}
+
+// SdtCheckboxSymbol is the symbol shown by a checkbox in one of its states.
+type SdtCheckboxSymbol struct {
+	Val  string // Hexadecimal code of the character, such as "2612"
+	Font string
+}
+
+func (p SdtProperty) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	start = xml.StartElement{Name: xml.Name{Local: "w:sdtPr"}}
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if p.RunProperty != nil {
+		if err = p.RunProperty.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	elems := []struct {
+		name string
+		elem xml.Marshaler
+		set  bool
+	}{
+		{"w:alias", p.Alias, p.Alias != nil},
+		{"w:tag", p.Tag, p.Tag != nil},
+		{"w:id", p.ID, p.ID != nil},
+		{"w:lock", p.Lock, p.Lock != nil},
+		{"w:placeholder", p.Placeholder, p.Placeholder != nil},
+		{"w:temporary", p.Temporary, p.Temporary != nil},
+		{"w:showingPlcHdr", p.ShowingPlcHdr, p.ShowingPlcHdr != nil},
+		{"w:dataBinding", p.DataBinding, p.DataBinding != nil},
+	}
+	for _, elem := range elems {
+		if !elem.set {
+			continue
+		}
+		if err = elem.elem.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: elem.name}}); err != nil {
+			return err
+		}
+	}
+
+	for _, raw := range p.Raw {
+		if strings.HasPrefix(raw.Name(), "w:") {
+			if err = raw.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	types := []struct {
+		name string
+		elem xml.Marshaler
+		set  bool
+	}{
+		{"w:comboBox", p.ComboBox, p.ComboBox != nil},
+		{"w:date", p.Date, p.Date != nil},
+		{"w:dropDownList", p.DropDownList, p.DropDownList != nil},
+		{"w:picture", p.Picture, p.Picture != nil},
+		{"w:richText", p.RichText, p.RichText != nil},
+		{"w:text", p.Text, p.Text != nil},
+		{"w:group", p.Group, p.Group != nil},
+		{"w14:checkbox", p.Checkbox, p.Checkbox != nil},
+	}
+	for _, elem := range types {
+		if !elem.set {
+			continue
+		}
+		if err = elem.elem.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: elem.name}}); err != nil {
+			return err
+		}
+	}
+
+	for _, raw := range p.Raw {
+		if !strings.HasPrefix(raw.Name(), "w:") {
+			if err = raw.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (p *SdtProperty) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			var target interface{}
+			switch elem.Name.Local {
+			case "rPr":
+				p.RunProperty = &RunProperty{}
+				target = p.RunProperty
+			case "alias":
+				p.Alias = &CTString{}
+				target = p.Alias
+			case "tag":
+				p.Tag = &CTString{}
+				target = p.Tag
+			case "id":
+				p.ID = &DecimalNum{}
+				target = p.ID
+			case "lock":
+				p.Lock = &GenSingleStrVal[stypes.SdtLock]{}
+				target = p.Lock
+			case "placeholder":
+				p.Placeholder = &SdtPlaceholder{}
+				target = p.Placeholder
+			case "temporary":
+				p.Temporary = &OnOff{}
+				target = p.Temporary
+			case "showingPlcHdr":
+				p.ShowingPlcHdr = &OnOff{}
+				target = p.ShowingPlcHdr
+			case "dataBinding":
+				p.DataBinding = &DataBinding{}
+				target = p.DataBinding
+			case "richText":
+				p.RichText = &Empty{}
+				target = p.RichText
+			case "text":
+				p.Text = &SdtText{}
+				target = p.Text
+			case "comboBox":
+				p.ComboBox = &SdtList{}
+				target = p.ComboBox
+			case "dropDownList":
+				p.DropDownList = &SdtList{}
+				target = p.DropDownList
+			case "date":
+				p.Date = &SdtDate{}
+				target = p.Date
+			case "picture":
+				p.Picture = &Empty{}
+				target = p.Picture
+			case "group":
+				p.Group = &Empty{}
+				target = p.Group
+			case "checkbox":
+				p.Checkbox = &SdtCheckbox{}
+				target = p.Checkbox
+			}
+
+			if target == nil {
+				raw := RawXML{}
+				if err = raw.UnmarshalXML(d, elem); err != nil {
+					return err
+				}
+				p.Raw = append(p.Raw, raw)
+				continue
+			}
+			if err = d.DecodeElement(target, &elem); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (p SdtPlaceholder) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err = NewCTString(p.DocPart).MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:docPart"}}); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (p *SdtPlaceholder) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var placeholder struct {
+		DocPart CTString `xml:"docPart"`
+	}
+	if err := d.DecodeElement(&placeholder, &start); err != nil {
+		return err
+	}
+	p.DocPart = placeholder.DocPart.Val
+	return nil
+}
+
+func (b DataBinding) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if b.PrefixMappings != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:prefixMappings"}, Value: *b.PrefixMappings})
+	}
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:xpath"}, Value: b.XPath})
+	if b.StoreItemID != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:storeItemID"}, Value: *b.StoreItemID})
+	}
+	return e.EncodeElement("", start)
+}
+
+func (b *DataBinding) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		value := attr.Value
+		switch attr.Name.Local {
+		case "prefixMappings":
+			b.PrefixMappings = &value
+		case "xpath":
+			b.XPath = value
+		case "storeItemID":
+			b.StoreItemID = &value
+		}
+	}
+	return d.Skip()
+}
+
+func (t SdtText) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.MultiLine != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:multiLine"}, Value: string(*t.MultiLine)})
+	}
+	return e.EncodeElement("", start)
+}
+
+func (t *SdtText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "multiLine" {
+			multiLine, err := stypes.OnOffFromStr(attr.Value)
+			if err != nil {
+				return err
+			}
+			t.MultiLine = &multiLine
+		}
+	}
+	return d.Skip()
+}
+
+func (l SdtList) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	if l.LastValue != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:lastValue"}, Value: *l.LastValue})
+	}
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, item := range l.Items {
+		itemStart := xml.StartElement{Name: xml.Name{Local: "w:listItem"}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: "w:displayText"}, Value: item.DisplayText},
+			{Name: xml.Name{Local: "w:value"}, Value: item.Value},
+		}}
+		if err = e.EncodeElement("", itemStart); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (l *SdtList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "lastValue" {
+			value := attr.Value
+			l.LastValue = &value
+		}
+	}
+
+	var list struct {
+		Items []struct {
+			DisplayText string `xml:"displayText,attr"`
+			Value       string `xml:"value,attr"`
+		} `xml:"listItem"`
+	}
+	if err := d.DecodeElement(&list, &start); err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		l.Items = append(l.Items, SdtListItem{DisplayText: item.DisplayText, Value: item.Value})
+	}
+	return nil
+}
+
+func (s SdtDate) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	if s.FullDate != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:fullDate"}, Value: *s.FullDate})
+	}
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := []struct {
+		name string
+		elem *CTString
+	}{
+		{"w:dateFormat", s.DateFormat},
+		{"w:lid", s.Lid},
+		{"w:storeMappedDataAs", s.StoreMappedDataAs},
+		{"w:calendar", s.Calendar},
+	}
+	for _, elem := range elems {
+		if elem.elem == nil {
+			continue
+		}
+		if err = elem.elem.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: elem.name}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (s *SdtDate) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "fullDate" {
+			value := attr.Value
+			s.FullDate = &value
+		}
+	}
+
+	var date struct {
+		DateFormat        *CTString `xml:"dateFormat"`
+		Lid               *CTString `xml:"lid"`
+		StoreMappedDataAs *CTString `xml:"storeMappedDataAs"`
+		Calendar          *CTString `xml:"calendar"`
+	}
+	if err := d.DecodeElement(&date, &start); err != nil {
+		return err
+	}
+	s.DateFormat = date.DateFormat
+	s.Lid = date.Lid
+	s.StoreMappedDataAs = date.StoreMappedDataAs
+	s.Calendar = date.Calendar
+	return nil
+}
+
+func (c SdtCheckbox) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	checked := "0"
+	if c.Checked {
+		checked = "1"
+	}
+	checkedStart := xml.StartElement{
+		Name: xml.Name{Local: "w14:checked"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "w14:val"}, Value: checked}},
+	}
+	if err = e.EncodeElement("", checkedStart); err != nil {
+		return err
+	}
+
+	states := []struct {
+		name   string
+		symbol *SdtCheckboxSymbol
+	}{
+		{"w14:checkedState", c.CheckedState},
+		{"w14:uncheckedState", c.UncheckedState},
+	}
+	for _, state := range states {
+		if state.symbol == nil {
+			continue
+		}
+		stateStart := xml.StartElement{
+			Name: xml.Name{Local: state.name},
+			Attr: []xml.Attr{
+				{Name: xml.Name{Local: "w14:val"}, Value: state.symbol.Val},
+				{Name: xml.Name{Local: "w14:font"}, Value: state.symbol.Font},
+			},
+		}
+		if err = e.EncodeElement("", stateStart); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (c *SdtCheckbox) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var checkbox struct {
+		Checked *struct {
+			Val string `xml:"val,attr"`
+		} `xml:"checked"`
+		CheckedState   *SdtCheckboxSymbol `xml:"checkedState"`
+		UncheckedState *SdtCheckboxSymbol `xml:"uncheckedState"`
+	}
+	if err := d.DecodeElement(&checkbox, &start); err != nil {
+		return err
+	}
+
+	if checkbox.Checked != nil {
+		c.Checked = checkbox.Checked.Val == "1" || checkbox.Checked.Val == "true"
+	}
+	c.CheckedState = checkbox.CheckedState
+	c.UncheckedState = checkbox.UncheckedState
+	return nil
+}
+
+func (s *SdtCheckboxSymbol) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "val":
+			s.Val = attr.Value
+		case "font":
+			s.Font = attr.Value
+		}
+	}
+	return d.Skip()
+}
+
+// SdtBlock is a content control around paragraphs and tables.
+type SdtBlock struct {
+	Property       *SdtProperty
+	EndRunProperty *RunProperty // w:sdtEndPr, formatting of the end of the control
+
+	// Paragraphs, tables and nested controls; the block-level content model is that of a table cell
+	Content []TCBlockContent
+}
+
+// SdtRun is a content control around runs within a paragraph.
+type SdtRun struct {
+	Property       *SdtProperty
+	EndRunProperty *RunProperty
+	Content        []ParagraphChild
+}
+
+// SdtRow is a content control around table rows.
+type SdtRow struct {
+	Property       *SdtProperty
+	EndRunProperty *RunProperty
+	Content        []RowContent
+}
+
+// SdtCell is a content control around cells of a table row.
+type SdtCell struct {
+	Property       *SdtProperty
+	EndRunProperty *RunProperty
+	Content        []TRCellContent
+}
+
+func (s SdtBlock) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalSdt(e, s.Property, s.EndRunProperty, func() error {
+		for _, content := range s.Content {
+			if err := content.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SdtBlock) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalSdt(d, &s.Property, &s.EndRunProperty, func(elem xml.StartElement) error {
+		content := TCBlockContent{}
+		switch elem.Name.Local {
+		case "p":
+			content.Paragraph = &Paragraph{}
+			if err := d.DecodeElement(content.Paragraph, &elem); err != nil {
+				return err
+			}
+		case "tbl":
+			content.Table = &Table{}
+			if err := d.DecodeElement(content.Table, &elem); err != nil {
+				return err
+			}
+		case "sdt":
+			content.Sdt = &SdtBlock{}
+			if err := content.Sdt.UnmarshalXML(d, elem); err != nil {
+				return err
+			}
+		case "bookmarkStart", "bookmarkEnd":
+			content.RngMarkup = &RngMarkupElem{}
+			if err := content.RngMarkup.UnmarshalXML(d, elem); err != nil {
+				return err
+			}
+		default:
+			return d.Skip()
+		}
+		s.Content = append(s.Content, content)
+		return nil
+	})
+}
+
+func (s SdtRun) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalSdt(e, s.Property, s.EndRunProperty, func() error {
+		return marshalParagraphChildren(e, s.Content)
+	})
+}
+
+func (s *SdtRun) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalSdt(d, &s.Property, &s.EndRunProperty, func(elem xml.StartElement) error {
+		child, err := unmarshalParagraphChild(d, elem)
+		if err != nil {
+			return err
+		}
+		s.Content = append(s.Content, child)
+		return nil
+	})
+}
+
+func (s SdtRow) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalSdt(e, s.Property, s.EndRunProperty, func() error {
+		for _, content := range s.Content {
+			if err := content.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SdtRow) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalSdt(d, &s.Property, &s.EndRunProperty, func(elem xml.StartElement) error {
+		content, ok, err := unmarshalRowContent(d, elem)
+		if err != nil || !ok {
+			return err
+		}
+		s.Content = append(s.Content, content)
+		return nil
+	})
+}
+
+func (s SdtCell) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalSdt(e, s.Property, s.EndRunProperty, func() error {
+		for _, content := range s.Content {
+			if err := content.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SdtCell) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalSdt(d, &s.Property, &s.EndRunProperty, func(elem xml.StartElement) error {
+		content, ok, err := unmarshalCellContent(d, elem)
+		if err != nil || !ok {
+			return err
+		}
+		s.Content = append(s.Content, content)
+		return nil
+	})
+}
+
+// marshalSdt encodes a w:sdt element, with the content written by marshalContent.
+func marshalSdt(e *xml.Encoder, prop *SdtProperty, endProp *RunProperty, marshalContent func() error) (err error) {
+	start := xml.StartElement{Name: xml.Name{Local: "w:sdt"}}
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if prop != nil {
+		if err = prop.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	if endProp != nil {
+		endStart := xml.StartElement{Name: xml.Name{Local: "w:sdtEndPr"}}
+		if err = e.EncodeToken(endStart); err != nil {
+			return err
+		}
+		if err = endProp.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+		if err = e.EncodeToken(endStart.End()); err != nil {
+			return err
+		}
+	}
+
+	contentStart := xml.StartElement{Name: xml.Name{Local: "w:sdtContent"}}
+	if err = e.EncodeToken(contentStart); err != nil {
+		return err
+	}
+	if err = marshalContent(); err != nil {
+		return err
+	}
+	if err = e.EncodeToken(contentStart.End()); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// unmarshalSdt decodes the properties of a w:sdt element and calls unmarshalContent for each element of
+// its content.
+func unmarshalSdt(d *xml.Decoder, prop **SdtProperty, endProp **RunProperty, unmarshalContent func(elem xml.StartElement) error) error {
+	container := "" // w:sdtEndPr or w:sdtContent while decoding their children
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch {
+			case container == "" && elem.Name.Local == "sdtPr":
+				*prop = &SdtProperty{}
+				if err = (*prop).UnmarshalXML(d, elem); err != nil {
+					return err
+				}
+			case container == "" && (elem.Name.Local == "sdtEndPr" || elem.Name.Local == "sdtContent"):
+				container = elem.Name.Local
+			case container == "sdtEndPr" && elem.Name.Local == "rPr":
+				*endProp = &RunProperty{}
+				if err = d.DecodeElement(*endProp, &elem); err != nil {
+					return err
+				}
+			case container == "sdtContent":
+				if err = unmarshalContent(elem); err != nil {
+					return err
+				}
+			default:
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if container == "" {
+				return nil
+			}
+			container = ""
+		}
+	}
+}
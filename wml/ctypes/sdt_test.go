@@ -0,0 +1,119 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+func TestSdtBlock_RoundTrip(t *testing.T) {
+	input := `<w:sdt ` + rawTestNS + `><w:sdtPr>` +
+		`<w:rPr><w:b/></w:rPr><w:alias w:val="Start date"/><w:tag w:val="start"/><w:id w:val="-1425"/>` +
+		`<w:lock w:val="sdtLocked"/><w:placeholder><w:docPart w:val="DefaultPlaceholder"/></w:placeholder>` +
+		`<w:showingPlcHdr/><w:dataBinding w:xpath="/root/start" w:storeItemID="{0A1B}"/>` +
+		`<w:date w:fullDate="2024-05-01T00:00:00Z"><w:dateFormat w:val="d MMMM yyyy"/><w:lid w:val="en-GB"/></w:date>` +
+		`<w14:extra/>` +
+		`</w:sdtPr><w:sdtEndPr><w:rPr><w:i/></w:rPr></w:sdtEndPr>` +
+		`<w:sdtContent><w:p><w:r><w:t>1 May 2024</w:t></w:r></w:p></w:sdtContent></w:sdt>`
+
+	sdt := SdtBlock{}
+	if err := xml.Unmarshal([]byte(input), &sdt); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	prop := sdt.Property
+	if prop == nil || prop.Tag.Val != "start" || prop.Alias.Val != "Start date" || prop.ID.Val != -1425 {
+		t.Fatalf("Unexpected properties: %+v", prop)
+	}
+	if prop.Lock.Val != stypes.SdtLockSdtLocked || prop.Placeholder.DocPart != "DefaultPlaceholder" {
+		t.Errorf("Unexpected lock or placeholder: %+v", prop)
+	}
+	if prop.DataBinding == nil || prop.DataBinding.XPath != "/root/start" {
+		t.Errorf("Unexpected data binding: %+v", prop.DataBinding)
+	}
+	if prop.Date == nil || *prop.Date.FullDate != "2024-05-01T00:00:00Z" || prop.Date.DateFormat.Val != "d MMMM yyyy" {
+		t.Errorf("Unexpected date: %+v", prop.Date)
+	}
+	if sdt.EndRunProperty == nil || sdt.EndRunProperty.Italic == nil {
+		t.Errorf("Expected the end run properties")
+	}
+	if len(sdt.Content) != 1 || sdt.Content[0].Paragraph == nil {
+		t.Fatalf("Expected a paragraph as content")
+	}
+
+	expected := `<w:sdt><w:sdtPr>` +
+		`<w:rPr><w:b></w:b></w:rPr><w:alias w:val="Start date"></w:alias><w:tag w:val="start"></w:tag><w:id w:val="-1425"></w:id>` +
+		`<w:lock w:val="sdtLocked"></w:lock><w:placeholder><w:docPart w:val="DefaultPlaceholder"></w:docPart></w:placeholder>` +
+		`<w:showingPlcHdr></w:showingPlcHdr><w:dataBinding w:xpath="/root/start" w:storeItemID="{0A1B}"></w:dataBinding>` +
+		`<w:date w:fullDate="2024-05-01T00:00:00Z"><w:dateFormat w:val="d MMMM yyyy"></w:dateFormat><w:lid w:val="en-GB"></w:lid></w:date>` +
+		`<w14:extra></w14:extra>` +
+		`</w:sdtPr><w:sdtEndPr><w:rPr><w:i></w:i></w:rPr></w:sdtEndPr>` +
+		`<w:sdtContent><w:p><w:r><w:t>1 May 2024</w:t></w:r></w:p></w:sdtContent></w:sdt>`
+	if got := marshalToString(t, sdt); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestSdtRun_InParagraph(t *testing.T) {
+	input := `<w:p><w:r><w:t>Plan: </w:t></w:r><w:sdt><w:sdtPr><w:tag w:val="plan"/>` +
+		`<w:dropDownList w:lastValue="basic"><w:listItem w:displayText="Basic plan" w:value="basic"/></w:dropDownList>` +
+		`</w:sdtPr><w:sdtContent><w:r><w:t>Basic plan</w:t></w:r></w:sdtContent></w:sdt></w:p>`
+
+	p := Paragraph{}
+	if err := xml.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if len(p.Children) != 2 || p.Children[1].Sdt == nil {
+		t.Fatalf("Expected a run and a content control, got %+v", p.Children)
+	}
+	list := p.Children[1].Sdt.Property.DropDownList
+	if list == nil || *list.LastValue != "basic" || len(list.Items) != 1 || list.Items[0].DisplayText != "Basic plan" {
+		t.Errorf("Unexpected drop-down list: %+v", list)
+	}
+
+	expected := `<w:p><w:r><w:t>Plan: </w:t></w:r><w:sdt><w:sdtPr><w:tag w:val="plan"></w:tag>` +
+		`<w:dropDownList w:lastValue="basic"><w:listItem w:displayText="Basic plan" w:value="basic"></w:listItem></w:dropDownList>` +
+		`</w:sdtPr><w:sdtContent><w:r><w:t>Basic plan</w:t></w:r></w:sdtContent></w:sdt></w:p>`
+	if got := marshalToString(t, p); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestSdtRowAndCell_InTable(t *testing.T) {
+	input := `<w:tbl ` + rawTestNS + `><w:sdt><w:sdtPr><w:tag w:val="rows"/></w:sdtPr><w:sdtContent><w:tr>` +
+		`<w:sdt><w:sdtPr><w:tag w:val="cell"/><w14:checkbox><w14:checked w14:val="1"/></w14:checkbox></w:sdtPr>` +
+		`<w:sdtContent><w:tc><w:p/></w:tc></w:sdtContent></w:sdt>` +
+		`</w:tr></w:sdtContent></w:sdt></w:tbl>`
+
+	tbl := Table{}
+	if err := xml.Unmarshal([]byte(input), &tbl); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if len(tbl.RowContents) != 1 || tbl.RowContents[0].Sdt == nil {
+		t.Fatalf("Expected a row-level content control")
+	}
+	rows := tbl.RowContents[0].Sdt.Content
+	if len(rows) != 1 || rows[0].Row == nil || len(rows[0].Row.Contents) != 1 || rows[0].Row.Contents[0].Sdt == nil {
+		t.Fatalf("Expected a row holding a cell-level content control")
+	}
+	cell := rows[0].Row.Contents[0].Sdt
+	if cell.Property.Checkbox == nil || !cell.Property.Checkbox.Checked {
+		t.Errorf("Expected a checked checkbox")
+	}
+	if len(cell.Content) != 1 || cell.Content[0].Cell == nil {
+		t.Errorf("Expected a cell as content")
+	}
+
+	got := marshalToString(t, tbl)
+	for _, exp := range []string{
+		`<w:sdt><w:sdtPr><w:tag w:val="rows"></w:tag></w:sdtPr><w:sdtContent><w:tr>`,
+		`<w:sdt><w:sdtPr><w:tag w:val="cell"></w:tag><w14:checkbox><w14:checked w14:val="1"></w14:checked></w14:checkbox></w:sdtPr>`,
+		`<w:sdtContent><w:tc><w:p></w:p></w:tc></w:sdtContent></w:sdt></w:tr></w:sdtContent></w:sdt>`,
+	} {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected XML part not found:\nExpected part: %s\nActual XML: %s", exp, got)
+		}
+	}
+}
@@ -8,14 +8,15 @@ import (
 
 // Document Final Section Properties : w:sectPr
 type SectionProp struct {
-	HeaderReference *HeaderReference                       `xml:"headerReference,omitempty"`
-	FooterReference *FooterReference                       `xml:"footerReference,omitempty"`
+	HeaderReference []HeaderReference                      `xml:"headerReference,omitempty"`
+	FooterReference []FooterReference                      `xml:"footerReference,omitempty"`
 	PageSize        *PageSize                              `xml:"pgSz,omitempty"`
 	Type            *GenSingleStrVal[stypes.SectionMark]   `xml:"type,omitempty"`
 	PageMargin      *PageMargin                            `xml:"pgMar,omitempty"`
 	PageNum         *PageNumbering                         `xml:"pgNumType,omitempty"`
+	Cols            *Columns                               `xml:"cols,omitempty"`
 	FormProt        *GenSingleStrVal[stypes.OnOff]         `xml:"formProt,omitempty"`
-	TitlePg         *GenSingleStrVal[stypes.OnOff]         `xml:"titlePg,omitempty"`
+	TitlePg         *OnOff                                 `xml:"titlePg,omitempty"`
 	TextDir         *GenSingleStrVal[stypes.TextDirection] `xml:"textDirection,omitempty"`
 	DocGrid         *DocGrid                               `xml:"docGrid,omitempty"`
 }
@@ -32,14 +33,14 @@ func (s SectionProp) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
-	if s.HeaderReference != nil {
-		if err := s.HeaderReference.MarshalXML(e, xml.StartElement{}); err != nil {
+	for _, ref := range s.HeaderReference {
+		if err := ref.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
 
-	if s.FooterReference != nil {
-		if err := s.FooterReference.MarshalXML(e, xml.StartElement{}); err != nil {
+	for _, ref := range s.FooterReference {
+		if err := ref.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
@@ -70,6 +71,12 @@ func (s SectionProp) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		}
 	}
 
+	if s.Cols != nil {
+		if err = s.Cols.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
 	if s.FormProt != nil {
 		if err = s.FormProt.MarshalXML(e, xml.StartElement{
 			Name: xml.Name{Local: "w:formProt"},
@@ -87,7 +94,7 @@ func (s SectionProp) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	}
 
 	if s.TextDir != nil {
-		if s.TextDir.MarshalXML(e, xml.StartElement{
+		if err = s.TextDir.MarshalXML(e, xml.StartElement{
 			Name: xml.Name{Local: "w:textDirection"},
 		}); err != nil {
 			return err
@@ -95,10 +102,78 @@ func (s SectionProp) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	}
 
 	if s.DocGrid != nil {
-		if s.DocGrid.MarshalXML(e, xml.StartElement{}); err != nil {
+		if err = s.DocGrid.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
 
 	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
+
+// Clone returns a copy of the section properties that can be changed without affecting s.
+// Nested values are copied one level deep; they are replaced rather than modified in place
+// by the docx package.
+func (s SectionProp) Clone() *SectionProp {
+	c := s
+	c.HeaderReference = append([]HeaderReference(nil), s.HeaderReference...)
+	c.FooterReference = append([]FooterReference(nil), s.FooterReference...)
+	c.PageSize = clonePtr(s.PageSize)
+	c.Type = clonePtr(s.Type)
+	c.PageMargin = clonePtr(s.PageMargin)
+	c.PageNum = clonePtr(s.PageNum)
+	c.Cols = clonePtr(s.Cols)
+	if s.Cols != nil {
+		c.Cols.Col = append([]ColumnDef(nil), s.Cols.Col...)
+	}
+	c.FormProt = clonePtr(s.FormProt)
+	c.TitlePg = clonePtr(s.TitlePg)
+	c.TextDir = clonePtr(s.TextDir)
+	c.DocGrid = clonePtr(s.DocGrid)
+	return &c
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+// GetHeaderReference returns the reference to the header of the given type, or nil if the section has none.
+func (s *SectionProp) GetHeaderReference(hdrType stypes.HdrFtrType) *HeaderReference {
+	for i := range s.HeaderReference {
+		if s.HeaderReference[i].Type == hdrType {
+			return &s.HeaderReference[i]
+		}
+	}
+	return nil
+}
+
+// SetHeaderReference adds the reference, replacing an existing reference of the same type.
+func (s *SectionProp) SetHeaderReference(ref HeaderReference) {
+	if existing := s.GetHeaderReference(ref.Type); existing != nil {
+		*existing = ref
+		return
+	}
+	s.HeaderReference = append(s.HeaderReference, ref)
+}
+
+// GetFooterReference returns the reference to the footer of the given type, or nil if the section has none.
+func (s *SectionProp) GetFooterReference(ftrType stypes.HdrFtrType) *FooterReference {
+	for i := range s.FooterReference {
+		if s.FooterReference[i].Type == ftrType {
+			return &s.FooterReference[i]
+		}
+	}
+	return nil
+}
+
+// SetFooterReference adds the reference, replacing an existing reference of the same type.
+func (s *SectionProp) SetFooterReference(ref FooterReference) {
+	if existing := s.GetFooterReference(ref.Type); existing != nil {
+		*existing = ref
+		return
+	}
+	s.FooterReference = append(s.FooterReference, ref)
+}
@@ -18,8 +18,8 @@ func TestSectionProp_MarshalXML(t *testing.T) {
 		{
 			name: "All attributes",
 			input: SectionProp{
-				HeaderReference: &HeaderReference{Type: "default", ID: "rId1"},
-				FooterReference: &FooterReference{Type: "default", ID: "rId2"},
+				HeaderReference: []HeaderReference{{Type: "default", ID: "rId1"}},
+				FooterReference: []FooterReference{{Type: "default", ID: "rId2"}},
 				PageSize: &PageSize{
 					Width:  uint64Ptr(12240),
 					Height: uint64Ptr(15840),
@@ -27,12 +27,13 @@ func TestSectionProp_MarshalXML(t *testing.T) {
 				Type:       NewGenSingleStrVal(stypes.SectionMarkNextPage),
 				PageMargin: &PageMargin{Top: intPtr(1440), Bottom: intPtr(1440), Left: intPtr(1440), Right: intPtr(1440)},
 				PageNum:    &PageNumbering{Format: stypes.NumFmtDecimal},
+				Cols:       &Columns{Num: intPtr(2), Space: intPtr(720)},
 				FormProt:   NewGenSingleStrVal(stypes.OnOffTrue),
-				TitlePg:    NewGenSingleStrVal(stypes.OnOffTrue),
+				TitlePg:    OnOffFromBool(true),
 				TextDir:    NewGenSingleStrVal(stypes.TextDirectionLrTb),
 				DocGrid:    &DocGrid{Type: "default", LinePitch: intPtr(360)},
 			},
-			expected: `<w:sectPr><w:headerReference w:type="default" r:id="rId1"></w:headerReference><w:footerReference w:type="default" r:id="rId2"></w:footerReference><w:type w:val="nextPage"></w:type><w:pgSz w:w="12240" w:h="15840"></w:pgSz><w:pgMar w:left="1440" w:right="1440" w:top="1440" w:bottom="1440"></w:pgMar><w:pgNumType w:fmt="decimal"></w:pgNumType><w:formProt w:val="true"></w:formProt><w:titlePg w:val="true"></w:titlePg><w:textDirection w:val="lrTb"></w:textDirection><w:docGrid w:type="default" w:linePitch="360"></w:docGrid></w:sectPr>`,
+			expected: `<w:sectPr><w:headerReference w:type="default" r:id="rId1"></w:headerReference><w:footerReference w:type="default" r:id="rId2"></w:footerReference><w:type w:val="nextPage"></w:type><w:pgSz w:w="12240" w:h="15840"></w:pgSz><w:pgMar w:left="1440" w:right="1440" w:top="1440" w:bottom="1440"></w:pgMar><w:pgNumType w:fmt="decimal"></w:pgNumType><w:cols w:num="2" w:space="720"></w:cols><w:formProt w:val="true"></w:formProt><w:titlePg w:val="true"></w:titlePg><w:textDirection w:val="lrTb"></w:textDirection><w:docGrid w:type="default" w:linePitch="360"></w:docGrid></w:sectPr>`,
 		},
 		{
 			name:     "No attributes",
@@ -103,14 +104,15 @@ func TestSectionProp_UnmarshalXML(t *testing.T) {
 				<w:type w:val="nextPage"></w:type>
 				<w:pgMar w:top="1440" w:bottom="1440" w:left="1440" w:right="1440"></w:pgMar>
 				<w:pgNumType w:fmt="decimal"></w:pgNumType>
+				<w:cols w:num="2" w:space="720"></w:cols>
 				<w:formProt w:val="true"></w:formProt>
 				<w:titlePg w:val="true"></w:titlePg>
 				<w:textDirection w:val="lrTb"></w:textDirection>
 				<w:docGrid w:type="default" w:linePitch="360"></w:docGrid>
 			</w:sectPr>`,
 			expected: SectionProp{
-				HeaderReference: &HeaderReference{Type: "default", ID: "rId1"},
-				FooterReference: &FooterReference{Type: "default", ID: "rId2"},
+				HeaderReference: []HeaderReference{{Type: "default", ID: "rId1"}},
+				FooterReference: []FooterReference{{Type: "default", ID: "rId2"}},
 				PageSize: &PageSize{
 					Width:  uint64Ptr(12240),
 					Height: uint64Ptr(15840),
@@ -118,8 +120,9 @@ func TestSectionProp_UnmarshalXML(t *testing.T) {
 				Type:       NewGenSingleStrVal(stypes.SectionMarkNextPage),
 				PageMargin: &PageMargin{Top: intPtr(1440), Bottom: intPtr(1440), Left: intPtr(1440), Right: intPtr(1440)},
 				PageNum:    &PageNumbering{Format: stypes.NumFmtDecimal},
+				Cols:       &Columns{Num: intPtr(2), Space: intPtr(720)},
 				FormProt:   NewGenSingleStrVal(stypes.OnOffTrue),
-				TitlePg:    NewGenSingleStrVal(stypes.OnOffTrue),
+				TitlePg:    OnOffFromBool(true),
 				TextDir:    NewGenSingleStrVal(stypes.TextDirectionLrTb),
 				DocGrid:    &DocGrid{Type: "default", LinePitch: intPtr(360)},
 			},
@@ -156,6 +159,9 @@ func TestSectionProp_UnmarshalXML(t *testing.T) {
 			if !reflect.DeepEqual(result.PageMargin, tt.expected.PageMargin) {
 				t.Errorf("PageMargin mismatch\nExpected: %#v\nActual:   %#v", tt.expected.PageMargin, result.PageMargin)
 			}
+			if !reflect.DeepEqual(result.Cols, tt.expected.Cols) {
+				t.Errorf("Cols mismatch\nExpected: %#v\nActual:   %#v", tt.expected.Cols, result.Cols)
+			}
 			if !reflect.DeepEqual(result.PageNum, tt.expected.PageNum) {
 				t.Errorf("PageNum mismatch\nExpected: %#v\nActual:   %#v", tt.expected.PageNum, result.PageNum)
 			}
@@ -174,3 +180,69 @@ func TestSectionProp_UnmarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestSectionProp_MultipleReferences(t *testing.T) {
+	input := `<w:sectPr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:headerReference w:type="default" r:id="rId1"/>` +
+		`<w:headerReference w:type="first" r:id="rId2"/>` +
+		`<w:footerReference w:type="even" r:id="rId3"/>` +
+		`</w:sectPr>`
+
+	var sp SectionProp
+	if err := xml.Unmarshal([]byte(input), &sp); err != nil {
+		t.Fatalf("Error during unmarshaling: %v", err)
+	}
+
+	if len(sp.HeaderReference) != 2 || len(sp.FooterReference) != 1 {
+		t.Fatalf("Expected 2 header and 1 footer references, got %d and %d", len(sp.HeaderReference), len(sp.FooterReference))
+	}
+
+	if ref := sp.GetHeaderReference(stypes.HdrFtrFirst); ref == nil || ref.ID != "rId2" {
+		t.Errorf("Expected first header reference rId2, got %+v", ref)
+	}
+	if ref := sp.GetHeaderReference(stypes.HdrFtrEven); ref != nil {
+		t.Errorf("Expected no even header reference, got %+v", ref)
+	}
+
+	sp.SetHeaderReference(HeaderReference{Type: stypes.HdrFtrDefault, ID: "rId9"})
+	sp.SetFooterReference(FooterReference{Type: stypes.HdrFtrDefault, ID: "rId10"})
+
+	if len(sp.HeaderReference) != 2 || sp.GetHeaderReference(stypes.HdrFtrDefault).ID != "rId9" {
+		t.Errorf("Expected default header reference to be replaced, got %+v", sp.HeaderReference)
+	}
+	if len(sp.FooterReference) != 2 || sp.GetFooterReference(stypes.HdrFtrDefault).ID != "rId10" {
+		t.Errorf("Expected default footer reference to be added, got %+v", sp.FooterReference)
+	}
+
+	expected := `<w:sectPr>` +
+		`<w:headerReference w:type="default" r:id="rId9"></w:headerReference>` +
+		`<w:headerReference w:type="first" r:id="rId2"></w:headerReference>` +
+		`<w:footerReference w:type="even" r:id="rId3"></w:footerReference>` +
+		`<w:footerReference w:type="default" r:id="rId10"></w:footerReference>` +
+		`</w:sectPr>`
+	if got := marshalToString(t, sp); got != expected {
+		t.Errorf("XML mismatch\nExpected:\n%s\nActual:\n%s", expected, got)
+	}
+}
+
+func TestSectionProp_Clone(t *testing.T) {
+	orig := SectionProp{
+		HeaderReference: []HeaderReference{{Type: stypes.HdrFtrDefault, ID: "rId1"}},
+		PageSize:        &PageSize{Width: uint64Ptr(12240), Height: uint64Ptr(15840)},
+		Cols:            &Columns{Num: intPtr(2), Col: []ColumnDef{{Width: intPtr(100)}}},
+	}
+
+	c := orig.Clone()
+	if !reflect.DeepEqual(*c, orig) {
+		t.Fatalf("Clone differs from original\nExpected: %#v\nActual:   %#v", orig, *c)
+	}
+
+	c.HeaderReference[0].ID = "rId2"
+	c.PageSize.Orient = stypes.PageOrientLandscape
+	c.Cols.Col[0].Width = intPtr(200)
+
+	if orig.HeaderReference[0].ID != "rId1" || orig.PageSize.Orient != "" || *orig.Cols.Col[0].Width != 100 {
+		t.Errorf("Changing the clone modified the original: %#v", orig)
+	}
+}
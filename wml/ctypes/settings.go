@@ -0,0 +1,187 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"github.com/mrlijnden/godocx/wml/stypes"
+)
+
+var defaultSettingsNSAttrs = map[string]string{
+	"xmlns:w": "http://schemas.openxmlformats.org/wordprocessingml/2006/main",
+	"xmlns:r": "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+}
+
+// settingsOrder is the sequence in which the children of w:settings must appear.
+var settingsOrder = []string{
+	"w:writeProtection", "w:view", "w:zoom", "w:removePersonalInformation", "w:removeDateAndTime",
+	"w:doNotDisplayPageBoundaries", "w:displayBackgroundShape", "w:printPostScriptOverText",
+	"w:printFractionalCharacterWidth", "w:printFormsData", "w:embedTrueTypeFonts", "w:embedSystemFonts",
+	"w:saveSubsetFonts", "w:saveFormsData", "w:mirrorMargins", "w:alignBordersAndEdges",
+	"w:bordersDoNotSurroundHeader", "w:bordersDoNotSurroundFooter", "w:gutterAtTop", "w:hideSpellingErrors",
+	"w:hideGrammaticalErrors", "w:activeWritingStyle", "w:proofState", "w:formsDesign", "w:attachedTemplate",
+	"w:linkStyles", "w:stylePaneFormatFilter", "w:stylePaneSortMethod", "w:documentType", "w:mailMerge",
+	"w:revisionView", "w:trackRevisions", "w:doNotTrackMoves", "w:doNotTrackFormatting", "w:documentProtection",
+	"w:autoFormatOverride", "w:styleLockTheme", "w:styleLockQFSet", "w:defaultTabStop", "w:autoHyphenation",
+	"w:consecutiveHyphenLimit", "w:hyphenationZone", "w:doNotHyphenateCaps", "w:showEnvelope",
+	"w:summaryLength", "w:clickAndTypeStyle", "w:defaultTableStyle", "w:evenAndOddHeaders",
+	"w:bookFoldRevPrinting", "w:bookFoldPrinting", "w:bookFoldPrintingSheets",
+	"w:drawingGridHorizontalSpacing", "w:drawingGridVerticalSpacing", "w:displayHorizontalDrawingGridEvery",
+	"w:displayVerticalDrawingGridEvery", "w:doNotUseMarginsForDrawingGridOrigin",
+	"w:drawingGridHorizontalOrigin", "w:drawingGridVerticalOrigin", "w:doNotShadeFormData",
+	"w:noPunctuationKerning", "w:characterSpacingControl", "w:printTwoOnOne", "w:strictFirstAndLastChars",
+	"w:noLineBreaksAfter", "w:noLineBreaksBefore", "w:savePreviewPicture", "w:doNotValidateAgainstSchema",
+	"w:saveInvalidXml", "w:ignoreMixedContent", "w:alwaysShowPlaceholderText", "w:doNotDemarcateInvalidXml",
+	"w:saveXmlDataOnly", "w:useXSLTWhenSaving", "w:saveThroughXslt", "w:showXMLTags",
+	"w:alwaysMergeEmptyNamespace", "w:updateFields", "w:hdrShapeDefaults", "w:footnotePr", "w:endnotePr",
+	"w:compat", "w:docVars", "w:rsids", "m:mathPr", "w:attachedSchema", "w:themeFontLang",
+	"w:clrSchemeMapping", "w:doNotIncludeSubdocsInStats", "w:doNotAutoCompressPictures", "w:forceUpgrade",
+	"w:captions", "w:readModeInkLockDown", "w:smartTagType", "sl:schemaLibrary", "w:shapeDefaults",
+	"w:doNotEmbedSmartTags", "w:decimalSymbol", "w:listSeparator",
+}
+
+// Document Settings : w:settings
+//
+// The children are kept as read and in document order, so that settings the library does not
+// model survive a round trip. Individual settings are addressed by their prefixed element name,
+// e.g. "w:evenAndOddHeaders".
+type Settings struct {
+	RelativePath string `xml:"-"`
+	Attr         []xml.Attr
+	Children     []*RawXML
+}
+
+func NewSettings(relativePath string) *Settings {
+	return &Settings{RelativePath: relativePath}
+}
+
+func (s *Settings) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:settings"
+
+	if len(s.Attr) == 0 {
+		for key, value := range defaultSettingsNSAttrs {
+			attr := xml.Attr{Name: xml.Name{Local: key}, Value: value}
+			start.Attr = append(start.Attr, attr)
+		}
+	} else {
+		start.Attr = s.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, child := range s.Children {
+		if err := child.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+func (s *Settings) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s.Attr = QualifyAttrs(start.Attr)
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			raw := NewRawXML()
+			if err = raw.UnmarshalXML(d, elem); err != nil {
+				return err
+			}
+			s.Children = append(s.Children, raw)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Get returns the setting with the given element name, or nil if it is not present.
+func (s *Settings) Get(name string) *RawXML {
+	for _, child := range s.Children {
+		if child.Name() == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Set adds the setting, replacing an existing one with the same element name. New settings are
+// inserted at the position required by the schema.
+func (s *Settings) Set(setting *RawXML) {
+	name := setting.Name()
+	for i, child := range s.Children {
+		if child.Name() == name {
+			s.Children[i] = setting
+			return
+		}
+	}
+
+	pos := 0
+	if order := settingsIndex(name); order >= 0 {
+		for i, child := range s.Children {
+			if idx := settingsIndex(child.Name()); idx >= 0 && idx < order {
+				pos = i + 1
+			}
+		}
+	} else {
+		pos = len(s.Children)
+	}
+
+	s.Children = append(s.Children, nil)
+	copy(s.Children[pos+1:], s.Children[pos:])
+	s.Children[pos] = setting
+}
+
+// Remove deletes the setting with the given element name.
+func (s *Settings) Remove(name string) {
+	children := s.Children[:0]
+	for _, child := range s.Children {
+		if child.Name() != name {
+			children = append(children, child)
+		}
+	}
+	s.Children = children
+}
+
+// OnOff reports whether an on/off setting such as "w:evenAndOddHeaders" is enabled.
+func (s *Settings) OnOff(name string) bool {
+	setting := s.Get(name)
+	if setting == nil {
+		return false
+	}
+
+	val, ok := setting.Attr("w:val")
+	if !ok {
+		return true
+	}
+
+	onOff, err := stypes.OnOffFromStr(val)
+	if err != nil {
+		return false
+	}
+	return onOff.ToBool()
+}
+
+// SetOnOff enables or disables an on/off setting such as "w:evenAndOddHeaders".
+func (s *Settings) SetOnOff(name string, value bool) {
+	if !value {
+		s.Remove(name)
+		return
+	}
+	s.Set(NewRawElement(name))
+}
+
+func settingsIndex(name string) int {
+	for i, n := range settingsOrder {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
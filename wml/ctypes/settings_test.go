@@ -0,0 +1,92 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+const settingsTestXML = `<w:settings xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+	`<w:zoom w:percent="100"/>` +
+	`<w:defaultTabStop w:val="720"/>` +
+	`<w:compat><w:useFELayout/></w:compat>` +
+	`<w14:docId w14:val="24062061"/>` +
+	`</w:settings>`
+
+func TestSettings_RoundTrip(t *testing.T) {
+	var s Settings
+	if err := xml.Unmarshal([]byte(settingsTestXML), &s); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(s.Children) != 4 {
+		t.Fatalf("Expected 4 children, got %d", len(s.Children))
+	}
+
+	expected := `<w:settings xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">` +
+		`<w:zoom w:percent="100"></w:zoom>` +
+		`<w:defaultTabStop w:val="720"></w:defaultTabStop>` +
+		`<w:compat><w:useFELayout></w:useFELayout></w:compat>` +
+		`<w14:docId w14:val="24062061"></w14:docId>` +
+		`</w:settings>`
+	if got := marshalToString(t, &s); got != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestSettings_SetOnOff(t *testing.T) {
+	var s Settings
+	if err := xml.Unmarshal([]byte(settingsTestXML), &s); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	s.SetOnOff("w:evenAndOddHeaders", true)
+	s.SetOnOff("w:trackRevisions", true)
+	s.SetOnOff("w:updateFields", true)
+
+	names := []string{}
+	for _, child := range s.Children {
+		names = append(names, child.Name())
+	}
+
+	expected := []string{"w:zoom", "w:trackRevisions", "w:defaultTabStop", "w:evenAndOddHeaders", "w:updateFields", "w:compat", "w14:docId"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected children %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("Expected children %v, got %v", expected, names)
+		}
+	}
+
+	if !s.OnOff("w:evenAndOddHeaders") {
+		t.Errorf("Expected evenAndOddHeaders to be on")
+	}
+
+	s.SetOnOff("w:evenAndOddHeaders", false)
+	if s.OnOff("w:evenAndOddHeaders") || s.Get("w:evenAndOddHeaders") != nil {
+		t.Errorf("Expected evenAndOddHeaders to be removed")
+	}
+
+	s.Set(NewRawElement("w:evenAndOddHeaders", xml.Attr{Name: xml.Name{Local: "w:val"}, Value: "0"}))
+	if s.OnOff("w:evenAndOddHeaders") {
+		t.Errorf("Expected evenAndOddHeaders with w:val=0 to be off")
+	}
+}
+
+func TestSettings_DefaultNamespaces(t *testing.T) {
+	s := NewSettings("word/settings.xml")
+	s.SetOnOff("w:evenAndOddHeaders", true)
+
+	got := marshalToString(t, s)
+	for _, exp := range []string{
+		`xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"`,
+		`<w:evenAndOddHeaders></w:evenAndOddHeaders></w:settings>`,
+	} {
+		if !strings.Contains(got, exp) {
+			t.Errorf("Expected %s in %s", exp, got)
+		}
+	}
+}
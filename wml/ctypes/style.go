@@ -4,7 +4,6 @@ import (
 	"encoding/xml"
 	"fmt"
 
-	"github.com/mrlijnden/godocx/common/constants"
 	"github.com/mrlijnden/godocx/wml/stypes"
 )
 
@@ -73,28 +72,8 @@ func (s *Styles) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 }
 
 func (s *Styles) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
-	s.Attr = make([]xml.Attr, len(start.Attr))
-
-	for _, attr := range start.Attr {
-		// Broken Go xml - Handling the Docx namespace
-		//TODO: Centralize, generic function for document also
-		ns := attr.Name.Space
-		if ns != "xmlns" {
-			// Convert if it is namespace with http:// not the xmlns attribute
-			local, ok := constants.NSToLocal[ns]
-			ns = local
-			if !ok {
-				continue
-			}
-		}
+	s.Attr = QualifyAttrs(start.Attr)
 
-		s.Attr = append(s.Attr, xml.Attr{
-			Name: xml.Name{
-				Local: fmt.Sprintf("%s:%s", ns, attr.Name.Local),
-			},
-			Value: attr.Value,
-		})
-	}
 loop:
 	for {
 		currentToken, err := d.Token()
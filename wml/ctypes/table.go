@@ -63,6 +63,8 @@ func (t Table) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 }
 
 func (t *Table) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	seenProp := false // range markup before the table properties belongs to the table itself
+
 loop:
 	for {
 		currentToken, err := d.Token()
@@ -80,6 +82,7 @@ loop:
 				}
 
 				t.TableProp = prop
+				seenProp = true
 			case "tblGrid":
 				grid := Grid{}
 				if err = d.DecodeElement(&grid, &elem); err != nil {
@@ -87,20 +90,25 @@ loop:
 				}
 
 				t.Grid = grid
-			case "tr":
-				row := Row{}
-				if err = d.DecodeElement(&row, &elem); err != nil {
+			case "bookmarkStart", "bookmarkEnd":
+				rng := RngMarkupElem{}
+				if err = rng.UnmarshalXML(d, elem); err != nil {
 					return err
 				}
 
-				t.RowContents = append(t.RowContents, RowContent{
-					Row: &row,
-				})
-
+				if seenProp {
+					t.RowContents = append(t.RowContents, RowContent{RngMarkup: &rng})
+				} else {
+					t.RngMarkupElems = append(t.RngMarkupElems, rng)
+				}
 			default:
-				if err = d.Skip(); err != nil {
+				content, ok, err := unmarshalRowContent(d, elem)
+				if err != nil {
 					return err
 				}
+				if ok {
+					t.RowContents = append(t.RowContents, content)
+				}
 			}
 		case xml.EndElement:
 			break loop
@@ -0,0 +1,42 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// FtnEdn specifies the kind of a footnote or endnote.
+type FtnEdn string
+
+const (
+	FtnEdnNormal                FtnEdn = "normal"                // Normal Footnote/Endnote
+	FtnEdnSeparator             FtnEdn = "separator"             // Separator
+	FtnEdnContinuationSeparator FtnEdn = "continuationSeparator" // Continuation Separator
+	FtnEdnContinuationNotice    FtnEdn = "continuationNotice"    // Continuation Notice Separator
+)
+
+func FtnEdnFromStr(value string) (FtnEdn, error) {
+	switch value {
+	case "normal":
+		return FtnEdnNormal, nil
+	case "separator":
+		return FtnEdnSeparator, nil
+	case "continuationSeparator":
+		return FtnEdnContinuationSeparator, nil
+	case "continuationNotice":
+		return FtnEdnContinuationNotice, nil
+	default:
+		return "", errors.New("invalid FtnEdn value")
+	}
+}
+
+func (d *FtnEdn) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := FtnEdnFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+
+	*d = val
+
+	return nil
+}
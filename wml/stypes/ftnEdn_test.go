@@ -0,0 +1,100 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestFtnEdnFromStr_ValidValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected FtnEdn
+	}{
+		{"normal", FtnEdnNormal},
+		{"separator", FtnEdnSeparator},
+		{"continuationSeparator", FtnEdnContinuationSeparator},
+		{"continuationNotice", FtnEdnContinuationNotice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := FtnEdnFromStr(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFtnEdnFromStr_InvalidValue(t *testing.T) {
+	input := "invalidValue"
+
+	result, err := FtnEdnFromStr(input)
+
+	if err == nil {
+		t.Fatalf("Expected error for invalid value %s, but got none. Result: %s", input, result)
+	}
+
+	expectedError := "invalid FtnEdn value"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s' but got '%s'", expectedError, err.Error())
+	}
+}
+
+func TestFtnEdn_UnmarshalXMLAttr_ValidValues(t *testing.T) {
+	tests := []struct {
+		inputXML string
+		expected FtnEdn
+	}{
+		{`<element val="normal"></element>`, FtnEdnNormal},
+		{`<element val="separator"></element>`, FtnEdnSeparator},
+		{`<element val="continuationSeparator"></element>`, FtnEdnContinuationSeparator},
+		{`<element val="continuationNotice"></element>`, FtnEdnContinuationNotice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inputXML, func(t *testing.T) {
+			type Element struct {
+				XMLName xml.Name `xml:"element"`
+				Val     FtnEdn   `xml:"val,attr"`
+			}
+
+			var elem Element
+
+			err := xml.Unmarshal([]byte(tt.inputXML), &elem)
+			if err != nil {
+				t.Fatalf("Error unmarshaling XML: %v", err)
+			}
+
+			if elem.Val != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, elem.Val)
+			}
+		})
+	}
+}
+
+func TestFtnEdn_UnmarshalXMLAttr_InvalidValue(t *testing.T) {
+	inputXML := `<element val="invalidValue"></element>`
+
+	type Element struct {
+		XMLName xml.Name `xml:"element"`
+		Val     FtnEdn   `xml:"val,attr"`
+	}
+
+	var elem Element
+
+	err := xml.Unmarshal([]byte(inputXML), &elem)
+
+	if err == nil {
+		t.Fatalf("Expected error for invalid value, but got none")
+	}
+
+	expectedError := "invalid FtnEdn value"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s' but got '%s'", expectedError, err.Error())
+	}
+}
@@ -0,0 +1,39 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// LevelSuffix specifies the character placed between a numbering level's text and the paragraph text.
+type LevelSuffix string
+
+const (
+	LevelSuffixTab     LevelSuffix = "tab"     // Tab Character
+	LevelSuffixSpace   LevelSuffix = "space"   // Space
+	LevelSuffixNothing LevelSuffix = "nothing" // Nothing
+)
+
+func LevelSuffixFromStr(value string) (LevelSuffix, error) {
+	switch value {
+	case "tab":
+		return LevelSuffixTab, nil
+	case "space":
+		return LevelSuffixSpace, nil
+	case "nothing":
+		return LevelSuffixNothing, nil
+	default:
+		return "", errors.New("invalid LevelSuffix value")
+	}
+}
+
+func (d *LevelSuffix) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := LevelSuffixFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+
+	*d = val
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// MultiLevelType specifies the type of numbering defined by an abstract numbering definition.
+type MultiLevelType string
+
+const (
+	MultiLevelTypeSingleLevel      MultiLevelType = "singleLevel"      // Single Level Numbering Definition
+	MultiLevelTypeMultilevel       MultiLevelType = "multilevel"       // Multilevel Numbering Definition
+	MultiLevelTypeHybridMultilevel MultiLevelType = "hybridMultilevel" // Hybrid Multilevel Numbering Definition
+)
+
+func MultiLevelTypeFromStr(value string) (MultiLevelType, error) {
+	switch value {
+	case "singleLevel":
+		return MultiLevelTypeSingleLevel, nil
+	case "multilevel":
+		return MultiLevelTypeMultilevel, nil
+	case "hybridMultilevel":
+		return MultiLevelTypeHybridMultilevel, nil
+	default:
+		return "", errors.New("invalid MultiLevelType value")
+	}
+}
+
+func (d *MultiLevelType) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := MultiLevelTypeFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+
+	*d = val
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestMultiLevelTypeFromStr_ValidValues(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected MultiLevelType
+	}{
+		{"singleLevel", MultiLevelTypeSingleLevel},
+		{"multilevel", MultiLevelTypeMultilevel},
+		{"hybridMultilevel", MultiLevelTypeHybridMultilevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := MultiLevelTypeFromStr(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMultiLevelTypeFromStr_InvalidValue(t *testing.T) {
+	input := "invalidValue"
+
+	result, err := MultiLevelTypeFromStr(input)
+
+	if err == nil {
+		t.Fatalf("Expected error for invalid value %s, but got none. Result: %s", input, result)
+	}
+
+	expectedError := "invalid MultiLevelType value"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s' but got '%s'", expectedError, err.Error())
+	}
+}
+
+func TestMultiLevelType_UnmarshalXMLAttr_ValidValues(t *testing.T) {
+	tests := []struct {
+		inputXML string
+		expected MultiLevelType
+	}{
+		{`<element val="singleLevel"></element>`, MultiLevelTypeSingleLevel},
+		{`<element val="multilevel"></element>`, MultiLevelTypeMultilevel},
+		{`<element val="hybridMultilevel"></element>`, MultiLevelTypeHybridMultilevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.inputXML, func(t *testing.T) {
+			type Element struct {
+				XMLName xml.Name       `xml:"element"`
+				Val     MultiLevelType `xml:"val,attr"`
+			}
+
+			var elem Element
+
+			err := xml.Unmarshal([]byte(tt.inputXML), &elem)
+			if err != nil {
+				t.Fatalf("Error unmarshaling XML: %v", err)
+			}
+
+			if elem.Val != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, elem.Val)
+			}
+		})
+	}
+}
+
+func TestMultiLevelType_UnmarshalXMLAttr_InvalidValue(t *testing.T) {
+	inputXML := `<element val="invalidValue"></element>`
+
+	type Element struct {
+		XMLName xml.Name       `xml:"element"`
+		Val     MultiLevelType `xml:"val,attr"`
+	}
+
+	var elem Element
+
+	err := xml.Unmarshal([]byte(inputXML), &elem)
+
+	if err == nil {
+		t.Fatalf("Expected error for invalid value, but got none")
+	}
+
+	expectedError := "invalid MultiLevelType value"
+	if err.Error() != expectedError {
+		t.Errorf("Expected error message '%s' but got '%s'", expectedError, err.Error())
+	}
+}
@@ -50,3 +50,13 @@ func (d *OnOff) UnmarshalXMLAttr(attr xml.Attr) error {
 	return nil
 
 }
+
+// ToBool reports whether the value turns the property on.
+func (d OnOff) ToBool() bool {
+	switch d {
+	case OnOffOne, OnOffTrue, OnOffOn:
+		return true
+	default:
+		return false
+	}
+}
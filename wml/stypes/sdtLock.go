@@ -0,0 +1,45 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// SdtLock is the locking setting of a structured document tag (content control)
+type SdtLock string
+
+const (
+	SdtLockSdtLocked        SdtLock = "sdtLocked"        // The control cannot be deleted
+	SdtLockContentLocked    SdtLock = "contentLocked"    // The contents cannot be edited
+	SdtLockUnlocked         SdtLock = "unlocked"         // No locking
+	SdtLockSdtContentLocked SdtLock = "sdtContentLocked" // Neither the control nor its contents can be changed
+)
+
+func SdtLockFromStr(value string) (SdtLock, error) {
+	switch value {
+	case "sdtLocked":
+		return SdtLockSdtLocked, nil
+	case "contentLocked":
+		return SdtLockContentLocked, nil
+	case "unlocked":
+		return SdtLockUnlocked, nil
+	case "sdtContentLocked":
+		return SdtLockSdtContentLocked, nil
+	default:
+		return "", errors.New("Invalid SdtLock value")
+	}
+}
+
+func (l *SdtLock) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := SdtLockFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+	*l = val
+	return nil
+}
+
+// ContentLocked reports whether the contents of the control cannot be edited.
+func (l SdtLock) ContentLocked() bool {
+	return l == SdtLockContentLocked || l == SdtLockSdtContentLocked
+}
@@ -0,0 +1,52 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestSdtLockFromStr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SdtLock
+	}{
+		{"sdtLocked", SdtLockSdtLocked},
+		{"contentLocked", SdtLockContentLocked},
+		{"unlocked", SdtLockUnlocked},
+		{"sdtContentLocked", SdtLockSdtContentLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := SdtLockFromStr(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := SdtLockFromStr("locked"); err == nil {
+		t.Error("Expected error for invalid value")
+	}
+}
+
+func TestSdtLock_UnmarshalXMLAttr(t *testing.T) {
+	type Element struct {
+		XMLName xml.Name `xml:"element"`
+		Lock    SdtLock  `xml:"val,attr"`
+	}
+
+	var elem Element
+	if err := xml.Unmarshal([]byte(`<element val="contentLocked"></element>`), &elem); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if elem.Lock != SdtLockContentLocked {
+		t.Errorf("Expected %s but got %s", SdtLockContentLocked, elem.Lock)
+	}
+	if !elem.Lock.ContentLocked() || SdtLockSdtLocked.ContentLocked() {
+		t.Error("ContentLocked does not match the lock setting")
+	}
+}